@@ -0,0 +1,187 @@
+package bitmex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-numb/go-bitmex/decimal"
+)
+
+// Currency is a settlement currency, named after the smallest unit BitMEX
+// counts it in.
+type Currency string
+
+const (
+	XBt  Currency = "XBt"  // satoshi, 10^-8 XBT
+	USDt Currency = "USDt" // 10^-6 USDT
+	Gwei Currency = "Gwei" // 10^-9 ETH
+)
+
+var currencyUnits = map[Currency]struct {
+	name   string
+	places int
+}{
+	XBt:  {"XBT", 8},
+	USDt: {"USDT", 6},
+	Gwei: {"ETH", 9},
+}
+
+// Coin returns the whole coin of c, e.g. "XBT" for XBt, or c itself when unknown.
+func (c Currency) Coin() string {
+	if coin, ok := currencyUnits[c]; ok {
+		return coin.name
+	}
+	return string(c)
+}
+
+// Places returns the number of decimal places of a whole coin c counts, e.g.
+// 8 for XBt, and 0 when unknown.
+func (c Currency) Places() int {
+	return currencyUnits[c].places
+}
+
+// ErrCurrencyMismatch is returned when amounts of different currencies are
+// added, subtracted or compared.
+var ErrCurrencyMismatch = errors.New("bitmex: currency mismatch")
+
+// Amount is a sum of money in the smallest unit of its currency, as the
+// amounts of Margin, Wallet, Position and Transaction, e.g. 150000 XBt for
+// 0.0015 XBT. Amounts decoded from a model take the currency of the model,
+// the settlement currency of an Execution or an Instrument, and none for a
+// Trade or a TradeBin. The zero Amount has no currency and combines with any
+// amount.
+type Amount struct {
+	value    int64
+	currency Currency
+}
+
+// NewAmount returns value units of currency.
+func NewAmount(value int64, currency Currency) Amount {
+	return Amount{value: value, currency: currency}
+}
+
+// AmountFromCoins returns the amount of whole coins of currency, e.g. 150000
+// XBt for 0.0015. It fails when coins has more decimal places than currency
+// counts or does not fit.
+func AmountFromCoins(coins decimal.Decimal, currency Currency) (Amount, error) {
+	unit := int64(1)
+	for i := 0; i < currency.Places(); i++ {
+		unit *= 10
+	}
+	switch {
+	case coins.Abs().GreaterThan(decimal.NewFromInt(math.MaxInt64 / unit)):
+		return Amount{}, fmt.Errorf("bitmex: %s %s out of range", coins, currency.Coin())
+	case coins.Scale() > currency.Places():
+		return Amount{}, fmt.Errorf("bitmex: %s %s is not a whole number of %s", coins, currency.Coin(), currency)
+	}
+	return NewAmount(coins.Mul(decimal.NewFromInt(unit)).IntPart(), currency), nil
+}
+
+// Int64 returns a in the smallest unit of its currency.
+func (a Amount) Int64() int64 {
+	return a.value
+}
+
+// Currency returns the currency of a.
+func (a Amount) Currency() Currency {
+	return a.currency
+}
+
+// Coins returns a in whole coins, e.g. 0.0015 for 150000 XBt.
+func (a Amount) Coins() decimal.Decimal {
+	return decimal.New(a.value, a.currency.Places())
+}
+
+// IsZero reports whether a is 0.
+func (a Amount) IsZero() bool {
+	return a.value == 0
+}
+
+// Sign returns -1, 0 or +1 as a is negative, zero or positive.
+func (a Amount) Sign() int {
+	switch {
+	case a.value < 0:
+		return -1
+	case a.value > 0:
+		return 1
+	}
+	return 0
+}
+
+// Neg returns -a.
+func (a Amount) Neg() Amount {
+	return Amount{value: -a.value, currency: a.currency}
+}
+
+// Add returns a + b, or ErrCurrencyMismatch.
+func (a Amount) Add(b Amount) (Amount, error) {
+	c, err := a.common(b, "add")
+	return Amount{value: a.value + b.value, currency: c}, err
+}
+
+// Sub returns a - b, or ErrCurrencyMismatch.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	c, err := a.common(b, "subtract")
+	return Amount{value: a.value - b.value, currency: c}, err
+}
+
+// Cmp returns -1, 0 or +1 as a is less than, equal to or greater than b, or
+// ErrCurrencyMismatch.
+func (a Amount) Cmp(b Amount) (int, error) {
+	if _, err := a.common(b, "compare"); err != nil {
+		return 0, err
+	}
+	d, _ := a.Sub(b)
+	return d.Sign(), nil
+}
+
+// common returns the currency of a and b, the zero Amount taking the currency of the other.
+func (a Amount) common(b Amount, op string) (Currency, error) {
+	switch {
+	case a.currency == b.currency || b == Amount{}:
+		return a.currency, nil
+	case a == Amount{}:
+		return b.currency, nil
+	}
+	return "", fmt.Errorf("%w: can't %s %v and %v", ErrCurrencyMismatch, op, a, b)
+}
+
+// String returns a in whole coins with all the places of its currency, e.g.
+// "0.00150000 XBT", or in units when the currency is unknown, e.g. "150000 Foo".
+func (a Amount) String() string {
+	places := a.currency.Places()
+	switch {
+	case a.currency == "":
+		return strconv.FormatInt(a.value, 10)
+	case places == 0:
+		return strconv.FormatInt(a.value, 10) + " " + string(a.currency)
+	}
+	whole, frac, _ := strings.Cut(a.Coins().String(), ".")
+	return whole + "." + frac + strings.Repeat("0", places-len(frac)) + " " + a.currency.Coin()
+}
+
+// MarshalJSON implements json.Marshaler, encoding a as its number of units.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, a.value, 10), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. null is 0.
+func (a *Amount) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		a.value = 0
+		return nil
+	}
+	return json.Unmarshal(b, &a.value)
+}
+
+// setCurrency sets the currency of amounts, the amounts of a model decoded with
+// currency.
+func setCurrency(currency string, amounts ...*Amount) {
+	for _, a := range amounts {
+		a.currency = Currency(currency)
+	}
+}
@@ -0,0 +1,50 @@
+package bitmex_test
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/go-numb/go-bitmex"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmountFields(t *testing.T) {
+	// 2^31 and more, which don't fit the int of 32-bit targets.
+	for _, tt := range []struct {
+		name   string
+		json   string
+		row    interface{}
+		amount func(row interface{}) bitmex.Amount
+		want   bitmex.Amount
+	}{
+		{"execution cost", `{"settlCurrency":"XBt","currency":"USD","execCost":-2147483648}`, &bitmex.Execution{},
+			func(r interface{}) bitmex.Amount { return r.(*bitmex.Execution).ExecCost }, bitmex.NewAmount(-2147483648, bitmex.XBt)},
+		{"execution commission", `{"settlCurrency":"USDt","execComm":3000000000}`, &bitmex.Execution{},
+			func(r interface{}) bitmex.Amount { return r.(*bitmex.Execution).ExecComm }, bitmex.NewAmount(3000000000, bitmex.USDt)},
+		{"instrument turnover", `{"settlCurrency":"XBt","totalTurnover":1234567890123456}`, &bitmex.Instrument{},
+			func(r interface{}) bitmex.Amount { return r.(*bitmex.Instrument).TotalTurnover }, bitmex.NewAmount(1234567890123456, bitmex.XBt)},
+		{"instrument open value", `{"settlCurrency":"XBt","openValue":5000000000}`, &bitmex.Instrument{},
+			func(r interface{}) bitmex.Amount { return r.(*bitmex.Instrument).OpenValue }, bitmex.NewAmount(5000000000, bitmex.XBt)},
+		{"trade gross value", `{"grossValue":4294967296}`, &bitmex.Trade{},
+			func(r interface{}) bitmex.Amount { return r.(*bitmex.Trade).GrossValue }, bitmex.NewAmount(4294967296, "")},
+		{"trade bin turnover", `{"turnover":9007199254740993}`, &bitmex.TradeBin{},
+			func(r interface{}) bitmex.Amount { return r.(*bitmex.TradeBin).Turnover }, bitmex.NewAmount(9007199254740993, "")},
+		{"insurance", `{"currency":"XBt","walletBalance":7000000000000}`, &bitmex.Insurance{},
+			func(r interface{}) bitmex.Amount { return r.(*bitmex.Insurance).WalletBalance }, bitmex.NewAmount(7000000000000, bitmex.XBt)},
+		{"stats", `{"currency":"XBt","turnover24h":3000000000}`, &bitmex.Stats{},
+			func(r interface{}) bitmex.Amount { return r.(*bitmex.Stats).Turnover24h }, bitmex.NewAmount(3000000000, bitmex.XBt)},
+		{"affiliate", `{"currency":"XBt","totalTurnover":3000000000}`, &bitmex.Affiliate{},
+			func(r interface{}) bitmex.Amount { return r.(*bitmex.Affiliate).TotalTurnover }, bitmex.NewAmount(3000000000, bitmex.XBt)},
+	} {
+		if !assert.NoError(t, json.Unmarshal([]byte(tt.json), tt.row), tt.name) {
+			continue
+		}
+		assert.Equal(t, tt.want, tt.amount(tt.row), tt.name)
+
+		b, err := json.Marshal(tt.row)
+		assert.NoError(t, err, tt.name)
+		assert.Contains(t, string(b), ":"+strconv.FormatInt(tt.want.Int64(), 10), tt.name)
+	}
+}
@@ -1,40 +1,78 @@
 package bitmex
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 )
 
 const (
 	// APIREMAIN is API Limit initial number
 	APIREMAIN = 60 // par 60sec
+	// APIREMAINPUBLIC is API Limit initial number for unauthenticated requests
+	APIREMAINPUBLIC = 30 // par 60sec
 )
 
+// RateLimitPolicy decides what the client does before sending a request
+// while the request budget is spent.
+type RateLimitPolicy int
+
+const (
+	// RateLimitBlock waits until the budget resets or the context is done.
+	RateLimitBlock RateLimitPolicy = iota
+	// RateLimitFailFast returns a *RateLimitError without sending the request.
+	RateLimitFailFast
+	// RateLimitOff only records the headers and never holds a request back.
+	RateLimitOff
+)
+
+// RateLimitError is returned when a request is held back by the client side limiter.
+type RateLimitError struct {
+	Remain int
+	Reset  time.Time
+	Wait   time.Duration // time until the request could have been sent
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("api limit, has API Limit Remain:%d, Reset time: %s, retry in %s",
+		e.Remain, e.Reset.Format("15:04:05"), e.Wait)
+}
+
+// Is reports whether target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
 // Limit is API Limit struct
+// A Limit is safe for concurrent use; read the fields through Status when it
+// is shared between goroutines.
 type Limit struct {
+	mu sync.Mutex
+
 	Wait   int       // 429 too much request時の待機時間
 	Limit  int       // Limit is resets count
 	Remain int       // Remain is 残Requests
 	Reset  time.Time // Reset Remainの詳細時間(sec未満なし)
+
+	retryAt time.Time // no request may be sent before this time (Retry-After)
 }
 
 // NewLimit is API Limit
+// Authenticated requests are counted against the API key, public ones against the IP.
 func NewLimit(isPrivate bool) *Limit {
+	limit := APIREMAINPUBLIC
 	if isPrivate {
-		return &Limit{
-			Wait:   0,
-			Limit:  APIREMAIN,
-			Remain: APIREMAIN,
-			Reset:  time.Now().Add(time.Minute),
-		}
+		limit = APIREMAIN
 	}
 
 	return &Limit{
 		Wait:   0,
-		Limit:  APIREMAIN,
-		Remain: APIREMAIN,
+		Limit:  limit,
+		Remain: limit,
 		Reset:  time.Now().Add(time.Minute),
 	}
 }
@@ -42,9 +80,13 @@ func NewLimit(isPrivate bool) *Limit {
 // FromHeader X-xxxからLimitを取得
 // If you are limited, you will receive a 429 response and an additional header, Retry-After, that indicates the number of seconds you should sleep before retrying.
 func (p *Limit) FromHeader(h http.Header) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	wait := h.Get("Retry-After") // リセット後の残回数
 	if wait != "" {
 		p.Wait, _ = strconv.Atoi(wait)
+		p.retryAt = time.Now().Add(time.Duration(p.Wait) * time.Second)
 	} else {
 		p.Wait = 0
 	}
@@ -65,19 +107,80 @@ func (p *Limit) FromHeader(h http.Header) {
 
 // Check is checks remain number
 func (p *Limit) Check() error {
-	if p.Remain <= 0 {
-		if time.Now().After(p.Reset) { // APIRESET時間を過ぎていたらRemainを補充
-			p.Remain = p.Limit
-		}
-		return fmt.Errorf("api limit, has API Limit Remain:%d, Reset time: %s(%s)",
-			p.Remain,
-			p.Reset.Format("15:04:05"),
-			time.Now().Format("15:04:05"))
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if wait := p.wait(time.Now(), 1); wait > 0 {
+		return &RateLimitError{Remain: p.Remain, Reset: p.Reset, Wait: wait}
 	}
 	return nil
 }
 
+// Status returns a consistent snapshot of the budget.
+func (p *Limit) Status() (limit, remain int, reset time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.Limit, p.Remain, p.Reset
+}
+
+// Take reserves weight requests from the budget. With block set it waits until
+// the budget allows the request or ctx is done, otherwise it returns a
+// *RateLimitError as soon as the budget is spent.
+func (p *Limit) Take(ctx context.Context, weight int, block bool) error {
+	for {
+		p.mu.Lock()
+		wait := p.wait(time.Now(), weight)
+		if wait <= 0 {
+			p.Remain -= weight
+			p.mu.Unlock()
+			return nil
+		}
+		err := &RateLimitError{Remain: p.Remain, Reset: p.Reset, Wait: wait}
+		p.mu.Unlock()
+
+		if !block {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// wait returns how long a request of the given weight has to be held back.
+// Callers must hold p.mu.
+func (p *Limit) wait(now time.Time, weight int) time.Duration {
+	if now.Before(p.retryAt) {
+		return p.retryAt.Sub(now)
+	}
+	if !now.Before(p.Reset) { // APIRESET時間を過ぎていたらRemainを補充
+		p.Remain = p.Limit
+		p.Reset = now.Add(time.Minute)
+	}
+	// A request heavier than the whole budget goes out once the budget is full.
+	if p.Remain < weight && p.Remain < p.Limit {
+		return p.Reset.Sub(now)
+	}
+	return 0
+}
+
 // int64 to time.Time
 func (p *Limit) toTime(t int64) {
 	p.Reset = time.Unix(t, 10)
 }
+
+// bulkWeight is the cost of a bulk request carrying the JSON array orders,
+// which BitMEX charges at ceil(0.1 * orders).
+func bulkWeight(orders string) int {
+	var v []json.RawMessage
+	if err := json.Unmarshal([]byte(orders), &v); err != nil || len(v) == 0 {
+		return 1
+	}
+	return (len(v) + 9) / 10
+}
@@ -0,0 +1,191 @@
+package bitmex_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitTake(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		limit   int
+		remain  int
+		reset   time.Duration // from now
+		weight  int
+		block   bool
+		timeout time.Duration // of ctx, none when zero
+		err     error
+		remains int // after Take
+	}{
+		{name: "within budget", limit: 60, remain: 10, reset: time.Hour, weight: 1, remains: 9},
+		{name: "whole budget", limit: 60, remain: 10, reset: time.Hour, weight: 10, remains: 0},
+		{name: "spent, fail fast", limit: 60, remain: 0, reset: time.Hour, weight: 1, err: bitmex.ErrRateLimited},
+		{name: "too heavy, fail fast", limit: 60, remain: 3, reset: time.Hour, weight: 4, err: bitmex.ErrRateLimited, remains: 3},
+		{name: "heavier than a full budget", limit: 2, remain: 2, reset: time.Hour, weight: 3, remains: -1},
+		{name: "spent, blocking until the reset", limit: 60, remain: 0, reset: 50 * time.Millisecond, weight: 2, block: true, remains: 58},
+		{name: "reset passed", limit: 60, remain: 0, reset: -time.Second, weight: 1, remains: 59},
+		{name: "canceled while blocked", limit: 60, remain: 0, reset: time.Hour, weight: 1, block: true,
+			timeout: 20 * time.Millisecond, err: context.DeadlineExceeded},
+	} {
+		l := &bitmex.Limit{Limit: tt.limit, Remain: tt.remain, Reset: time.Now().Add(tt.reset)}
+		ctx := context.Background()
+		if tt.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+			defer cancel()
+		}
+
+		start := time.Now()
+		err := l.Take(ctx, tt.weight, tt.block)
+		_, remain, _ := l.Status()
+		if tt.err != nil {
+			assert.ErrorIs(t, err, tt.err, tt.name)
+			assert.Equal(t, tt.remain, remain, tt.name)
+			var limitErr *bitmex.RateLimitError
+			if errors.As(err, &limitErr) {
+				assert.Greater(t, limitErr.Wait, 59*time.Minute, tt.name)
+			}
+			continue
+		}
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.remains, remain, tt.name)
+		if tt.block && tt.reset > 0 {
+			assert.GreaterOrEqual(t, time.Since(start), tt.reset-5*time.Millisecond, tt.name)
+		}
+	}
+}
+
+func TestLimitConcurrent(t *testing.T) {
+	l := &bitmex.Limit{Limit: 60, Remain: 10, Reset: time.Now().Add(time.Hour)}
+	var taken, refused int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.Take(context.Background(), 1, false); err != nil {
+				atomic.AddInt32(&refused, 1)
+				return
+			}
+			atomic.AddInt32(&taken, 1)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(10), taken)
+	assert.Equal(t, int32(40), refused)
+	_, remain, _ := l.Status()
+	assert.Equal(t, 0, remain)
+
+	// Blocked takers all go through once the budget resets.
+	l = &bitmex.Limit{Limit: 5, Remain: 0, Reset: time.Now().Add(30 * time.Millisecond)}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, l.Take(context.Background(), 1, true))
+		}()
+	}
+	wg.Wait()
+	_, remain, _ = l.Status()
+	assert.Equal(t, 0, remain)
+}
+
+func TestLimitFromHeader(t *testing.T) {
+	reset := time.Now().Add(30 * time.Second).Truncate(time.Second)
+	l := bitmex.NewLimit(true)
+	h := http.Header{}
+	h.Set("x-ratelimit-limit", "120")
+	h.Set("x-ratelimit-remaining", "0")
+	h.Set("x-ratelimit-reset", strconv.FormatInt(reset.Unix(), 10))
+	l.FromHeader(h)
+	limit, remain, got := l.Status()
+	assert.Equal(t, 120, limit)
+	assert.Equal(t, 0, remain)
+	assert.WithinDuration(t, reset, got, time.Millisecond)
+	err := l.Check()
+	assert.ErrorIs(t, err, bitmex.ErrRateLimited)
+
+	// The next response resynchronises the budget.
+	h.Set("x-ratelimit-remaining", "119")
+	l.FromHeader(h)
+	assert.NoError(t, l.Check())
+
+	// A Retry-After holds every request back, whatever the budget.
+	h.Set("Retry-After", "2")
+	l.FromHeader(h)
+	var limitErr *bitmex.RateLimitError
+	if assert.ErrorAs(t, l.Check(), &limitErr) {
+		assert.InDelta(t, 2*time.Second, limitErr.Wait, float64(100*time.Millisecond))
+		assert.Equal(t, 119, limitErr.Remain)
+	}
+	assert.Equal(t, 2, l.Wait)
+}
+
+func TestLimitClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+	cfg := bitmex.NewConfiguration()
+	cfg.BasePath = srv.URL
+	client := bitmex.NewAPIClient(cfg)
+
+	// Bulk requests are charged ceil(0.1 * orders).
+	for _, tt := range []struct {
+		orders int
+		weight int
+	}{
+		{0, 1},
+		{1, 1},
+		{10, 1},
+		{11, 2},
+		{25, 3},
+		{100, 10},
+	} {
+		key := "bulk" + strconv.Itoa(tt.orders)
+		rows := strings.TrimSuffix(strings.Repeat(`{"symbol":"XBTUSD","orderQty":1,"clOrdID":"x"},`, tt.orders), ",")
+		var opts bitmex.OrderNewBulkOpts
+		opts.Orders.Set("[" + rows + "]")
+		_, _, err := client.OrderApi.OrderNewBulk(bitmex.NewAPIKeyContext(key, "secret"), &opts)
+		assert.NoError(t, err)
+		_, remain, _ := client.KeyLimit(key).Status()
+		assert.Equal(t, bitmex.APIREMAIN-tt.weight, remain, "%d orders", tt.orders)
+	}
+
+	// Each key has its budget, the first one that of Limit(true), and
+	// anonymous requests another.
+	_, _, err := client.OrderApi.OrderGetOrders(bitmex.NewAPIKeyContext("bulk0", "secret"), nil)
+	assert.NoError(t, err)
+	_, remain, _ := client.KeyLimit("bulk0").Status()
+	assert.Equal(t, bitmex.APIREMAIN-2, remain)
+	assert.Same(t, client.KeyLimit("bulk0"), client.Limit(true))
+	assert.NotSame(t, client.KeyLimit("bulk1"), client.Limit(true))
+	_, _, err = client.InstrumentApi.InstrumentGetActive(context.Background())
+	assert.NoError(t, err)
+	_, remain, _ = client.Limit(false).Status()
+	assert.Equal(t, bitmex.APIREMAINPUBLIC-1, remain)
+
+	// The budget of Configuration.Signer is that of Limit(true).
+	cfg = bitmex.NewConfiguration()
+	cfg.BasePath = srv.URL
+	cfg.Signer = bitmex.NewHMACSigner("signer", "secret")
+	client = bitmex.NewAPIClient(cfg)
+	_, _, err = client.OrderApi.OrderGetOrders(bitmex.NewAPIKeyContext("other", "secret"), nil)
+	assert.NoError(t, err)
+	_, remain, _ = client.Limit(true).Status()
+	assert.Equal(t, bitmex.APIREMAIN, remain)
+	assert.Same(t, client.KeyLimit("signer"), client.Limit(true))
+}
@@ -0,0 +1,277 @@
+// Package backfill downloads TradeBin history into local files and keeps them
+// complete: a run resumes where a file ends, drops duplicate buckets and
+// fetches the buckets missing in between, and only those.
+//
+//	store, _ := backfill.OpenStore("XBTUSD_4h.csv")
+//	report, err := backfill.Run(ctx, client, backfill.Job{
+//		Symbol:  "XBTUSD",
+//		BinSize: "4h",
+//		Start:   time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+//		Store:   store,
+//	}, nil)
+//
+// BitMEX serves 1m, 5m, 1h and 1d bins. Other sizes that are a multiple of one
+// of them, e.g. 15m, 4h or 1w, are derived from the largest one they are a
+// multiple of. As on BitMEX, a bin is stamped with the time it closes.
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+	"github.com/go-numb/go-bitmex/decimal"
+)
+
+// nativeSizes are the bin sizes served by TradeGetBucketed, largest first.
+var nativeSizes = []struct {
+	name string
+	size time.Duration
+}{
+	{"1d", 24 * time.Hour},
+	{"1h", time.Hour},
+	{"5m", 5 * time.Minute},
+	{"1m", time.Minute},
+}
+
+// Job describes the bins to keep in a Store.
+type Job struct {
+	Symbol string
+	// BinSize is a native size, 1m, 5m, 1h or 1d, or a multiple of one, e.g. "4h" or "1w".
+	BinSize string
+	// Start is the first bin to hold. It may be zero when the store is not empty.
+	Start time.Time
+	// End is the last bin to hold, the last closed bin when zero.
+	End time.Time
+	// Store holds the bins.
+	Store Store
+}
+
+// Report sums up a run.
+type Report struct {
+	Existing   int       // bins read from the store
+	Duplicates int       // duplicate bins dropped
+	Missing    int       // bins missing before the run
+	Fetched    int       // bins fetched and written
+	Gaps       []Gap     // ranges still missing after the run
+	Requests   int       // TradeGetBucketed requests sent
+	First      time.Time // first bin of the range
+	Last       time.Time // last bin of the range
+}
+
+// Gap is a range of missing bins, from the bin First to the bin Last.
+type Gap struct {
+	First, Last time.Time
+}
+
+func (g Gap) String() string {
+	return g.First.Format(time.RFC3339) + " - " + g.Last.Format(time.RFC3339)
+}
+
+// ParseBinSize parses a bin size such as "5m", "4h", "1d" or "1w".
+func ParseBinSize(s string) (time.Duration, error) {
+	units := map[string]time.Duration{"m": time.Minute, "h": time.Hour, "d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	if len(s) >= 2 {
+		if unit, ok := units[s[len(s)-1:]]; ok {
+			if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && n > 0 {
+				return time.Duration(n) * unit, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("backfill: invalid bin size %q", s)
+}
+
+// source returns the native bin size size is derived from.
+func source(size time.Duration) (string, time.Duration, error) {
+	for _, n := range nativeSizes {
+		if size%n.size == 0 {
+			return n.name, n.size, nil
+		}
+	}
+	return "", 0, fmt.Errorf("backfill: bin size %v is not a multiple of 1m", size)
+}
+
+// Run brings the store of job up to date and returns what it found and did.
+// The bins are written back once all gaps have been tried, so an error leaves
+// the store as it was. Progress is logged to l when not nil.
+func Run(ctx context.Context, client *bitmex.APIClient, job Job, l *log.Logger) (Report, error) {
+	var report Report
+	if l == nil {
+		l = log.New(io.Discard, "", 0)
+	}
+	size, err := ParseBinSize(job.BinSize)
+	if err != nil {
+		return report, err
+	}
+	native, nativeSize, err := source(size)
+	if err != nil {
+		return report, err
+	}
+
+	bins, err := job.Store.Read()
+	if err != nil {
+		return report, err
+	}
+	report.Existing = len(bins)
+	bins, report.Duplicates = dedupe(bins)
+
+	// bins are stamped with their close time
+	first := ceil(job.Start, size)
+	if job.Start.IsZero() {
+		if len(bins) == 0 {
+			return report, fmt.Errorf("backfill: %s %s: start time required for an empty store", job.Symbol, job.BinSize)
+		}
+		first = bins[0].Timestamp
+	}
+	last := job.End.Truncate(size)
+	if job.End.IsZero() {
+		last = client.Clock().Now().Truncate(size)
+	}
+	report.First, report.Last = first, last
+
+	gaps := findGaps(bins, first, last, size)
+	for _, g := range gaps {
+		report.Missing += int(g.Last.Sub(g.First)/size) + 1
+	}
+	if len(gaps) > 0 {
+		l.Printf("%s %s: %d bins missing in %d gaps", job.Symbol, job.BinSize, report.Missing, len(gaps))
+	}
+
+	var fetched []bitmex.TradeBin
+	for _, g := range gaps {
+		var opts bitmex.TradeGetBucketedOpts
+		opts.BinSize.Set(native)
+		opts.Symbol.Set(job.Symbol)
+		opts.StartTime.Set(g.First.Add(nativeSize - size))
+		opts.EndTime.Set(g.Last)
+
+		it := client.TradeApi.TradeBucketedIter(ctx, &opts)
+		rows, err := it.All()
+		report.Requests += it.Pages()
+		if err != nil {
+			return report, fmt.Errorf("backfill: %s %s: gap %v: %w", job.Symbol, job.BinSize, g, err)
+		}
+		if size != nativeSize {
+			rows = aggregate(rows, size, nativeSize)
+		}
+		l.Printf("%s %s: gap %v: %d bins fetched", job.Symbol, job.BinSize, g, len(rows))
+		fetched = append(fetched, rows...)
+	}
+
+	if len(fetched) > 0 || report.Duplicates > 0 {
+		bins, _ = dedupe(append(bins, fetched...))
+		if err := job.Store.Write(bins); err != nil {
+			return report, err
+		}
+	}
+	report.Fetched = len(fetched)
+	report.Gaps = findGaps(bins, first, last, size)
+	return report, nil
+}
+
+// dedupe sorts bins by time and drops the bins of a time seen already.
+func dedupe(bins []bitmex.TradeBin) ([]bitmex.TradeBin, int) {
+	sort.SliceStable(bins, func(i, j int) bool { return bins[i].Timestamp.Before(bins[j].Timestamp) })
+	out := bins[:0]
+	for _, b := range bins {
+		if len(out) > 0 && out[len(out)-1].Timestamp.Equal(b.Timestamp) {
+			continue
+		}
+		out = append(out, b)
+	}
+	return out, len(bins) - len(out)
+}
+
+// findGaps returns the ranges of bins of size from first to last missing in
+// bins, sorted without duplicates.
+func findGaps(bins []bitmex.TradeBin, first, last time.Time, size time.Duration) []Gap {
+	var gaps []Gap
+	next := first
+	for _, b := range bins {
+		t := b.Timestamp
+		if t.Before(next) {
+			continue
+		}
+		if t.After(last) {
+			break
+		}
+		if t.After(next) {
+			gaps = append(gaps, Gap{First: next, Last: t.Add(-size)})
+		}
+		next = t.Add(size)
+	}
+	if !next.After(last) {
+		gaps = append(gaps, Gap{First: next, Last: last})
+	}
+	return gaps
+}
+
+// aggregate merges bins of the native size into bins of size, keeping only
+// the bins with all of their native bins.
+func aggregate(rows []bitmex.TradeBin, size, native time.Duration) []bitmex.TradeBin {
+	per := int(size / native)
+	var out []bitmex.TradeBin
+	var cur bitmex.TradeBin
+	n := 0
+	for _, r := range rows {
+		t := ceil(r.Timestamp, size)
+		if n > 0 && !t.Equal(cur.Timestamp) {
+			if n == per {
+				out = append(out, cur)
+			}
+			n = 0
+		}
+		if n == 0 {
+			cur = r
+			cur.Timestamp = t
+		} else {
+			cur.High = bitmex.FromDecimal(decimal.Max(dec(cur.High), dec(r.High)))
+			cur.Low = bitmex.FromDecimal(decimal.Min(dec(cur.Low), dec(r.Low)))
+			cur.Close = r.Close
+			cur.Trades += r.Trades
+			cur.Volume += r.Volume
+			cur.LastSize = r.LastSize
+			cur.Turnover, _ = cur.Turnover.Add(r.Turnover) // bins have no currency
+			cur.HomeNotional = bitmex.FromDecimal(dec(cur.HomeNotional).Add(dec(r.HomeNotional)))
+			cur.ForeignNotional = bitmex.FromDecimal(dec(cur.ForeignNotional).Add(dec(r.ForeignNotional)))
+		}
+		if home := dec(cur.HomeNotional); !home.IsZero() {
+			cur.Vwap = bitmex.FromDecimal(dec(cur.ForeignNotional).Div(home, vwapPlaces))
+		}
+		n++
+	}
+	if n == per {
+		out = append(out, cur)
+	}
+	return out
+}
+
+// vwapPlaces are the decimal places of the vwap of derived bins.
+const vwapPlaces = 8
+
+// dec returns v as a decimal.Decimal, whether the bitmex package is built with
+// decimal or float prices.
+func dec(v bitmex.Decimal) decimal.Decimal {
+	return bitmex.ToDecimal(v)
+}
+
+// ceil rounds t up to a multiple of size.
+func ceil(t time.Time, size time.Duration) time.Time {
+	c := t.Truncate(size)
+	if c.Before(t) {
+		c = c.Add(size)
+	}
+	return c
+}
+
+// FileName returns the conventional name of the file holding the bins of
+// symbol and binSize in format, e.g. "XBTUSD_4h.csv".
+func FileName(symbol, binSize, format string) string {
+	return fmt.Sprintf("%s_%s.%s", symbol, binSize, strings.TrimPrefix(format, "."))
+}
@@ -0,0 +1,194 @@
+package backfill_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+	"github.com/go-numb/go-bitmex/backfill"
+	"github.com/go-numb/go-bitmex/bitmextest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var t0 = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// hourly returns the 1h bin closing i hours after t0, with a vwap of 100+i.
+func hourly(i int) bitmex.TradeBin {
+	p := float64(100 + i)
+	return bitmex.TradeBin{
+		Timestamp:       t0.Add(time.Duration(i) * time.Hour),
+		Symbol:          "XBTUSD",
+		Open:            bitmex.FromFloat(p),
+		High:            bitmex.FromFloat(p + 10),
+		Low:             bitmex.FromFloat(p - 10),
+		Close:           bitmex.FromFloat(p + 1),
+		Trades:          i,
+		Volume:          100 * i,
+		Vwap:            bitmex.FromFloat(p),
+		LastSize:        i,
+		Turnover:        bitmex.NewAmount(int64(1000*i), ""),
+		HomeNotional:    bitmex.FromFloat(float64(i)),
+		ForeignNotional: bitmex.FromFloat(p * float64(i)),
+	}
+}
+
+// hours returns the bins of hourly for the hours from first to last, but the
+// ones skipped.
+func hours(first, last int, skip ...int) []bitmex.TradeBin {
+	var bins []bitmex.TradeBin
+next:
+	for i := first; i <= last; i++ {
+		for _, s := range skip {
+			if i == s {
+				continue next
+			}
+		}
+		bins = append(bins, hourly(i))
+	}
+	return bins
+}
+
+func at(h int) time.Time {
+	return t0.Add(time.Duration(h) * time.Hour)
+}
+
+func TestRun(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		served  []bitmex.TradeBin // 1h bins of the server
+		stored  []bitmex.TradeBin // bins of the store before the run
+		binSize string
+		start   time.Time
+		want    backfill.Report // but Last, at(24)
+		bins    int             // bins of the store after the run
+	}{
+		{
+			name: "empty store", served: hours(1, 24), binSize: "1h", start: at(1),
+			want: backfill.Report{First: at(1), Missing: 24, Fetched: 24, Requests: 1},
+			bins: 24,
+		},
+		{
+			name: "start rounded up to a bin", served: hours(1, 24), binSize: "1h", start: at(1).Add(-time.Minute),
+			want: backfill.Report{First: at(1), Missing: 24, Fetched: 24, Requests: 1},
+			bins: 24,
+		},
+		{
+			name: "resume with a hole and a duplicate", served: hours(1, 24), binSize: "1h",
+			stored: append(hours(1, 10, 5), hourly(3)),
+			want:   backfill.Report{First: at(1), Existing: 10, Duplicates: 1, Missing: 15, Fetched: 15, Requests: 2},
+			bins:   24,
+		},
+		{
+			name: "complete store", served: hours(1, 24), binSize: "1h", stored: hours(1, 24),
+			want: backfill.Report{First: at(1), Existing: 24},
+			bins: 24,
+		},
+		{
+			name: "bins missing on the server", served: hours(1, 24, 20, 21), binSize: "1h", start: at(1),
+			want: backfill.Report{First: at(1), Missing: 24, Fetched: 22, Requests: 1, Gaps: []backfill.Gap{{First: at(20), Last: at(21)}}},
+			bins: 22,
+		},
+		{
+			name: "derived bins", served: hours(1, 24), binSize: "4h", start: at(1),
+			want: backfill.Report{First: at(4), Missing: 6, Fetched: 6, Requests: 1},
+			bins: 6,
+		},
+		{
+			name: "derived bin with a native bin missing", served: hours(1, 24, 6), binSize: "4h", start: at(4),
+			want: backfill.Report{First: at(4), Missing: 6, Fetched: 5, Requests: 1, Gaps: []backfill.Gap{{First: at(8), Last: at(8)}}},
+			bins: 5,
+		},
+	} {
+		srv := bitmextest.NewServer()
+		srv.AddTradeBins("1h", tt.served...)
+		client := bitmex.NewAPIClient(bitmex.NewConfiguration())
+		client.ChangeBasePath(srv.URL)
+
+		store := &backfill.CSVStore{Path: filepath.Join(t.TempDir(), "XBTUSD.csv")}
+		if tt.stored != nil {
+			if err := store.Write(tt.stored); err != nil {
+				t.Fatal(err)
+			}
+		}
+		report, err := backfill.Run(context.Background(), client, backfill.Job{
+			Symbol:  "XBTUSD",
+			BinSize: tt.binSize,
+			Start:   tt.start,
+			End:     at(24),
+			Store:   store,
+		}, nil)
+		srv.Close()
+
+		assert.NoError(t, err, tt.name)
+		tt.want.Last = at(24)
+		assert.Equal(t, tt.want, report, tt.name)
+
+		bins, err := store.Read()
+		assert.NoError(t, err, tt.name)
+		assert.Len(t, bins, tt.bins, tt.name)
+		for i := 1; i < len(bins); i++ {
+			assert.True(t, bins[i-1].Timestamp.Before(bins[i].Timestamp), "%s: bins %d and %d", tt.name, i-1, i)
+		}
+	}
+}
+
+func TestRunAggregate(t *testing.T) {
+	srv := bitmextest.NewServer()
+	defer srv.Close()
+	srv.AddTradeBins("1h", hours(1, 8)...)
+	client := bitmex.NewAPIClient(bitmex.NewConfiguration())
+	client.ChangeBasePath(srv.URL)
+
+	store := &backfill.CSVStore{Path: filepath.Join(t.TempDir(), "XBTUSD_4h.csv")}
+	_, err := backfill.Run(context.Background(), client, backfill.Job{
+		Symbol: "XBTUSD", BinSize: "4h", Start: at(4), End: at(8), Store: store,
+	}, nil)
+	assert.NoError(t, err)
+	bins, err := store.Read()
+	assert.NoError(t, err)
+	if !assert.Len(t, bins, 2) {
+		return
+	}
+
+	// The 4h bin closing at 4:00 merges the 1h bins closing at 1:00 to 4:00.
+	b := bins[0]
+	assert.Equal(t, at(4), b.Timestamp)
+	for _, tt := range []struct {
+		name string
+		got  bitmex.Decimal
+		want float64
+	}{
+		{"open", b.Open, 101},
+		{"high", b.High, 114},
+		{"low", b.Low, 91},
+		{"close", b.Close, 105},
+		{"homeNotional", b.HomeNotional, 1 + 2 + 3 + 4},
+		{"foreignNotional", b.ForeignNotional, 101 + 2*102 + 3*103 + 4*104},
+		{"vwap", b.Vwap, 103},
+	} {
+		assert.Equal(t, tt.want, bitmex.ToFloat(tt.got), tt.name)
+	}
+	assert.Equal(t, 1+2+3+4, b.Trades)
+	assert.Equal(t, 100*(1+2+3+4), b.Volume)
+	assert.Equal(t, 4, b.LastSize)
+	assert.Equal(t, bitmex.NewAmount(1000*(1+2+3+4), ""), b.Turnover)
+}
+
+func TestRunErrors(t *testing.T) {
+	client := bitmex.NewAPIClient(bitmex.NewConfiguration())
+	store := &backfill.CSVStore{Path: filepath.Join(t.TempDir(), "XBTUSD.csv")}
+	for _, tt := range []struct {
+		name string
+		job  backfill.Job
+	}{
+		{"no start for an empty store", backfill.Job{Symbol: "XBTUSD", BinSize: "1h", Store: store}},
+		{"invalid bin size", backfill.Job{Symbol: "XBTUSD", BinSize: "4x", Start: t0, Store: store}},
+		{"zero bin size", backfill.Job{Symbol: "XBTUSD", BinSize: "0m", Start: t0, Store: store}},
+	} {
+		_, err := backfill.Run(context.Background(), client, tt.job, nil)
+		assert.Error(t, err, tt.name)
+	}
+}
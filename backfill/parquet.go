@@ -0,0 +1,113 @@
+//go:build parquet
+
+package backfill
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// ParquetStore stores bins in a Parquet file, compressed with Snappy.
+type ParquetStore struct {
+	Path string
+}
+
+// parquetBin is the schema of a ParquetStore.
+type parquetBin struct {
+	Timestamp       int64   `parquet:"name=timestamp, type=INT64, convertedtype=TIMESTAMP_MILLIS"`
+	Symbol          string  `parquet:"name=symbol, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Open            float64 `parquet:"name=open, type=DOUBLE"`
+	High            float64 `parquet:"name=high, type=DOUBLE"`
+	Low             float64 `parquet:"name=low, type=DOUBLE"`
+	Close           float64 `parquet:"name=close, type=DOUBLE"`
+	Trades          int64   `parquet:"name=trades, type=INT64"`
+	Volume          int64   `parquet:"name=volume, type=INT64"`
+	Vwap            float64 `parquet:"name=vwap, type=DOUBLE"`
+	LastSize        int64   `parquet:"name=lastSize, type=INT64"`
+	Turnover        int64   `parquet:"name=turnover, type=INT64"`
+	HomeNotional    float64 `parquet:"name=homeNotional, type=DOUBLE"`
+	ForeignNotional float64 `parquet:"name=foreignNotional, type=DOUBLE"`
+}
+
+func newParquetStore(name string) (Store, error) {
+	return &ParquetStore{Path: name}, nil
+}
+
+// Read implements Store.
+func (s *ParquetStore) Read() ([]bitmex.TradeBin, error) {
+	if _, err := os.Stat(s.Path); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	f, err := local.NewLocalFileReader(s.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r, err := reader.NewParquetReader(f, new(parquetBin), 1)
+	if err != nil {
+		return nil, err
+	}
+	defer r.ReadStop()
+
+	rows := make([]parquetBin, r.GetNumRows())
+	if err := r.Read(&rows); err != nil {
+		return nil, err
+	}
+	bins := make([]bitmex.TradeBin, len(rows))
+	for i, p := range rows {
+		bins[i] = bitmex.TradeBin{
+			Timestamp:       time.UnixMilli(p.Timestamp).UTC(),
+			Symbol:          p.Symbol,
+			Open:            bitmex.FromFloat(p.Open),
+			High:            bitmex.FromFloat(p.High),
+			Low:             bitmex.FromFloat(p.Low),
+			Close:           bitmex.FromFloat(p.Close),
+			Trades:          int(p.Trades),
+			Volume:          int(p.Volume),
+			Vwap:            bitmex.FromFloat(p.Vwap),
+			LastSize:        int(p.LastSize),
+			Turnover:        bitmex.NewAmount(p.Turnover, ""),
+			HomeNotional:    bitmex.FromFloat(p.HomeNotional),
+			ForeignNotional: bitmex.FromFloat(p.ForeignNotional),
+		}
+	}
+	return bins, nil
+}
+
+// Write implements Store. The file is replaced at once, so that an
+// interrupted write leaves the previous one.
+func (s *ParquetStore) Write(bins []bitmex.TradeBin) error {
+	return replaceFile(s.Path, func(f *os.File) error {
+		w, err := writer.NewParquetWriterFromWriter(f, new(parquetBin), 1)
+		if err != nil {
+			return err
+		}
+		for _, b := range bins {
+			if err := w.Write(parquetBin{
+				Timestamp:       b.Timestamp.UnixMilli(),
+				Symbol:          b.Symbol,
+				Open:            bitmex.ToFloat(b.Open),
+				High:            bitmex.ToFloat(b.High),
+				Low:             bitmex.ToFloat(b.Low),
+				Close:           bitmex.ToFloat(b.Close),
+				Trades:          int64(b.Trades),
+				Volume:          int64(b.Volume),
+				Vwap:            bitmex.ToFloat(b.Vwap),
+				LastSize:        int64(b.LastSize),
+				Turnover:        b.Turnover.Int64(),
+				HomeNotional:    bitmex.ToFloat(b.HomeNotional),
+				ForeignNotional: bitmex.ToFloat(b.ForeignNotional),
+			}); err != nil {
+				return err
+			}
+		}
+		return w.WriteStop()
+	})
+}
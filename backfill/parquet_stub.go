@@ -0,0 +1,11 @@
+//go:build !parquet
+
+package backfill
+
+import "fmt"
+
+// newParquetStore fails: Parquet support pulls in github.com/xitongsys/parquet-go
+// and is only built with the parquet build tag.
+func newParquetStore(name string) (Store, error) {
+	return nil, fmt.Errorf("backfill: %s: built without Parquet support, rebuild with -tags parquet", name)
+}
@@ -0,0 +1,157 @@
+package backfill
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+	"github.com/go-numb/go-bitmex/decimal"
+)
+
+// Store holds the bins of a Job.
+type Store interface {
+	// Read returns the bins stored, none when the store does not exist yet.
+	Read() ([]bitmex.TradeBin, error)
+	// Write replaces the bins stored with bins, sorted by time.
+	Write(bins []bitmex.TradeBin) error
+}
+
+// OpenStore returns the Store of the file name, a CSVStore for a .csv file or
+// a ParquetStore for a .parquet file.
+func OpenStore(name string) (Store, error) {
+	switch filepath.Ext(name) {
+	case ".csv":
+		return &CSVStore{Path: name}, nil
+	case ".parquet":
+		return newParquetStore(name)
+	}
+	return nil, fmt.Errorf("backfill: %s: unknown format, want .csv or .parquet", name)
+}
+
+// CSVStore stores bins in a CSV file with a header line and the timestamps in RFC 3339.
+type CSVStore struct {
+	Path string
+}
+
+var csvHeader = []string{
+	"timestamp", "symbol", "open", "high", "low", "close", "trades", "volume",
+	"vwap", "lastSize", "turnover", "homeNotional", "foreignNotional",
+}
+
+// Read implements Store.
+func (s *CSVStore) Read() ([]bitmex.TradeBin, error) {
+	f, err := os.Open(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = len(csvHeader)
+	if _, err := r.Read(); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("backfill: %s: %v", s.Path, err)
+	}
+	var bins []bitmex.TradeBin
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			return bins, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("backfill: %s: %v", s.Path, err)
+		}
+		b, err := parseRecord(rec)
+		if err != nil {
+			line, _ := r.FieldPos(0)
+			return nil, fmt.Errorf("backfill: %s:%d: %v", s.Path, line, err)
+		}
+		bins = append(bins, b)
+	}
+}
+
+// Write implements Store. The file is replaced at once, so that an
+// interrupted write leaves the previous one.
+func (s *CSVStore) Write(bins []bitmex.TradeBin) error {
+	return replaceFile(s.Path, func(f *os.File) error {
+		w := csv.NewWriter(f)
+		w.Write(csvHeader)
+		for _, b := range bins {
+			w.Write(record(b))
+		}
+		w.Flush()
+		return w.Error()
+	})
+}
+
+func record(b bitmex.TradeBin) []string {
+	number := func(v bitmex.Decimal) string { return dec(v).String() }
+	return []string{
+		b.Timestamp.UTC().Format(time.RFC3339), b.Symbol,
+		number(b.Open), number(b.High), number(b.Low), number(b.Close),
+		strconv.Itoa(b.Trades), strconv.Itoa(b.Volume), number(b.Vwap),
+		strconv.Itoa(b.LastSize), strconv.FormatInt(b.Turnover.Int64(), 10),
+		number(b.HomeNotional), number(b.ForeignNotional),
+	}
+}
+
+func parseRecord(rec []string) (b bitmex.TradeBin, err error) {
+	number := func(s string) bitmex.Decimal {
+		v, e := decimal.Parse(s)
+		if e != nil && err == nil {
+			err = e
+		}
+		return bitmex.FromDecimal(v)
+	}
+	integer := func(s string) int {
+		v, e := strconv.Atoi(s)
+		if e != nil && err == nil {
+			err = e
+		}
+		return v
+	}
+	amount := func(s string) bitmex.Amount {
+		v, e := strconv.ParseInt(s, 10, 64)
+		if e != nil && err == nil {
+			err = e
+		}
+		return bitmex.NewAmount(v, "")
+	}
+	if b.Timestamp, err = time.Parse(time.RFC3339, rec[0]); err != nil {
+		return b, err
+	}
+	b.Symbol = rec[1]
+	b.Open, b.High, b.Low, b.Close = number(rec[2]), number(rec[3]), number(rec[4]), number(rec[5])
+	b.Trades, b.Volume, b.Vwap = integer(rec[6]), integer(rec[7]), number(rec[8])
+	b.LastSize, b.Turnover = integer(rec[9]), amount(rec[10])
+	b.HomeNotional, b.ForeignNotional = number(rec[11]), number(rec[12])
+	return b, err
+}
+
+// replaceFile writes name through a temporary file renamed over it once write succeeds.
+func replaceFile(name string, write func(f *os.File) error) error {
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
@@ -0,0 +1,398 @@
+package bitmextest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Mode tells a Cassette whether to record or replay.
+type Mode int
+
+const (
+	// Replay serves the interactions of the cassette file, without network access.
+	Replay Mode = iota
+	// Record performs the requests and records them, see Cassette.Save.
+	Record
+)
+
+// scrubbed replaces the credentials in a cassette.
+const scrubbed = "[scrubbed]"
+
+// scrubbedHeaders are the request headers holding credentials.
+var scrubbedHeaders = []string{"api-key", "api-signature", "api-expires", "Authorization", "Cookie"}
+
+// rateLimitHeaders are the response headers dropped on replay.
+var rateLimitHeaders = []string{"x-ratelimit-limit", "x-ratelimit-remaining", "x-ratelimit-reset"}
+
+// clOrdIDFields are the parameters and fields holding ClOrdIDs.
+var clOrdIDFields = map[string]bool{"clOrdID": true, "origClOrdID": true}
+
+// Cassette is an http.RoundTripper recording the requests of an APIClient to a
+// file, then replaying them in deterministic tests:
+//
+//	cassette, err := bitmextest.NewCassette("testdata/order.json", bitmextest.Record)
+//	cfg := bitmex.NewConfiguration()
+//	cfg.HTTPClient = cassette.Client()
+//	// ... calls against testnet ...
+//	err = cassette.Save()
+//
+// When recording, the api-key, api-signature and api-expires headers are
+// scrubbed, and so are the key ID and any "secret" field wherever they occur in
+// a response. The Date header is dropped, so that a replay does not skew the
+// bitmex.Clock of the client.
+//
+// When replaying, a request is served the first interaction not replayed yet
+// with the same method, path, query and body. The query and form bodies are
+// compared once sorted by key, JSON bodies once decoded. A request without such
+// an interaction fails.
+//
+// ClOrdIDs are ignored when matching, wherever they occur in the query or
+// body, including the orders of a bulk request and a filter, since the ones
+// generated by SafeOrderNew or OrderNewBatch differ on every run. The ClOrdIDs
+// recorded are replaced in the response by those of the request, in the order
+// they occur. The x-ratelimit-* headers are dropped from the response, so that
+// a replay doesn't consume the bitmex.Limit of the client; a Retry-After is
+// kept.
+type Cassette struct {
+	// Transport performs the requests when recording, http.DefaultTransport when nil.
+	Transport http.RoundTripper
+
+	name string
+	mode Mode
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	replayed     []bool
+}
+
+// Interaction is a request and its response, as saved in a cassette file.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request a replay is matched on.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Body   string      `json:"body,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// RecordedResponse is a response as replayed.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// NewCassette returns a Cassette recording to or replaying from the file name.
+// In Replay mode the file is read at once.
+func NewCassette(name string, mode Mode) (*Cassette, error) {
+	c := &Cassette{name: name, mode: mode}
+	if mode == Record {
+		return c, nil
+	}
+
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &c.interactions); err != nil {
+		return nil, fmt.Errorf("bitmextest: cassette %s: %v", name, err)
+	}
+	c.replayed = make([]bool, len(c.interactions))
+	return c, nil
+}
+
+// Client returns an http.Client using the cassette, for bitmex.Configuration.HTTPClient.
+func (c *Cassette) Client() *http.Client {
+	return &http.Client{Transport: c}
+}
+
+// Interactions returns the interactions recorded or loaded so far.
+func (c *Cassette) Interactions() []*Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*Interaction(nil), c.interactions...)
+}
+
+// Save writes the recorded interactions to the cassette file.
+func (c *Cassette) Save() error {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	c.mu.Lock()
+	err := enc.Encode(c.interactions)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.name, b.Bytes(), 0o644)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  normalizeQuery(req.URL.RawQuery),
+		Body:   normalizeBody(req.Header.Get("Content-Type"), body),
+	}
+
+	if c.mode == Record {
+		return c.record(req, recorded)
+	}
+	return c.replay(req, recorded)
+}
+
+func (c *Cassette) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	transport := c.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	recorded.Header = req.Header.Clone()
+	for _, name := range scrubbedHeaders {
+		if recorded.Header.Get(name) != "" {
+			recorded.Header.Set(name, scrubbed)
+		}
+	}
+	header := res.Header.Clone()
+	header.Del("Date")
+	header.Del("Set-Cookie")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, &Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     header,
+			Body:       scrub(string(body), req.Header.Get("api-key")),
+		},
+	})
+	return res, nil
+}
+
+func (c *Cassette) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	query, ids := maskClOrdIDs(recorded.Query)
+	body, bodyIDs := maskClOrdIDs(recorded.Body)
+	ids = append(ids, bodyIDs...)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, in := range c.interactions {
+		if c.replayed[i] || in.Request.Method != recorded.Method || in.Request.Path != recorded.Path {
+			continue
+		}
+		inQuery, inQueryIDs := maskClOrdIDs(in.Request.Query)
+		inBody, inBodyIDs := maskClOrdIDs(in.Request.Body)
+		if inQuery != query || inBody != body {
+			continue
+		}
+		c.replayed[i] = true
+
+		// The same masked query and body hold as many ClOrdIDs, in the same order.
+		var pairs []string
+		for j, id := range append(inQueryIDs, inBodyIDs...) {
+			pairs = append(pairs, `"`+id+`"`, `"`+ids[j]+`"`)
+		}
+		resBody := strings.NewReplacer(pairs...).Replace(in.Response.Body)
+		header := in.Response.Header.Clone()
+		for _, name := range rateLimitHeaders {
+			header.Del(name)
+		}
+		header.Set("Content-Length", strconv.Itoa(len(resBody)))
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(resBody)),
+			ContentLength: int64(len(resBody)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("bitmextest: cassette %s: no interaction left for %s %s?%s %s",
+		c.name, recorded.Method, recorded.Path, recorded.Query, recorded.Body)
+}
+
+// maskClOrdIDs replaces the ClOrdIDs of a normalized query or body with a
+// placeholder, returning them in the order they occur: by key in a query, form
+// or JSON object, and by index in a JSON array. Form values holding JSON, as
+// the orders of a bulk request and a filter do, are masked in turn.
+func maskClOrdIDs(s string) (string, []string) {
+	if s == "" {
+		return s, nil
+	}
+	var ids []string
+	if v, err := decode([]byte(s)); err == nil {
+		if b, err := json.Marshal(maskJSON(v, false, &ids)); err == nil {
+			return string(b), ids
+		}
+		return s, nil
+	}
+	values, err := url.ParseQuery(s)
+	if err != nil {
+		return s, nil
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for i, value := range values[k] {
+			if clOrdIDFields[k] {
+				ids = append(ids, value)
+				values[k][i] = masked
+				continue
+			}
+			if v, err := decode([]byte(value)); err == nil {
+				if b, err := json.Marshal(maskJSON(v, false, &ids)); err == nil {
+					values[k][i] = string(b)
+				}
+			}
+		}
+	}
+	return values.Encode(), ids
+}
+
+// masked replaces the ClOrdIDs of a request when matching.
+const masked = "[clOrdID]"
+
+// maskJSON replaces the ClOrdIDs of a decoded JSON value, appending them to
+// ids; id tells whether v is the value of a ClOrdID field, which is a list of
+// them in a filter.
+func maskJSON(v interface{}, id bool, ids *[]string) interface{} {
+	switch v := v.(type) {
+	case string:
+		if id {
+			*ids = append(*ids, v)
+			return masked
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			v[k] = maskJSON(v[k], clOrdIDFields[k], ids)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = maskJSON(item, id, ids)
+		}
+	}
+	return v
+}
+
+// normalizeQuery sorts a query string by key.
+func normalizeQuery(query string) string {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return query
+	}
+	return values.Encode()
+}
+
+// normalizeBody sorts a form body by key and re-encodes a JSON body with
+// sorted keys and no spacing.
+func normalizeBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		return normalizeQuery(string(body))
+	case "application/json":
+		if v, err := decode(body); err == nil {
+			if b, err := json.Marshal(v); err == nil {
+				return string(b)
+			}
+		}
+	}
+	return string(body)
+}
+
+// scrub removes the key ID and the "secret" fields from a response body.
+func scrub(body, key string) string {
+	if key != "" {
+		body = strings.ReplaceAll(body, key, scrubbed)
+	}
+	v, err := decode([]byte(body))
+	if err != nil || !scrubSecrets(v) {
+		return body
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return string(b)
+}
+
+// decode decodes JSON keeping numbers as written.
+func decode(b []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	err := dec.Decode(&v)
+	return v, err
+}
+
+// scrubSecrets replaces the "secret" fields of a decoded JSON value, reporting
+// whether it found any.
+func scrubSecrets(v interface{}) bool {
+	found := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if k == "secret" {
+				v[k] = scrubbed
+				found = true
+				continue
+			}
+			found = scrubSecrets(field) || found
+		}
+	case []interface{}:
+		for _, item := range v {
+			found = scrubSecrets(item) || found
+		}
+	}
+	return found
+}
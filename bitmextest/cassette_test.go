@@ -0,0 +1,113 @@
+package bitmextest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-numb/go-bitmex"
+	"github.com/go-numb/go-bitmex/bitmextest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// session places an order with a generated ClOrdID, a batch of two, and
+// cancels the first order by its ClOrdID, returning the orders as placed and
+// canceled.
+func session(t *testing.T, client *bitmex.APIClient, ctx context.Context) []bitmex.Order {
+	t.Helper()
+	var opts bitmex.OrderNewOpts
+	opts.Side.Set(bitmex.SideBuy)
+	opts.OrderQty.Set(100)
+	opts.Price.Set(bitmex.FromFloat(9000))
+	res, err := client.OrderApi.SafeOrderNew(ctx, "XBTUSD", &opts, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orders := []bitmex.Order{res.Order}
+
+	batch := make([]bitmex.OrderNewOpts, 2)
+	for i := range batch {
+		batch[i].Side.Set(bitmex.SideSell)
+		batch[i].OrderQty.Set(100)
+		batch[i].Price.Set(bitmex.FromFloat(float64(11000 + i)))
+	}
+	results, err := client.OrderApi.OrderNewBatch(ctx, "XBTUSD", batch, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		assert.NoError(t, r.Err)
+		assert.Equal(t, r.ClOrdID, r.Order.ClOrdID)
+		orders = append(orders, r.Order)
+	}
+
+	var cancel bitmex.OrderCancelOpts
+	cancel.ClOrdID.Set(res.ClOrdID)
+	canceled, _, err := client.OrderApi.OrderCancel(ctx, &cancel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(orders, canceled...)
+}
+
+func TestCassette(t *testing.T) {
+	srv := bitmextest.NewServer()
+	defer srv.Close()
+	srv.RateLimit = 10
+	srv.AddAccount("cassette-key", "cassette-secret", 100000000)
+	ctx := bitmex.NewAPIKeyContext("cassette-key", "cassette-secret")
+	name := filepath.Join(t.TempDir(), "cassette.json")
+
+	rec, err := bitmextest.NewCassette(name, bitmextest.Record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := bitmex.NewConfiguration()
+	cfg.BasePath = srv.URL
+	cfg.HTTPClient = rec.Client()
+	recorded := session(t, bitmex.NewAPIClient(cfg), ctx)
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotContains(t, string(b), "cassette-key")
+	assert.Contains(t, string(b), "X-Ratelimit-Remaining")
+
+	// A fresh client generates other ClOrdIDs, and its budget is unaffected by
+	// the one recorded.
+	play, err := bitmextest.NewCassette(name, bitmextest.Replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg = bitmex.NewConfiguration()
+	cfg.BasePath = srv.URL
+	cfg.HTTPClient = play.Client()
+	client := bitmex.NewAPIClient(cfg)
+	replayed := session(t, client, ctx)
+
+	if assert.Len(t, replayed, len(recorded)) {
+		for i, o := range replayed {
+			assert.Equal(t, recorded[i].OrderID, o.OrderID)
+			assert.Equal(t, recorded[i].OrdStatus, o.OrdStatus)
+			assert.NotEqual(t, recorded[i].ClOrdID, o.ClOrdID)
+		}
+		assert.Equal(t, replayed[0].ClOrdID, replayed[3].ClOrdID)
+		assert.Equal(t, bitmex.OrdStatusCanceled, replayed[3].OrdStatus)
+	}
+	limit, remain, _ := client.Limit(true).Status()
+	assert.Equal(t, bitmex.APIREMAIN, limit)
+	assert.Greater(t, remain, srv.RateLimit)
+
+	// Every interaction is replayed once.
+	_, _, err = client.OrderApi.OrderGetOrders(ctx, nil)
+	if assert.Error(t, err) {
+		assert.True(t, strings.Contains(err.Error(), "no interaction left"), err.Error())
+	}
+}
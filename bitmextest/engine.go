@@ -0,0 +1,790 @@
+package bitmextest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+	"github.com/go-numb/go-bitmex/decimal"
+)
+
+// All accounts settle in XBt. The value of a contract in XBt is |multiplier| / price
+// for inverse instruments and multiplier * price for the others.
+
+// places are the decimal places the engine keeps when it divides amounts and
+// prices. The rows it serves round amounts to whole XBt.
+const places = 8
+
+// satoshi is the value of 1 XBt in XBT.
+var satoshi = decimal.New(1, 8)
+
+func defaultInstruments() []bitmex.Instrument {
+	return []bitmex.Instrument{{
+		Symbol:           "XBTUSD",
+		RootSymbol:       "XBT",
+		State:            bitmex.InstrumentStateOpen,
+		Typ:              "FFWCSX",
+		PositionCurrency: "USD",
+		Underlying:       "XBT",
+		QuoteCurrency:    "USD",
+		SettlCurrency:    "XBt",
+		MaxOrderQty:      10000000,
+		MaxPrice:         bitmex.FromFloat(1000000),
+		LotSize:          1,
+		TickSize:         bitmex.FromFloat(0.5),
+		Multiplier:       -100000000,
+		IsInverse:        true,
+		InitMargin:       0.01,
+		MaintMargin:      0.005,
+		MakerFee:         -0.00025,
+		TakerFee:         0.00075,
+		LastPrice:        bitmex.FromFloat(10000),
+	}, {
+		Symbol:           "ETHUSD",
+		RootSymbol:       "ETH",
+		State:            bitmex.InstrumentStateOpen,
+		Typ:              "FFWCSX",
+		PositionCurrency: "USD",
+		Underlying:       "ETH",
+		QuoteCurrency:    "USD",
+		SettlCurrency:    "XBt",
+		MaxOrderQty:      10000000,
+		MaxPrice:         bitmex.FromFloat(100000),
+		LotSize:          1,
+		TickSize:         bitmex.FromFloat(0.05),
+		Multiplier:       100,
+		IsQuanto:         true,
+		InitMargin:       0.02,
+		MaintMargin:      0.01,
+		MakerFee:         -0.00025,
+		TakerFee:         0.00075,
+		LastPrice:        bitmex.FromFloat(200),
+	}}
+}
+
+// cost is the signed cost in XBt of buying qty contracts of inst at price,
+// negative qty to sell, such that the profit of a position is its cost at the
+// mark price minus its cost.
+func cost(inst *bitmex.Instrument, qty int, price decimal.Decimal) decimal.Decimal {
+	if qty == 0 || price.IsZero() {
+		return decimal.Zero
+	}
+	if inst.IsInverse {
+		return decimal.NewFromInt(int64(qty)*int64(abs(inst.Multiplier))).Neg().Div(price, places)
+	}
+	return decimal.NewFromInt(int64(qty) * int64(inst.Multiplier)).Mul(price)
+}
+
+// value is the value in XBt of qty contracts of inst at price.
+func value(inst *bitmex.Instrument, qty int, price decimal.Decimal) decimal.Decimal {
+	return cost(inst, qty, price).Abs()
+}
+
+// book holds the resting orders of a symbol, best price first, then oldest first.
+type book struct {
+	bids, asks []*bitmex.Order
+}
+
+func (b *book) side(side bitmex.Side) *[]*bitmex.Order {
+	if side == bitmex.SideBuy {
+		return &b.bids
+	}
+	return &b.asks
+}
+
+func (b *book) insert(o *bitmex.Order) {
+	orders := b.side(o.Side)
+	i := sort.Search(len(*orders), func(i int) bool {
+		c := bitmex.ToDecimal((*orders)[i].Price).Cmp(bitmex.ToDecimal(o.Price))
+		if o.Side == bitmex.SideBuy {
+			return c < 0
+		}
+		return c > 0
+	})
+	*orders = append(*orders, nil)
+	copy((*orders)[i+1:], (*orders)[i:])
+	(*orders)[i] = o
+}
+
+func (b *book) remove(o *bitmex.Order) {
+	orders := b.side(o.Side)
+	for i := range *orders {
+		if (*orders)[i] == o {
+			*orders = append((*orders)[:i], (*orders)[i+1:]...)
+			return
+		}
+	}
+}
+
+// crosses reports whether an order on side at price (0 for a market order)
+// would trade against the resting order r.
+func crosses(side bitmex.Side, price decimal.Decimal, r *bitmex.Order) bool {
+	c := bitmex.ToDecimal(r.Price).Cmp(price)
+	switch {
+	case price.IsZero():
+		return true
+	case side == bitmex.SideBuy:
+		return c <= 0
+	default:
+		return c >= 0
+	}
+}
+
+// position is the state of an account in an instrument.
+type position struct {
+	qty      int
+	cost     decimal.Decimal // cost of the open contracts
+	realised decimal.Decimal // realised profit net of commissions
+	comm     decimal.Decimal
+	leverage float64 // isolated leverage, 0 for cross margin
+	opened   time.Time
+}
+
+func (s *Server) now() time.Time {
+	return s.Now().UTC()
+}
+
+func (s *Server) nextID() string {
+	s.seq++
+	return fmt.Sprintf("%08x-0000-4000-8000-%012x", s.seq, s.seq)
+}
+
+func (s *Server) account(id int) *Account {
+	for _, a := range s.accounts {
+		if a.ID == id {
+			return a
+		}
+	}
+	return nil
+}
+
+func (s *Server) position(a *Account, symbol string) *position {
+	p, ok := a.positions[symbol]
+	if !ok {
+		p = &position{}
+		a.positions[symbol] = p
+	}
+	return p
+}
+
+func isOpen(o *bitmex.Order) bool {
+	return o.OrdStatus == bitmex.OrdStatusNew || o.OrdStatus == bitmex.OrdStatusPartiallyFilled
+}
+
+func badRequest(format string, a ...interface{}) error {
+	return errorf(http.StatusBadRequest, "HTTPError", format, a...)
+}
+
+// newOrder validates the parameters of POST /order and places the order.
+// An order refused for lack of margin is returned as Rejected, along with the
+// error, so that bulk requests can report it in its row.
+func (s *Server) newOrder(a *Account, p params) (*bitmex.Order, error) {
+	inst, ok := s.instruments[p.get("symbol")]
+	if !ok {
+		return nil, badRequest("Invalid symbol %q", p.get("symbol"))
+	}
+	qty, hasQty, err := p.int("orderQty")
+	if err != nil {
+		return nil, err
+	}
+	price, hasPrice, err := p.decimal("price")
+	if err != nil {
+		return nil, err
+	}
+	stopPx, hasStop, err := p.decimal("stopPx")
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	o := &bitmex.Order{
+		OrderID:       s.nextID(),
+		ClOrdID:       p.get("clOrdID"),
+		ClOrdLinkID:   p.get("clOrdLinkID"),
+		Account:       a.ID,
+		Symbol:        inst.Symbol,
+		Side:          bitmex.Side(p.get("side")),
+		Price:         bitmex.FromDecimal(price),
+		StopPx:        bitmex.FromDecimal(stopPx),
+		Currency:      inst.QuoteCurrency,
+		SettlCurrency: inst.SettlCurrency,
+		OrdType:       bitmex.OrdType(p.get("ordType")),
+		TimeInForce:   bitmex.TimeInForce(p.get("timeInForce")),
+		ExecInst:      bitmex.ExecInst(p.get("execInst")),
+		OrdStatus:     bitmex.OrdStatusNew,
+		Text:          p.get("text"),
+		TransactTime:  now,
+		Timestamp:     now,
+	}
+	if o.Text == "" {
+		o.Text = "Submitted via API."
+	}
+
+	if o.Side == "" && qty < 0 {
+		o.Side, qty = bitmex.SideSell, -qty
+	} else if o.Side == "" {
+		o.Side = bitmex.SideBuy
+	}
+	if !o.Side.IsValid() {
+		return nil, badRequest("Invalid side")
+	}
+	pos := s.position(a, inst.Symbol)
+	if o.ExecInst.Has(bitmex.ExecInstClose) {
+		if pos.qty == 0 {
+			return nil, badRequest("Invalid execInst: Close with no open position")
+		}
+		if p.get("side") == "" {
+			o.Side = bitmex.SideSell
+			if pos.qty < 0 {
+				o.Side = bitmex.SideBuy
+			}
+		}
+		if !hasQty {
+			qty = abs(pos.qty)
+		}
+	} else if !hasQty || qty <= 0 {
+		return nil, badRequest("Invalid orderQty")
+	}
+	if qty%max(inst.LotSize, 1) != 0 {
+		return nil, badRequest("Invalid orderQty: not a multiple of lotSize %d", inst.LotSize)
+	}
+	o.OrderQty, o.LeavesQty = qty, qty
+
+	if o.OrdType == "" {
+		switch {
+		case hasStop && hasPrice:
+			o.OrdType = bitmex.OrdTypeStopLimit
+		case hasStop:
+			o.OrdType = bitmex.OrdTypeStop
+		case hasPrice:
+			o.OrdType = bitmex.OrdTypeLimit
+		default:
+			o.OrdType = bitmex.OrdTypeMarket
+		}
+	}
+	switch o.OrdType {
+	case bitmex.OrdTypeLimit, bitmex.OrdTypeStopLimit, bitmex.OrdTypeLimitIfTouched:
+		if !hasPrice {
+			return nil, badRequest("Invalid price: required for %s orders", o.OrdType)
+		}
+	case bitmex.OrdTypeMarket, bitmex.OrdTypeStop, bitmex.OrdTypeMarketIfTouched:
+		if hasPrice {
+			return nil, badRequest("Invalid price: not allowed for %s orders", o.OrdType)
+		}
+	default:
+		return nil, badRequest("Invalid ordType %q", o.OrdType)
+	}
+	if o.OrdType.IsTriggered() != hasStop {
+		return nil, badRequest("Invalid stopPx for %s orders", o.OrdType)
+	}
+	if !onTick(inst, price, stopPx) {
+		return nil, badRequest("Invalid price tickSize")
+	}
+	if o.TimeInForce == "" {
+		o.TimeInForce = bitmex.TimeInForceGoodTillCancel
+		if o.OrdType == bitmex.OrdTypeMarket || o.OrdType == bitmex.OrdTypeStop || o.OrdType == bitmex.OrdTypeMarketIfTouched {
+			o.TimeInForce = bitmex.TimeInForceImmediateOrCancel
+		}
+	}
+
+	if o.ClOrdID != "" {
+		for _, other := range s.orders {
+			if other.Account == a.ID && other.ClOrdID == o.ClOrdID {
+				return nil, badRequest("Duplicate clOrdID")
+			}
+		}
+	}
+
+	if reduce := o.ExecInst.Has(bitmex.ExecInstReduceOnly) || o.ExecInst.Has(bitmex.ExecInstClose); reduce {
+		if pos.qty == 0 || (pos.qty > 0) == (o.Side == bitmex.SideBuy) {
+			s.place(o)
+			s.cancel(o, "Canceled: Order had execInst of ReduceOnly and would have increased position")
+			return o, nil
+		}
+		if o.LeavesQty > abs(pos.qty) {
+			o.OrderQty, o.LeavesQty = abs(pos.qty), abs(pos.qty)
+		}
+	} else if required := s.orderMargin(a, o); required.GreaterThan(s.availableMargin(a)) {
+		o.OrdStatus, o.OrdRejReason = bitmex.OrdStatusRejected, "Account has insufficient Available Balance"
+		o.LeavesQty = 0
+		return o, badRequest("Account has insufficient Available Balance, %v XBt required", required.Round(0))
+	}
+
+	s.place(o)
+	return o, nil
+}
+
+// onTick reports whether the prices are positive multiples of the tick size
+// of inst, or zero.
+func onTick(inst *bitmex.Instrument, prices ...decimal.Decimal) bool {
+	for _, px := range prices {
+		if px.Sign() < 0 || !px.IsMultipleOf(bitmex.ToDecimal(inst.TickSize)) {
+			return false
+		}
+	}
+	return true
+}
+
+// place records a new order and sends it to the book.
+func (s *Server) place(o *bitmex.Order) {
+	s.orders = append(s.orders, o)
+	s.execution(o, bitmex.ExecTypeNew, nil, 0, decimal.Zero, "")
+	if o.OrdType.IsTriggered() {
+		s.stops = append(s.stops, o)
+		return
+	}
+	s.work(o)
+}
+
+// work matches o against the book and rests what is left of it.
+func (s *Server) work(o *bitmex.Order) {
+	inst := s.instruments[o.Symbol]
+	b := s.books[o.Symbol]
+	opposite := b.side(bitmex.SideBuy)
+	if o.Side == bitmex.SideBuy {
+		opposite = b.side(bitmex.SideSell)
+	}
+	limit := bitmex.ToDecimal(o.Price)
+	if o.OrdType == bitmex.OrdTypeMarket || o.OrdType == bitmex.OrdTypeStop || o.OrdType == bitmex.OrdTypeMarketIfTouched {
+		limit = decimal.Zero
+	}
+
+	if o.ExecInst.Has(bitmex.ExecInstParticipateDoNotInitiate) && len(*opposite) > 0 && crosses(o.Side, limit, (*opposite)[0]) {
+		s.cancel(o, "Canceled: Order had execInst of ParticipateDoNotInitiate")
+		return
+	}
+	if o.TimeInForce == bitmex.TimeInForceFillOrKill {
+		available := 0
+		for _, r := range *opposite {
+			if !crosses(o.Side, limit, r) {
+				break
+			}
+			available += r.LeavesQty
+		}
+		if available < o.LeavesQty {
+			s.cancel(o, "Canceled: Order had timeInForce of FillOrKill")
+			return
+		}
+	}
+
+	for o.LeavesQty > 0 && len(*opposite) > 0 && crosses(o.Side, limit, (*opposite)[0]) {
+		maker := (*opposite)[0]
+		qty := min(o.LeavesQty, maker.LeavesQty)
+		s.fill(inst, o, maker, qty, bitmex.ToDecimal(maker.Price))
+		if maker.LeavesQty == 0 {
+			b.remove(maker)
+		}
+	}
+
+	switch {
+	case o.LeavesQty == 0:
+	case limit.IsZero():
+		s.cancel(o, "Canceled: Market order had no more liquidity to execute against")
+	case o.TimeInForce == bitmex.TimeInForceImmediateOrCancel:
+		s.cancel(o, "Canceled: Order had timeInForce of ImmediateOrCancel")
+	default:
+		o.WorkingIndicator = true
+		b.insert(o)
+	}
+}
+
+// fill trades qty contracts between the incoming order taker and the resting order maker.
+func (s *Server) fill(inst *bitmex.Instrument, taker, maker *bitmex.Order, qty int, price decimal.Decimal) {
+	match := s.nextID()
+	now := s.now()
+	for _, o := range []*bitmex.Order{taker, maker} {
+		filled := bitmex.ToDecimal(o.AvgPx).Mul(decimal.NewFromInt(int64(o.CumQty))).Add(price.Mul(decimal.NewFromInt(int64(qty))))
+		o.AvgPx = bitmex.FromDecimal(filled.Div(decimal.NewFromInt(int64(o.CumQty+qty)), places))
+		o.CumQty += qty
+		o.LeavesQty -= qty
+		o.OrdStatus = bitmex.OrdStatusPartiallyFilled
+		if o.LeavesQty == 0 {
+			o.OrdStatus = bitmex.OrdStatusFilled
+			o.WorkingIndicator = false
+		}
+		o.Timestamp = now
+
+		fee, liquidity := inst.TakerFee, "RemovedLiquidity"
+		if o == maker {
+			fee, liquidity = inst.MakerFee, "AddedLiquidity"
+		}
+		comm := s.applyFill(s.account(o.Account), inst, o.Side, qty, price, fee)
+		s.execution(o, bitmex.ExecTypeTrade, inst, qty, price, match, func(e *bitmex.Execution) {
+			e.LastLiquidityInd = liquidity
+			e.Commission = fee
+			e.ExecComm = xbt(comm)
+		})
+	}
+
+	tick := bitmex.TickDirectionZeroPlusTick
+	if n := len(s.trades); n > 0 {
+		last := s.trades[n-1]
+		switch c := price.Cmp(bitmex.ToDecimal(last.Price)); {
+		case c > 0:
+			tick = bitmex.TickDirectionPlusTick
+		case c < 0:
+			tick = bitmex.TickDirectionMinusTick
+		case last.TickDirection == bitmex.TickDirectionMinusTick || last.TickDirection == bitmex.TickDirectionZeroMinusTick:
+			tick = bitmex.TickDirectionZeroMinusTick
+		}
+	}
+	gross := value(inst, qty, price)
+	s.trades = append(s.trades, bitmex.Trade{
+		Timestamp:       now,
+		Symbol:          inst.Symbol,
+		Side:            taker.Side,
+		Size:            qty,
+		Price:           bitmex.FromDecimal(price),
+		TickDirection:   tick,
+		TrdMatchID:      match,
+		GrossValue:      xbt(gross),
+		HomeNotional:    bitmex.FromDecimal(gross.Mul(satoshi)),
+		ForeignNotional: bitmex.FromDecimal(gross.Mul(satoshi).Mul(price)),
+	})
+	inst.LastPrice = bitmex.FromDecimal(price)
+	inst.LastTickDirection = tick
+	inst.Volume += qty
+	inst.TotalVolume += qty
+
+	s.trigger(inst, price)
+}
+
+// trigger sends the stop orders of inst set off by a trade at price to the book.
+func (s *Server) trigger(inst *bitmex.Instrument, price decimal.Decimal) {
+	var pending []*bitmex.Order
+	for _, o := range s.stops {
+		if o.Symbol != inst.Symbol || !isOpen(o) || o.Triggered != "" {
+			continue
+		}
+		up := o.Side == bitmex.SideBuy
+		if o.OrdType == bitmex.OrdTypeMarketIfTouched || o.OrdType == bitmex.OrdTypeLimitIfTouched {
+			up = !up
+		}
+		if c := price.Cmp(bitmex.ToDecimal(o.StopPx)); up && c >= 0 || !up && c <= 0 {
+			o.Triggered = "StopOrderTriggered"
+			pending = append(pending, o)
+		}
+	}
+	for _, o := range pending {
+		s.removeStop(o)
+		s.execution(o, bitmex.ExecTypeTriggeredOrActivatedBySystem, nil, 0, decimal.Zero, "")
+		s.work(o)
+	}
+}
+
+func (s *Server) removeStop(o *bitmex.Order) {
+	for i := range s.stops {
+		if s.stops[i] == o {
+			s.stops = append(s.stops[:i], s.stops[i+1:]...)
+			return
+		}
+	}
+}
+
+// cancel closes what is left of o.
+func (s *Server) cancel(o *bitmex.Order, text string) {
+	if o.WorkingIndicator {
+		s.books[o.Symbol].remove(o)
+	}
+	s.removeStop(o)
+	o.OrdStatus = bitmex.OrdStatusCanceled
+	o.LeavesQty = 0
+	o.WorkingIndicator = false
+	o.Text = text
+	o.Timestamp = s.now()
+	s.execution(o, bitmex.ExecTypeCanceled, nil, 0, decimal.Zero, "")
+}
+
+// amend changes the quantity or prices of the open order o as requested by p.
+func (s *Server) amend(o *bitmex.Order, p params) error {
+	if !isOpen(o) {
+		return badRequest("Invalid ordStatus")
+	}
+	inst := s.instruments[o.Symbol]
+	leaves := o.LeavesQty
+	if qty, ok, err := p.int("orderQty"); err != nil {
+		return err
+	} else if ok {
+		leaves = qty - o.CumQty
+	}
+	if qty, ok, err := p.int("leavesQty"); err != nil {
+		return err
+	} else if ok {
+		leaves = qty
+	}
+	if leaves <= 0 {
+		return badRequest("Invalid amend: orderQty is less than cumQty")
+	}
+	if leaves%max(inst.LotSize, 1) != 0 {
+		return badRequest("Invalid orderQty: not a multiple of lotSize %d", inst.LotSize)
+	}
+	price, hasPrice, err := p.decimal("price")
+	if err != nil {
+		return err
+	}
+	stopPx, hasStop, err := p.decimal("stopPx")
+	if err != nil {
+		return err
+	}
+	if !onTick(inst, price, stopPx) {
+		return badRequest("Invalid price tickSize")
+	}
+	if hasPrice && bitmex.ToDecimal(o.Price).IsZero() || hasStop && bitmex.ToDecimal(o.StopPx).IsZero() {
+		return badRequest("Invalid amend: price of a %s order", o.OrdType)
+	}
+
+	requeue := o.WorkingIndicator && (hasPrice && !price.Equal(bitmex.ToDecimal(o.Price)) || leaves > o.LeavesQty)
+	if requeue {
+		s.books[o.Symbol].remove(o)
+		o.WorkingIndicator = false
+	}
+	if clOrdID := p.get("clOrdID"); clOrdID != "" {
+		o.ClOrdID = clOrdID
+	}
+	if hasPrice {
+		o.Price = bitmex.FromDecimal(price)
+	}
+	if hasStop {
+		o.StopPx = bitmex.FromDecimal(stopPx)
+	}
+	o.OrderQty = o.CumQty + leaves
+	o.LeavesQty = leaves
+	if text := p.get("text"); text != "" {
+		o.Text = text
+	} else {
+		o.Text = "Amended via API."
+	}
+	o.Timestamp = s.now()
+	s.execution(o, bitmex.ExecTypeReplaced, nil, 0, decimal.Zero, "")
+	if requeue {
+		s.work(o)
+	}
+	return nil
+}
+
+// execution records an execution report of o.
+func (s *Server) execution(o *bitmex.Order, execType bitmex.ExecType, inst *bitmex.Instrument, qty int, price decimal.Decimal, match string, set ...func(*bitmex.Execution)) {
+	e := bitmex.Execution{
+		ExecID:           s.nextID(),
+		OrderID:          o.OrderID,
+		ClOrdID:          o.ClOrdID,
+		ClOrdLinkID:      o.ClOrdLinkID,
+		Account:          o.Account,
+		Symbol:           o.Symbol,
+		Side:             o.Side,
+		LastQty:          qty,
+		LastPx:           bitmex.FromDecimal(price),
+		OrderQty:         o.OrderQty,
+		Price:            o.Price,
+		StopPx:           o.StopPx,
+		Currency:         o.Currency,
+		SettlCurrency:    o.SettlCurrency,
+		ExecType:         execType,
+		OrdType:          o.OrdType,
+		TimeInForce:      o.TimeInForce,
+		ExecInst:         o.ExecInst,
+		OrdStatus:        o.OrdStatus,
+		Triggered:        o.Triggered,
+		WorkingIndicator: o.WorkingIndicator,
+		OrdRejReason:     o.OrdRejReason,
+		LeavesQty:        o.LeavesQty,
+		CumQty:           o.CumQty,
+		AvgPx:            o.AvgPx,
+		Text:             o.Text,
+		TrdMatchID:       match,
+		TransactTime:     o.Timestamp,
+		Timestamp:        o.Timestamp,
+	}
+	if inst != nil {
+		signed := qty
+		if o.Side == bitmex.SideSell {
+			signed = -qty
+		}
+		e.ExecCost = xbt(cost(inst, signed, price))
+		home := value(inst, qty, price).Mul(satoshi)
+		e.HomeNotional = bitmex.FromDecimal(home)
+		e.ForeignNotional = bitmex.FromDecimal(home.Mul(price))
+	}
+	for _, f := range set {
+		f(&e)
+	}
+	s.executions = append(s.executions, e)
+}
+
+// applyFill updates the position and wallet of a for a fill, and returns the commission.
+func (s *Server) applyFill(a *Account, inst *bitmex.Instrument, side bitmex.Side, qty int, price decimal.Decimal, fee float64) decimal.Decimal {
+	pos := s.position(a, inst.Symbol)
+	signed := qty
+	if side == bitmex.SideSell {
+		signed = -qty
+	}
+
+	if pos.qty != 0 && (pos.qty > 0) != (signed > 0) {
+		closed := min(abs(signed), abs(pos.qty))
+		share := pos.cost.Mul(decimal.NewFromInt(int64(closed))).Div(decimal.NewFromInt(int64(abs(pos.qty))), places)
+		closedQty := closed
+		if pos.qty < 0 {
+			closedQty = -closed
+		}
+		pnl := cost(inst, closedQty, price).Sub(share)
+		pos.cost = pos.cost.Sub(share)
+		pos.qty -= closedQty
+		pos.realised = pos.realised.Add(pnl)
+		a.walletBalance = a.walletBalance.Add(pnl)
+		signed += closedQty
+	}
+	if signed != 0 {
+		if pos.qty == 0 {
+			pos.opened = s.now()
+		}
+		pos.qty += signed
+		pos.cost = pos.cost.Add(cost(inst, signed, price))
+	}
+	if pos.qty == 0 {
+		pos.cost = decimal.Zero
+	}
+
+	comm := value(inst, qty, price).Mul(decimal.NewFromFloat(fee))
+	pos.comm = pos.comm.Add(comm)
+	pos.realised = pos.realised.Sub(comm)
+	a.walletBalance = a.walletBalance.Sub(comm)
+	return comm
+}
+
+// initMarginReq is the share of the value of a position or order held as margin.
+func initMarginReq(inst *bitmex.Instrument, pos *position) decimal.Decimal {
+	if pos.leverage > 0 {
+		return decimal.NewFromInt(1).Div(decimal.NewFromFloat(pos.leverage), places)
+	}
+	return decimal.NewFromFloat(inst.InitMargin)
+}
+
+// orderMargin is the margin an order requires on top of those already open.
+func (s *Server) orderMargin(a *Account, o *bitmex.Order) decimal.Decimal {
+	inst := s.instruments[o.Symbol]
+	price := bitmex.ToDecimal(o.Price)
+	if price.IsZero() {
+		price = bitmex.ToDecimal(o.StopPx)
+	}
+	if price.IsZero() {
+		price = bitmex.ToDecimal(inst.MarkPrice)
+	}
+	req := initMarginReq(inst, s.position(a, o.Symbol)).Add(decimal.NewFromFloat(max(inst.TakerFee, 0)))
+	return value(inst, o.LeavesQty, price).Mul(req)
+}
+
+// margins returns the unrealised profit, the position margin and the order margin of a.
+func (s *Server) margins(a *Account) (unrealised, posMargin, ordMargin decimal.Decimal) {
+	for symbol, pos := range a.positions {
+		if pos.qty == 0 {
+			continue
+		}
+		inst := s.instruments[symbol]
+		mark := bitmex.ToDecimal(inst.MarkPrice)
+		unrealised = unrealised.Add(cost(inst, pos.qty, mark).Sub(pos.cost))
+		posMargin = posMargin.Add(value(inst, pos.qty, mark).Mul(initMarginReq(inst, pos)))
+	}
+	for _, o := range s.orders {
+		if o.Account == a.ID && isOpen(o) && !o.ExecInst.Has(bitmex.ExecInstReduceOnly) && !o.ExecInst.Has(bitmex.ExecInstClose) {
+			ordMargin = ordMargin.Add(s.orderMargin(a, o))
+		}
+	}
+	return unrealised, posMargin, ordMargin
+}
+
+func (s *Server) availableMargin(a *Account) decimal.Decimal {
+	unrealised, posMargin, ordMargin := s.margins(a)
+	return a.walletBalance.Add(unrealised).Sub(posMargin).Sub(ordMargin)
+}
+
+// margin returns the Margin row of a.
+func (s *Server) margin(a *Account) bitmex.Margin {
+	unrealised, posMargin, ordMargin := s.margins(a)
+	wallet := a.walletBalance.Round(0)
+	balance := a.walletBalance.Add(unrealised).Round(0)
+	available := a.walletBalance.Add(unrealised).Sub(posMargin).Sub(ordMargin).Round(0)
+	m := bitmex.Margin{
+		Account:            a.ID,
+		Currency:           "XBt",
+		Amount:             xbt(wallet),
+		InitMargin:         xbt(ordMargin),
+		MaintMargin:        xbt(posMargin),
+		UnrealisedPnl:      xbt(unrealised),
+		WalletBalance:      xbt(wallet),
+		MarginBalance:      xbt(balance),
+		ExcessMargin:       xbt(available),
+		AvailableMargin:    xbt(available),
+		WithdrawableMargin: xbt(decimal.Max(decimal.Zero, decimal.Min(available, wallet))),
+		Timestamp:          s.now(),
+	}
+	realised := decimal.Zero
+	for _, pos := range a.positions {
+		realised = realised.Add(pos.realised.Round(0))
+	}
+	m.RealisedPnl = xbt(realised)
+	if balance.Sign() > 0 {
+		m.MarginUsedPcnt = posMargin.Add(ordMargin).Div(balance, places).Float64()
+	}
+	return m
+}
+
+// positionRow returns the Position row of a in symbol.
+func (s *Server) positionRow(a *Account, symbol string) bitmex.Position {
+	inst := s.instruments[symbol]
+	pos := s.position(a, symbol)
+	p := bitmex.Position{
+		Account:          a.ID,
+		Symbol:           symbol,
+		Currency:         "XBt",
+		Underlying:       inst.Underlying,
+		QuoteCurrency:    inst.QuoteCurrency,
+		Commission:       inst.TakerFee,
+		InitMarginReq:    initMarginReq(inst, pos).Float64(),
+		MaintMarginReq:   inst.MaintMargin,
+		Leverage:         decimal.NewFromInt(1).Div(initMarginReq(inst, pos), places).Float64(),
+		CrossMargin:      pos.leverage == 0,
+		OpeningTimestamp: pos.opened,
+		CurrentTimestamp: s.now(),
+		CurrentQty:       pos.qty,
+		CurrentCost:      xbt(pos.cost),
+		CurrentComm:      xbt(pos.comm),
+		IsOpen:           pos.qty != 0,
+		MarkPrice:        inst.MarkPrice,
+		LastPrice:        inst.LastPrice,
+		RealisedPnl:      xbt(pos.realised),
+		Timestamp:        s.now(),
+	}
+	if pos.qty != 0 {
+		mark := bitmex.ToDecimal(inst.MarkPrice)
+		markValue := cost(inst, pos.qty, mark)
+		p.MarkValue = xbt(markValue)
+		p.UnrealisedPnl = xbt(markValue.Sub(pos.cost))
+		p.PosMargin = xbt(value(inst, pos.qty, mark).Mul(initMarginReq(inst, pos)))
+		p.MaintMargin = p.PosMargin
+		contracts := decimal.NewFromInt(int64(pos.qty))
+		if inst.IsInverse {
+			p.AvgEntryPrice = bitmex.FromDecimal(contracts.Mul(decimal.NewFromInt(int64(abs(inst.Multiplier)))).Neg().Div(pos.cost, places))
+			p.HomeNotional = bitmex.FromDecimal(markValue.Neg().Mul(satoshi))
+			p.ForeignNotional = bitmex.FromDecimal(contracts.Neg())
+		} else {
+			p.AvgEntryPrice = bitmex.FromDecimal(pos.cost.Div(contracts.Mul(decimal.NewFromInt(int64(inst.Multiplier))), places))
+		}
+		p.AvgCostPrice = p.AvgEntryPrice
+		p.BreakEvenPrice = p.AvgEntryPrice
+	}
+	return p
+}
+
+// xbt returns v rounded to an Amount of XBt.
+func xbt(v decimal.Decimal) bitmex.Amount {
+	return bitmex.NewAmount(v.Round(0).IntPart(), bitmex.XBt)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
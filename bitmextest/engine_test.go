@@ -0,0 +1,276 @@
+package bitmextest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-numb/go-bitmex"
+	"github.com/go-numb/go-bitmex/bitmextest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// exchange is a Server with two accounts trading against each other.
+type exchange struct {
+	srv          *bitmextest.Server
+	client       *bitmex.APIClient
+	maker, taker context.Context
+}
+
+func newExchange(t *testing.T) *exchange {
+	srv := bitmextest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.AddAccount("maker", "maker-secret", 100000000)
+	srv.AddAccount("taker", "taker-secret", 100000000)
+	client := bitmex.NewAPIClient(bitmex.NewConfiguration())
+	client.ChangeBasePath(srv.URL)
+	return &exchange{
+		srv:    srv,
+		client: client,
+		maker:  bitmex.NewAPIKeyContext("maker", "maker-secret"),
+		taker:  bitmex.NewAPIKeyContext("taker", "taker-secret"),
+	}
+}
+
+// order is an order of XBTUSD; a zero price makes a market order.
+type order struct {
+	side     bitmex.Side
+	qty      int
+	price    float64
+	stopPx   float64
+	tif      bitmex.TimeInForce
+	execInst bitmex.ExecInst
+	clOrdID  string
+}
+
+func (x *exchange) place(t *testing.T, ctx context.Context, o order) bitmex.Order {
+	t.Helper()
+	var opts bitmex.OrderNewOpts
+	opts.Side.Set(o.side)
+	opts.OrderQty.Set(o.qty)
+	if o.price != 0 {
+		opts.Price.Set(bitmex.FromFloat(o.price))
+	}
+	if o.stopPx != 0 {
+		opts.StopPx.Set(bitmex.FromFloat(o.stopPx))
+	}
+	if o.tif != "" {
+		opts.TimeInForce.Set(o.tif)
+	}
+	if o.execInst != "" {
+		opts.ExecInst.Set(o.execInst)
+	}
+	if o.clOrdID != "" {
+		opts.ClOrdID.Set(o.clOrdID)
+	}
+	placed, _, err := x.client.OrderApi.OrderNew(ctx, "XBTUSD", &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return placed
+}
+
+// book returns the levels of the XBTUSD book as {price, size}, asks first,
+// highest price first.
+func (x *exchange) book(t *testing.T) [][2]float64 {
+	t.Helper()
+	rows, _, err := x.client.OrderBookApi.OrderBookGetL2(context.Background(), "XBTUSD", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	levels := [][2]float64{}
+	for _, r := range rows {
+		levels = append(levels, [2]float64{bitmex.ToFloat(r.Price), float64(r.Size)})
+	}
+	return levels
+}
+
+func TestMatching(t *testing.T) {
+	asks := []order{
+		{side: bitmex.SideSell, qty: 100, price: 10000, clOrdID: "first"},
+		{side: bitmex.SideSell, qty: 50, price: 10000, clOrdID: "second"},
+		{side: bitmex.SideSell, qty: 200, price: 10010},
+	}
+	for _, tt := range []struct {
+		name   string
+		taker  order
+		status bitmex.OrdStatus
+		cumQty int
+		avgPx  float64
+		book   [][2]float64
+		filled []string // clOrdIDs of the resting orders filled
+	}{
+		{"limit below the book rests", order{side: bitmex.SideBuy, qty: 10, price: 9990},
+			bitmex.OrdStatusNew, 0, 0, [][2]float64{{10010, 200}, {10000, 150}, {9990, 10}}, nil},
+		{"limit fills the oldest order first", order{side: bitmex.SideBuy, qty: 100, price: 10000},
+			bitmex.OrdStatusFilled, 100, 10000, [][2]float64{{10010, 200}, {10000, 50}}, []string{"first"}},
+		{"limit fills partially and rests", order{side: bitmex.SideBuy, qty: 200, price: 10000},
+			bitmex.OrdStatusPartiallyFilled, 150, 10000, [][2]float64{{10010, 200}, {10000, 50}}, []string{"first", "second"}},
+		{"market sweeps levels", order{side: bitmex.SideBuy, qty: 250},
+			bitmex.OrdStatusFilled, 250, 10004, [][2]float64{{10010, 100}}, []string{"first", "second"}},
+		{"market without liquidity is canceled", order{side: bitmex.SideBuy, qty: 400},
+			bitmex.OrdStatusCanceled, 350, (10000*150 + 10010*200) / 350.0, [][2]float64{}, []string{"first", "second", ""}},
+		{"immediate or cancel", order{side: bitmex.SideBuy, qty: 200, price: 10000, tif: bitmex.TimeInForceImmediateOrCancel},
+			bitmex.OrdStatusCanceled, 150, 10000, [][2]float64{{10010, 200}}, []string{"first", "second"}},
+		{"fill or kill", order{side: bitmex.SideBuy, qty: 200, price: 10000, tif: bitmex.TimeInForceFillOrKill},
+			bitmex.OrdStatusCanceled, 0, 0, [][2]float64{{10010, 200}, {10000, 150}}, nil},
+		{"post only crossing", order{side: bitmex.SideBuy, qty: 10, price: 10000, execInst: bitmex.ExecInstParticipateDoNotInitiate},
+			bitmex.OrdStatusCanceled, 0, 0, [][2]float64{{10010, 200}, {10000, 150}}, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			x := newExchange(t)
+			for _, o := range asks {
+				x.place(t, x.maker, o)
+			}
+
+			o := x.place(t, x.taker, tt.taker)
+			assert.Equal(t, tt.status, o.OrdStatus)
+			assert.Equal(t, tt.cumQty, o.CumQty)
+			assert.InDelta(t, tt.avgPx, bitmex.ToFloat(o.AvgPx), 1e-6)
+			leaves := 0
+			if o.OrdStatus.IsOpen() {
+				leaves = tt.taker.qty - tt.cumQty
+			}
+			assert.Equal(t, leaves, o.LeavesQty)
+			assert.Equal(t, tt.book, x.book(t))
+
+			var filter bitmex.OrderGetOrdersOpts
+			filter.Filter.Set(`{"ordStatus": "Filled"}`)
+			filled, _, err := x.client.OrderApi.OrderGetOrders(x.maker, &filter)
+			if assert.NoError(t, err) {
+				var ids []string
+				for _, o := range filled {
+					ids = append(ids, o.ClOrdID)
+				}
+				assert.Equal(t, tt.filled, ids)
+			}
+		})
+	}
+}
+
+func TestFills(t *testing.T) {
+	x := newExchange(t)
+	x.place(t, x.maker, order{side: bitmex.SideSell, qty: 100, price: 10000})
+	x.place(t, x.taker, order{side: bitmex.SideBuy, qty: 100})
+
+	// 100 contracts at 10000 are worth 1,000,000 XBt: the taker pays 0.075%,
+	// the maker earns 0.025%.
+	for _, tt := range []struct {
+		ctx       context.Context
+		side      bitmex.Side
+		liquidity string
+		cost      int
+		comm      int
+		qty       int
+	}{
+		{x.taker, bitmex.SideBuy, "RemovedLiquidity", -1000000, 750, 100},
+		{x.maker, bitmex.SideSell, "AddedLiquidity", 1000000, -250, -100},
+	} {
+		fills, _, err := x.client.ExecutionApi.ExecutionGetTradeHistory(tt.ctx, nil)
+		if assert.NoError(t, err) && assert.Len(t, fills, 1) {
+			e := fills[0]
+			assert.Equal(t, tt.side, e.Side)
+			assert.Equal(t, bitmex.ExecTypeTrade, e.ExecType)
+			assert.Equal(t, 100, e.LastQty)
+			assert.Equal(t, 10000.0, bitmex.ToFloat(e.LastPx))
+			assert.Equal(t, tt.liquidity, e.LastLiquidityInd)
+			assert.EqualValues(t, tt.cost, e.ExecCost.Int64())
+			assert.EqualValues(t, tt.comm, e.ExecComm.Int64())
+		}
+
+		pos, _, err := x.client.PositionApi.PositionGet(tt.ctx, nil)
+		if assert.NoError(t, err) && assert.Len(t, pos, 1) {
+			assert.Equal(t, tt.qty, pos[0].CurrentQty)
+			assert.Equal(t, 10000.0, bitmex.ToFloat(pos[0].AvgEntryPrice))
+			assert.EqualValues(t, tt.cost, pos[0].CurrentCost.Int64())
+			assert.EqualValues(t, -tt.comm, pos[0].RealisedPnl.Int64())
+		}
+	}
+
+	trades, _, err := x.client.TradeApi.TradeGet(context.Background(), nil)
+	if assert.NoError(t, err) && assert.Len(t, trades, 1) {
+		assert.Equal(t, bitmex.SideBuy, trades[0].Side)
+		assert.EqualValues(t, 1000000, trades[0].GrossValue.Int64())
+	}
+
+	// Closing at 11000 realises 1,000,000 - 100e8/11000 = 90,909 XBt.
+	x.place(t, x.maker, order{side: bitmex.SideBuy, qty: 100, price: 11000})
+	x.place(t, x.taker, order{side: bitmex.SideSell, qty: 100})
+	pos, _, err := x.client.PositionApi.PositionGet(x.taker, nil)
+	if assert.NoError(t, err) && assert.Len(t, pos, 1) {
+		assert.Equal(t, 0, pos[0].CurrentQty)
+		assert.False(t, pos[0].IsOpen)
+		assert.EqualValues(t, 90909-750-682, pos[0].RealisedPnl.Int64())
+	}
+	margin, _, err := x.client.UserApi.UserGetMargin(x.taker, nil)
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, 100000000+90909-750-682, margin.WalletBalance.Int64())
+	}
+}
+
+func TestCancel(t *testing.T) {
+	x := newExchange(t)
+	a := x.place(t, x.maker, order{side: bitmex.SideSell, qty: 10, price: 10000, clOrdID: "a"})
+	x.place(t, x.maker, order{side: bitmex.SideSell, qty: 20, price: 10010, clOrdID: "b"})
+	x.place(t, x.maker, order{side: bitmex.SideBuy, qty: 30, price: 9000, clOrdID: "c"})
+	stop := x.place(t, x.maker, order{side: bitmex.SideBuy, qty: 5, stopPx: 10005, clOrdID: "stop"})
+	assert.Equal(t, bitmex.OrdStatusNew, stop.OrdStatus)
+	assert.False(t, stop.WorkingIndicator)
+
+	var byID bitmex.OrderCancelOpts
+	byID.OrderID.Set(a.OrderID)
+	canceled, _, err := x.client.OrderApi.OrderCancel(x.maker, &byID)
+	if assert.NoError(t, err) && assert.Len(t, canceled, 1) {
+		assert.Equal(t, bitmex.OrdStatusCanceled, canceled[0].OrdStatus)
+		assert.Equal(t, 0, canceled[0].LeavesQty)
+		assert.Equal(t, "Canceled via API.", canceled[0].Text)
+	}
+	assert.Equal(t, [][2]float64{{10010, 20}, {9000, 30}}, x.book(t))
+
+	// canceling again, or an order of another account, fails in the row of
+	// the order, which is left as it was
+	canceled, _, err = x.client.OrderApi.OrderCancel(x.maker, &byID)
+	if assert.NoError(t, err) && assert.Len(t, canceled, 1) {
+		assert.Equal(t, a.OrderID, canceled[0].OrderID)
+		assert.Equal(t, bitmex.OrdStatusCanceled, canceled[0].OrdStatus)
+	}
+	var other bitmex.OrderCancelOpts
+	other.ClOrdID.Set("b")
+	canceled, _, err = x.client.OrderApi.OrderCancel(x.taker, &other)
+	if assert.NoError(t, err) && assert.Len(t, canceled, 1) {
+		assert.Equal(t, "b", canceled[0].ClOrdID)
+		assert.Empty(t, canceled[0].OrderID)
+	}
+	assert.Equal(t, [][2]float64{{10010, 20}, {9000, 30}}, x.book(t))
+
+	var all bitmex.OrderCancelAllOpts
+	all.Filter.Set(`{"side": "Buy"}`)
+	canceled, _, err = x.client.OrderApi.OrderCancelAll(x.maker, &all)
+	if assert.NoError(t, err) && assert.Len(t, canceled, 2) {
+		assert.ElementsMatch(t, []string{"c", "stop"}, []string{canceled[0].ClOrdID, canceled[1].ClOrdID})
+	}
+	assert.Equal(t, [][2]float64{{10010, 20}}, x.book(t))
+}
+
+func TestStopTriggered(t *testing.T) {
+	x := newExchange(t)
+	x.place(t, x.maker, order{side: bitmex.SideSell, qty: 10, price: 10000})
+	x.place(t, x.maker, order{side: bitmex.SideSell, qty: 10, price: 10010})
+	stop := x.place(t, x.taker, order{side: bitmex.SideBuy, qty: 10, stopPx: 10005, clOrdID: "stop"})
+
+	// a trade at 10000 leaves the stop untouched, one at 10010 sets it off
+	x.place(t, x.taker, order{side: bitmex.SideBuy, qty: 10})
+	x.place(t, x.maker, order{side: bitmex.SideSell, qty: 10, price: 10020})
+	x.place(t, x.taker, order{side: bitmex.SideBuy, qty: 5, price: 10010})
+
+	var filter bitmex.OrderGetOrdersOpts
+	filter.Filter.Set(`{"clOrdID": "stop"}`)
+	orders, _, err := x.client.OrderApi.OrderGetOrders(x.taker, &filter)
+	if assert.NoError(t, err) && assert.Len(t, orders, 1) {
+		o := orders[0]
+		assert.Equal(t, stop.OrderID, o.OrderID)
+		assert.Equal(t, "StopOrderTriggered", o.Triggered)
+		assert.Equal(t, bitmex.OrdStatusFilled, o.OrdStatus)
+		assert.InDelta(t, (10010*5+10020*5)/10.0, bitmex.ToFloat(o.AvgPx), 1e-6)
+	}
+}
@@ -0,0 +1,470 @@
+package bitmextest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+)
+
+func routes() map[string]route {
+	return map[string]route{
+		"GET /instrument":             {serve: (*Server).getInstrument},
+		"GET /instrument/active":      {serve: (*Server).getInstrumentActive},
+		"GET /orderBook/L2":           {serve: (*Server).getOrderBookL2},
+		"GET /trade":                  {serve: (*Server).getTrade},
+		"GET /trade/bucketed":         {serve: (*Server).getTradeBucketed},
+		"GET /quote":                  {serve: (*Server).getQuote},
+		"GET /order":                  {private: true, serve: (*Server).getOrder},
+		"POST /order":                 {private: true, serve: (*Server).postOrder},
+		"PUT /order":                  {private: true, serve: (*Server).putOrder},
+		"DELETE /order":               {private: true, serve: (*Server).deleteOrder},
+		"DELETE /order/all":           {private: true, serve: (*Server).deleteOrderAll},
+		"POST /order/bulk":            {private: true, bulk: true, serve: (*Server).postOrderBulk},
+		"PUT /order/bulk":             {private: true, bulk: true, serve: (*Server).putOrderBulk},
+		"GET /position":               {private: true, serve: (*Server).getPosition},
+		"POST /position/isolate":      {private: true, serve: (*Server).postPositionIsolate},
+		"POST /position/leverage":     {private: true, serve: (*Server).postPositionLeverage},
+		"GET /execution":              {private: true, serve: (*Server).getExecution},
+		"GET /execution/tradeHistory": {private: true, serve: (*Server).getExecutionTradeHistory},
+		"GET /user":                   {private: true, serve: (*Server).getUser},
+		"GET /user/margin":            {private: true, serve: (*Server).getUserMargin},
+		"GET /user/wallet":            {private: true, serve: (*Server).getUserWallet},
+	}
+}
+
+// orderResult is an order row, with the reason an operation on it failed.
+type orderResult struct {
+	bitmex.Order
+	Error string `json:"error,omitempty"`
+}
+
+func (s *Server) getInstrument(a *Account, p params) (interface{}, error) {
+	var rows []bitmex.Instrument
+	for _, symbol := range s.symbols {
+		rows = append(rows, *s.instruments[symbol])
+	}
+	return query(rows, p, func(i bitmex.Instrument) time.Time { return i.Timestamp })
+}
+
+func (s *Server) getInstrumentActive(a *Account, p params) (interface{}, error) {
+	rows := []bitmex.Instrument{}
+	for _, symbol := range s.symbols {
+		if inst := s.instruments[symbol]; inst.State == bitmex.InstrumentStateOpen {
+			rows = append(rows, *inst)
+		}
+	}
+	return rows, nil
+}
+
+func (s *Server) getOrderBookL2(a *Account, p params) (interface{}, error) {
+	symbol := p.get("symbol")
+	inst, ok := s.instruments[symbol]
+	if !ok {
+		return nil, badRequest("Invalid symbol %q", symbol)
+	}
+	depth, _, err := p.int("depth")
+	if err != nil {
+		return nil, err
+	}
+	index := 0
+	for i := range s.symbols {
+		if s.symbols[i] == symbol {
+			index = i
+		}
+	}
+
+	levels := func(orders []*bitmex.Order, side bitmex.Side) []bitmex.OrderBookL2 {
+		var rows []bitmex.OrderBookL2
+		for _, o := range orders {
+			if n := len(rows); n > 0 && rows[n-1].Price == o.Price {
+				rows[n-1].Size += o.LeavesQty
+				continue
+			}
+			if depth > 0 && len(rows) == depth {
+				break
+			}
+			rows = append(rows, bitmex.OrderBookL2{
+				Symbol: symbol,
+				Id:     100000000*(index+1) - int(bitmex.ToDecimal(o.Price).Div(bitmex.ToDecimal(inst.TickSize), 0).IntPart()),
+				Side:   side,
+				Size:   o.LeavesQty,
+				Price:  o.Price,
+			})
+		}
+		return rows
+	}
+
+	// sells then buys, both from the highest price down
+	b := s.books[symbol]
+	asks := levels(b.asks, bitmex.SideSell)
+	rows := []bitmex.OrderBookL2{}
+	for i := len(asks) - 1; i >= 0; i-- {
+		rows = append(rows, asks[i])
+	}
+	return append(rows, levels(b.bids, bitmex.SideBuy)...), nil
+}
+
+func (s *Server) getTrade(a *Account, p params) (interface{}, error) {
+	return query(s.trades, p, func(t bitmex.Trade) time.Time { return t.Timestamp })
+}
+
+func (s *Server) getTradeBucketed(a *Account, p params) (interface{}, error) {
+	if !p.has("binSize") {
+		return nil, badRequest("binSize is required")
+	}
+	return query(s.bins[p.get("binSize")], p, func(b bitmex.TradeBin) time.Time { return b.Timestamp })
+}
+
+func (s *Server) getQuote(a *Account, p params) (interface{}, error) {
+	return query(s.quotes, p, func(q bitmex.Quote) time.Time { return q.Timestamp })
+}
+
+func (s *Server) getOrder(a *Account, p params) (interface{}, error) {
+	var rows []bitmex.Order
+	for _, o := range s.orders {
+		if o.Account == a.ID {
+			rows = append(rows, *o)
+		}
+	}
+	return query(rows, p, func(o bitmex.Order) time.Time { return o.Timestamp })
+}
+
+func (s *Server) postOrder(a *Account, p params) (interface{}, error) {
+	o, err := s.newOrder(a, p)
+	if err != nil {
+		return nil, err
+	}
+	return *o, nil
+}
+
+func (s *Server) postOrderBulk(a *Account, p params) (interface{}, error) {
+	orders, err := bulkParams(p)
+	if err != nil {
+		return nil, err
+	}
+	rows := []bitmex.Order{}
+	for _, op := range orders {
+		o, err := s.newOrder(a, op)
+		if o == nil {
+			return nil, err
+		}
+		rows = append(rows, *o)
+	}
+	return rows, nil
+}
+
+func (s *Server) putOrder(a *Account, p params) (interface{}, error) {
+	o, err := s.findOrder(a, p)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.amend(o, p); err != nil {
+		return nil, err
+	}
+	return *o, nil
+}
+
+func (s *Server) putOrderBulk(a *Account, p params) (interface{}, error) {
+	orders, err := bulkParams(p)
+	if err != nil {
+		return nil, err
+	}
+	rows := []bitmex.Order{}
+	for _, op := range orders {
+		o, err := s.findOrder(a, op)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.amend(o, op); err != nil {
+			return nil, err
+		}
+		rows = append(rows, *o)
+	}
+	return rows, nil
+}
+
+// findOrder returns the order of a identified by the orderID or origClOrdID of p.
+func (s *Server) findOrder(a *Account, p params) (*bitmex.Order, error) {
+	orderID, clOrdID := p.get("orderID"), p.get("origClOrdID")
+	if orderID == "" && clOrdID == "" {
+		return nil, badRequest("Either orderID or origClOrdID must be sent")
+	}
+	for _, o := range s.orders {
+		if o.Account == a.ID && (orderID != "" && o.OrderID == orderID || orderID == "" && o.ClOrdID == clOrdID) {
+			return o, nil
+		}
+	}
+	return nil, errorf(http.StatusNotFound, "HTTPError", "Not Found")
+}
+
+func (s *Server) deleteOrder(a *Account, p params) (interface{}, error) {
+	orderIDs, clOrdIDs := p.list("orderID"), p.list("clOrdID")
+	if len(orderIDs) == 0 && len(clOrdIDs) == 0 {
+		return nil, badRequest("At least one of orderID, clOrdID must be sent")
+	}
+	text := p.get("text")
+	if text == "" {
+		text = "Canceled via API."
+	}
+
+	rows := []orderResult{}
+	cancel := func(match func(*bitmex.Order) bool, id bitmex.Order) {
+		for _, o := range s.orders {
+			if o.Account != a.ID || !match(o) {
+				continue
+			}
+			if !isOpen(o) {
+				rows = append(rows, orderResult{Order: *o, Error: "Unable to cancel order due to existing state: " + string(o.OrdStatus)})
+				return
+			}
+			s.cancel(o, text)
+			rows = append(rows, orderResult{Order: *o})
+			return
+		}
+		rows = append(rows, orderResult{Order: id, Error: "Not Found"})
+	}
+	for _, id := range orderIDs {
+		cancel(func(o *bitmex.Order) bool { return o.OrderID == id }, bitmex.Order{OrderID: id})
+	}
+	for _, id := range clOrdIDs {
+		cancel(func(o *bitmex.Order) bool { return o.ClOrdID == id }, bitmex.Order{ClOrdID: id})
+	}
+	return rows, nil
+}
+
+func (s *Server) deleteOrderAll(a *Account, p params) (interface{}, error) {
+	var open []bitmex.Order
+	for _, o := range s.orders {
+		if o.Account == a.ID && isOpen(o) {
+			open = append(open, *o)
+		}
+	}
+	matched, err := query(open, params{"symbol": p["symbol"], "filter": p["filter"], "count": {"1000"}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	text := p.get("text")
+	if text == "" {
+		text = "Canceled via API."
+	}
+	rows := []bitmex.Order{}
+	for _, m := range matched {
+		for _, o := range s.orders {
+			if o.OrderID == m.OrderID {
+				s.cancel(o, text)
+				rows = append(rows, *o)
+			}
+		}
+	}
+	return rows, nil
+}
+
+func (s *Server) getPosition(a *Account, p params) (interface{}, error) {
+	var rows []bitmex.Position
+	for _, symbol := range s.symbols {
+		if _, ok := a.positions[symbol]; ok {
+			rows = append(rows, s.positionRow(a, symbol))
+		}
+	}
+	return query(rows, p, func(p bitmex.Position) time.Time { return p.Timestamp })
+}
+
+func (s *Server) postPositionIsolate(a *Account, p params) (interface{}, error) {
+	inst, ok := s.instruments[p.get("symbol")]
+	if !ok {
+		return nil, badRequest("Invalid symbol %q", p.get("symbol"))
+	}
+	pos := s.position(a, inst.Symbol)
+	if !p.has("enabled") || p.bool("enabled") {
+		if pos.leverage == 0 {
+			pos.leverage = 1 / inst.InitMargin
+		}
+	} else {
+		pos.leverage = 0
+	}
+	return s.positionRow(a, inst.Symbol), nil
+}
+
+func (s *Server) postPositionLeverage(a *Account, p params) (interface{}, error) {
+	inst, ok := s.instruments[p.get("symbol")]
+	if !ok {
+		return nil, badRequest("Invalid symbol %q", p.get("symbol"))
+	}
+	leverage, _, err := p.float("leverage")
+	if err != nil {
+		return nil, err
+	}
+	if leverage < 0 || leverage > 1/inst.InitMargin {
+		return nil, badRequest("Invalid leverage")
+	}
+	s.position(a, inst.Symbol).leverage = leverage
+	return s.positionRow(a, inst.Symbol), nil
+}
+
+func (s *Server) getExecution(a *Account, p params) (interface{}, error) {
+	var rows []bitmex.Execution
+	for _, e := range s.executions {
+		if e.Account == a.ID {
+			rows = append(rows, e)
+		}
+	}
+	return query(rows, p, func(e bitmex.Execution) time.Time { return e.Timestamp })
+}
+
+func (s *Server) getExecutionTradeHistory(a *Account, p params) (interface{}, error) {
+	var rows []bitmex.Execution
+	for _, e := range s.executions {
+		if e.Account == a.ID && e.ExecType == bitmex.ExecTypeTrade {
+			rows = append(rows, e)
+		}
+	}
+	return query(rows, p, func(e bitmex.Execution) time.Time { return e.Timestamp })
+}
+
+func (s *Server) getUser(a *Account, p params) (interface{}, error) {
+	return bitmex.User{Id: a.ID, Username: fmt.Sprintf("user%d", a.ID)}, nil
+}
+
+func (s *Server) getUserMargin(a *Account, p params) (interface{}, error) {
+	switch currency := p.get("currency"); currency {
+	case "", "XBt":
+		return s.margin(a), nil
+	case "all":
+		return []bitmex.Margin{s.margin(a)}, nil
+	default:
+		return nil, badRequest("Invalid currency %q", currency)
+	}
+}
+
+func (s *Server) getUserWallet(a *Account, p params) (interface{}, error) {
+	return bitmex.Wallet{
+		Account:   a.ID,
+		Currency:  "XBt",
+		Amount:    xbt(a.walletBalance),
+		Timestamp: s.now(),
+	}, nil
+}
+
+// bulkParams returns the parameters of every order of the orders parameter of a bulk request.
+func bulkParams(p params) ([]params, error) {
+	var orders []map[string]interface{}
+	if err := json.Unmarshal([]byte(p.get("orders")), &orders); err != nil {
+		return nil, badRequest("Invalid orders: %v", err)
+	}
+	list := make([]params, len(orders))
+	for i, fields := range orders {
+		v := url.Values{}
+		for name, field := range fields {
+			if s, ok := field.(string); ok {
+				v.Set(name, s)
+				continue
+			}
+			b, _ := json.Marshal(field)
+			v.Set(name, string(b))
+		}
+		list[i] = params(v)
+	}
+	return list, nil
+}
+
+// query applies the symbol, filter, startTime, endTime, reverse, start and
+// count parameters of a table request to rows, which are oldest first.
+// timestamp may be nil when the time range is irrelevant.
+func query[T any](rows []T, p params, timestamp func(T) time.Time) ([]T, error) {
+	filter := map[string]interface{}{}
+	if f := p.get("filter"); f != "" {
+		if err := json.Unmarshal([]byte(f), &filter); err != nil {
+			return nil, badRequest("Invalid filter: %v", err)
+		}
+	}
+	if symbol := p.get("symbol"); symbol != "" {
+		filter["symbol"] = symbol
+	}
+	start, _, err := p.time("startTime")
+	if err != nil {
+		return nil, err
+	}
+	end, _, err := p.time("endTime")
+	if err != nil {
+		return nil, err
+	}
+	count, hasCount, err := p.int("count")
+	if err != nil {
+		return nil, err
+	}
+	if !hasCount {
+		count = 100
+	}
+	if count < 0 || count > 1000 {
+		return nil, badRequest("Invalid count: must be at most 1000")
+	}
+	offset, _, err := p.int("start")
+	if err != nil {
+		return nil, err
+	}
+
+	out := []T{}
+	for _, row := range rows {
+		if timestamp != nil {
+			t := timestamp(row)
+			if !start.IsZero() && t.Before(start) || !end.IsZero() && t.After(end) {
+				continue
+			}
+		}
+		if len(filter) > 0 && !matches(row, filter) {
+			continue
+		}
+		out = append(out, row)
+	}
+	if p.bool("reverse") {
+		for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+			out[i], out[j] = out[j], out[i]
+		}
+	}
+	if offset > len(out) {
+		offset = len(out)
+	}
+	out = out[offset:]
+	if len(out) > count {
+		out = out[:count]
+	}
+	return out, nil
+}
+
+// matches reports whether the JSON form of row has the values of filter. A
+// filter value may be a list of accepted values. "open": true selects open orders.
+func matches(row interface{}, filter map[string]interface{}) bool {
+	b, _ := json.Marshal(row)
+	var fields map[string]interface{}
+	json.Unmarshal(b, &fields)
+
+	for name, want := range filter {
+		got := fields[name]
+		if name == "open" {
+			open := fields["ordStatus"] == string(bitmex.OrdStatusNew) || fields["ordStatus"] == string(bitmex.OrdStatusPartiallyFilled)
+			if want != open {
+				return false
+			}
+			continue
+		}
+		list, ok := want.([]interface{})
+		if !ok {
+			list = []interface{}{want}
+		}
+		found := false
+		for _, w := range list {
+			if fmt.Sprint(got) == fmt.Sprint(w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
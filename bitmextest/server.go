@@ -0,0 +1,438 @@
+// Package bitmextest provides an in-process fake of the BitMEX REST API, for
+// testing code built on bitmex.APIClient without reaching testnet.
+//
+//	srv := bitmextest.NewServer()
+//	defer srv.Close()
+//	srv.AddAccount("key", "secret", 100000000)
+//
+//	client := bitmex.NewAPIClient(bitmex.NewConfiguration())
+//	client.ChangeBasePath(srv.URL)
+//	order, _, err := client.OrderApi.OrderNew(bitmex.NewAPIKeyContext("key", "secret"), "XBTUSD", &opts)
+//
+// The server checks signatures and expiries, keeps a wallet, positions, orders
+// and executions per account, matches orders of all accounts in a price-time
+// priority book, and sends rate limit headers. It covers the order, position,
+// execution, instrument, user, orderBook/L2 and trade routes, and serves the
+// quotes and trade bins given to AddQuotes and AddTradeBins.
+package bitmextest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+	"github.com/go-numb/go-bitmex/decimal"
+)
+
+// Server is a fake BitMEX REST API listening on a local port.
+type Server struct {
+	// URL is the base path to point an APIClient to, e.g. "http://127.0.0.1:41234/api/v1".
+	URL string
+
+	// Now returns the time of the server, time.Now by default. Set it before
+	// the first request, e.g. to simulate a skewed clock.
+	Now func() time.Time
+	// RateLimit and PublicRateLimit are the request budgets per minute of an
+	// API key and of an anonymous client.
+	RateLimit       int
+	PublicRateLimit int
+
+	srv    *httptest.Server
+	routes map[string]route
+
+	mu          sync.Mutex
+	accounts    map[string]*Account // by API key
+	instruments map[string]*bitmex.Instrument
+	symbols     []string
+	books       map[string]*book
+	stops       []*bitmex.Order
+	orders      []*bitmex.Order
+	executions  []bitmex.Execution
+	trades      []bitmex.Trade
+	quotes      []bitmex.Quote
+	bins        map[string][]bitmex.TradeBin // by bin size
+	buckets     map[string]*bucket
+	seq         int
+}
+
+// Account is a trading account of the server.
+type Account struct {
+	ID     int
+	Key    string
+	Secret string
+
+	walletBalance decimal.Decimal // XBt
+	positions     map[string]*position
+}
+
+// route serves an operation. Private routes require a signed request.
+type route struct {
+	private bool
+	bulk    bool
+	serve   func(s *Server, a *Account, p params) (interface{}, error)
+}
+
+// NewServer starts a Server listing XBTUSD and ETHUSD.
+func NewServer() *Server {
+	s := &Server{
+		Now:             time.Now,
+		RateLimit:       bitmex.APIREMAIN,
+		PublicRateLimit: bitmex.APIREMAINPUBLIC,
+		accounts:        map[string]*Account{},
+		instruments:     map[string]*bitmex.Instrument{},
+		books:           map[string]*book{},
+		bins:            map[string][]bitmex.TradeBin{},
+		buckets:         map[string]*bucket{},
+	}
+	s.routes = routes()
+	for _, inst := range defaultInstruments() {
+		s.AddInstrument(inst)
+	}
+	s.srv = httptest.NewServer(s)
+	s.URL = s.srv.URL + "/api/v1"
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// AddAccount opens an account for the API key key with a wallet of walletBalance XBt.
+func (s *Server) AddAccount(key, secret string, walletBalance int) *Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a := &Account{
+		ID:            100000 + len(s.accounts),
+		Key:           key,
+		Secret:        secret,
+		walletBalance: decimal.NewFromInt(int64(walletBalance)),
+		positions:     map[string]*position{},
+	}
+	s.accounts[key] = a
+	return a
+}
+
+// AddInstrument lists inst, or replaces the instrument of the same symbol.
+func (s *Server) AddInstrument(inst bitmex.Instrument) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.instruments[inst.Symbol]; !ok {
+		s.symbols = append(s.symbols, inst.Symbol)
+		s.books[inst.Symbol] = &book{}
+	}
+	if bitmex.ToDecimal(inst.MarkPrice).IsZero() {
+		inst.MarkPrice = inst.LastPrice
+	}
+	s.instruments[inst.Symbol] = &inst
+}
+
+// AddQuotes appends quotes, oldest first, to those served by GET /quote.
+func (s *Server) AddQuotes(quotes ...bitmex.Quote) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quotes = append(s.quotes, quotes...)
+}
+
+// AddTradeBins appends bins of binSize, e.g. "1h", oldest first, to those
+// served by GET /trade/bucketed.
+func (s *Server) AddTradeBins(binSize string, bins ...bitmex.TradeBin) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bins[binSize] = append(s.bins[binSize], bins...)
+}
+
+// SetMarkPrice sets the price positions of symbol are valued at.
+func (s *Server) SetMarkPrice(symbol string, price bitmex.Decimal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if inst, ok := s.instruments[symbol]; ok {
+		inst.MarkPrice = price
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	now := s.Now()
+	w.Header().Set("Date", now.UTC().Format(http.TimeFormat))
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, errorf(http.StatusBadRequest, "HTTPError", "%v", err))
+		return
+	}
+	rt, ok := s.routes[r.Method+" "+strings.TrimPrefix(r.URL.Path, "/api/v1")]
+	if !ok {
+		writeError(w, errorf(http.StatusNotFound, "HTTPError", "Not Found"))
+		return
+	}
+	p, err := parseParams(r, body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, err := s.authenticate(r, body, now)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if a == nil && rt.private {
+		writeError(w, errorf(http.StatusUnauthorized, "HTTPError", "Authorization Required"))
+		return
+	}
+
+	weight := 1
+	if rt.bulk {
+		weight = bulkWeight(p.get("orders"))
+	}
+	if err := s.takeBudget(w, r, a, weight, now); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	v, err := rt.serve(s, a, p)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(v)
+}
+
+// authenticate returns the account a request is signed for, or nil for an
+// anonymous request.
+func (s *Server) authenticate(r *http.Request, body []byte, now time.Time) (*Account, error) {
+	key := r.Header.Get("api-key")
+	if key == "" {
+		return nil, nil
+	}
+	a, ok := s.accounts[key]
+	if !ok {
+		return nil, errorf(http.StatusUnauthorized, "HTTPError", "Invalid API Key.")
+	}
+	expires, err := strconv.ParseInt(r.Header.Get("api-expires"), 10, 64)
+	if err != nil {
+		return nil, errorf(http.StatusUnauthorized, "HTTPError", "Missing api-expires header.")
+	}
+	if expires < now.Unix() {
+		return nil, errorf(http.StatusUnauthorized, "HTTPError",
+			"This request has expired - `expires` is in the past. Current time: %d", now.Unix())
+	}
+
+	path := r.URL.Path
+	if r.URL.RawQuery != "" {
+		path += "?" + r.URL.RawQuery
+	}
+	want, _ := bitmex.NewHMACSigner(a.Key, a.Secret).Sign(r.Method, path, expires, body)
+	if !hmac.Equal([]byte(want), []byte(r.Header.Get("api-signature"))) {
+		return nil, errorf(http.StatusUnauthorized, "HTTPError", "Signature not valid.")
+	}
+	return a, nil
+}
+
+// takeBudget charges weight to the budget of the caller and sets the rate limit headers.
+func (s *Server) takeBudget(w http.ResponseWriter, r *http.Request, a *Account, weight int, now time.Time) error {
+	id, limit := "ip:"+r.RemoteAddr, s.PublicRateLimit
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		id = "ip:" + host
+	}
+	if a != nil {
+		id, limit = "key:"+a.Key, s.RateLimit
+	}
+	b, ok := s.buckets[id]
+	if !ok {
+		b = &bucket{limit: limit, tokens: float64(limit), last: now}
+		s.buckets[id] = b
+	}
+
+	ok, wait := b.take(now, weight)
+	w.Header().Set("x-ratelimit-limit", strconv.Itoa(b.limit))
+	w.Header().Set("x-ratelimit-remaining", strconv.Itoa(int(b.tokens)))
+	w.Header().Set("x-ratelimit-reset", strconv.FormatInt(b.reset(now).Unix(), 10))
+	if !ok {
+		retry := int(wait/time.Second) + 1
+		w.Header().Set("Retry-After", strconv.Itoa(retry))
+		return errorf(http.StatusTooManyRequests, "RateLimitError", "Rate limit exceeded, retry in %d seconds.", retry)
+	}
+	return nil
+}
+
+// bucket is a request budget refilled continuously, limit requests per minute.
+type bucket struct {
+	limit  int
+	tokens float64
+	last   time.Time
+}
+
+func (b *bucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Minutes() * float64(b.limit)
+	if b.tokens > float64(b.limit) {
+		b.tokens = float64(b.limit)
+	}
+	b.last = now
+}
+
+// take spends weight tokens, or reports how long to wait until they are available.
+func (b *bucket) take(now time.Time, weight int) (bool, time.Duration) {
+	b.refill(now)
+	if b.tokens < float64(weight) {
+		missing := float64(weight) - b.tokens
+		return false, time.Duration(missing / float64(b.limit) * float64(time.Minute))
+	}
+	b.tokens -= float64(weight)
+	return true, 0
+}
+
+// reset returns when the budget is full again.
+func (b *bucket) reset(now time.Time) time.Time {
+	missing := float64(b.limit) - b.tokens
+	return now.Add(time.Duration(missing / float64(b.limit) * float64(time.Minute)))
+}
+
+// bulkWeight is the weight of a bulk request, ceil(0.1 * orders).
+func bulkWeight(orders string) int {
+	var v []json.RawMessage
+	if err := json.Unmarshal([]byte(orders), &v); err != nil || len(v) == 0 {
+		return 1
+	}
+	return (len(v) + 9) / 10
+}
+
+// apiError is an error response in the format of BitMEX.
+type apiError struct {
+	status  int
+	name    string
+	message string
+}
+
+func errorf(status int, name, format string, a ...interface{}) *apiError {
+	return &apiError{status: status, name: name, message: fmt.Sprintf(format, a...)}
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	e, ok := err.(*apiError)
+	if !ok {
+		e = errorf(http.StatusInternalServerError, "HTTPError", "%v", err)
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(e.status)
+	json.NewEncoder(w).Encode(bitmex.ModelError{Error_: &bitmex.ErrorError{Name: e.name, Message: e.message}})
+}
+
+// params are the parameters of a request, from its query, form or JSON body.
+type params url.Values
+
+func parseParams(r *http.Request, body []byte) (params, error) {
+	v := r.URL.Query()
+	switch ct := r.Header.Get("Content-Type"); {
+	case len(bytes.TrimSpace(body)) == 0:
+	case strings.HasPrefix(ct, "application/x-www-form-urlencoded"):
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, errorf(http.StatusBadRequest, "HTTPError", "%v", err)
+		}
+		for name, values := range form {
+			v[name] = append(v[name], values...)
+		}
+	default:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return nil, errorf(http.StatusBadRequest, "HTTPError", "Invalid JSON body: %v", err)
+		}
+		for name, raw := range fields {
+			var s string
+			if err := json.Unmarshal(raw, &s); err != nil {
+				s = string(raw)
+			}
+			v.Set(name, s)
+		}
+	}
+	return params(v), nil
+}
+
+func (p params) get(name string) string {
+	return url.Values(p).Get(name)
+}
+
+func (p params) has(name string) bool {
+	_, ok := p[name]
+	return ok
+}
+
+func (p params) float(name string) (float64, bool, error) {
+	if !p.has(name) {
+		return 0, false, nil
+	}
+	f, err := strconv.ParseFloat(p.get(name), 64)
+	if err != nil {
+		return 0, false, errorf(http.StatusBadRequest, "ValidationError", "Invalid %s: %q", name, p.get(name))
+	}
+	return f, true, nil
+}
+
+func (p params) decimal(name string) (decimal.Decimal, bool, error) {
+	if !p.has(name) {
+		return decimal.Zero, false, nil
+	}
+	d, err := decimal.Parse(p.get(name))
+	if err != nil {
+		return decimal.Zero, false, errorf(http.StatusBadRequest, "ValidationError", "Invalid %s: %q", name, p.get(name))
+	}
+	return d, true, nil
+}
+
+func (p params) int(name string) (int, bool, error) {
+	f, ok, err := p.float(name)
+	if err == nil && f != float64(int(f)) {
+		err = errorf(http.StatusBadRequest, "ValidationError", "Invalid %s: %q", name, p.get(name))
+	}
+	return int(f), ok, err
+}
+
+func (p params) bool(name string) bool {
+	b, _ := strconv.ParseBool(p.get(name))
+	return b
+}
+
+func (p params) time(name string) (time.Time, bool, error) {
+	if !p.has(name) {
+		return time.Time{}, false, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, p.get(name))
+	if err != nil {
+		return t, false, errorf(http.StatusBadRequest, "ValidationError", "Invalid %s: %q", name, p.get(name))
+	}
+	return t, true, nil
+}
+
+// list returns the values of a parameter sent either as a string or as a JSON array of strings.
+func (p params) list(name string) []string {
+	v := p.get(name)
+	var list []string
+	if err := json.Unmarshal([]byte(v), &list); err == nil {
+		return list
+	}
+	if v == "" {
+		return nil
+	}
+	return []string{v}
+}
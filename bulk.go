@@ -0,0 +1,190 @@
+package bitmex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrOrderNotSent is set on the orders of a batch left unsent after the
+// request of an earlier chunk failed.
+var ErrOrderNotSent = errors.New("bitmex: order not sent")
+
+// BulkOpts tunes OrderNewBatch and OrderAmendBatch. The zero value, like a nil
+// pointer, uses the defaults.
+type BulkOpts struct {
+	// ChunkSize is the number of orders per request, 100 by default. A bulk
+	// request is charged ceil(0.1 * orders), so a multiple of 10 wastes none of
+	// the request budget.
+	ChunkSize     int
+	ClOrdIDPrefix string // prefix of the ClOrdIDs generated by OrderNewBatch, at most 20 characters
+}
+
+func (o *BulkOpts) withDefaults() BulkOpts {
+	var bulk BulkOpts
+	if o != nil {
+		bulk = *o
+	}
+	if bulk.ChunkSize <= 0 {
+		bulk.ChunkSize = 100
+	}
+	return bulk
+}
+
+// BulkResult is the outcome of one order of a batch.
+type BulkResult struct {
+	ClOrdID string
+	// Order is the order as BitMEX returned it, set when the request carrying
+	// it succeeded.
+	Order Order
+	// Err is the error of the request carrying the order, or ErrOrderNotSent.
+	// It is nil for an order the matching engine rejected.
+	Err error
+}
+
+// Rejected reports whether the matching engine rejected the order, for the
+// reason Order.OrdRejReason.
+func (r BulkResult) Rejected() bool {
+	return r.Err == nil && r.Order.OrdStatus == OrdStatusRejected
+}
+
+// OK reports whether BitMEX accepted the order.
+func (r BulkResult) OK() bool {
+	return r.Err == nil && !r.Rejected()
+}
+
+/*
+OrderNewBatch creates the orders of symbol like OrderNewBulk, sending them in
+chunks of BulkOpts.ChunkSize orders. An order without a ClOrdID is assigned one,
+by which the returned orders are matched to their input.
+
+It returns one BulkResult per order, in the order of orders. Orders rejected by
+the matching engine are reported by BulkResult.Rejected; the error is that of
+the first failed request, or one preparing the orders. A refused request does
+not stop the chunks after it; any other failure leaves them unsent, as its
+outcome is unknown or the next requests would fail alike.
+*/
+func (a *OrderApiService) OrderNewBatch(ctx context.Context, symbol string, orders []OrderNewOpts, bulk *BulkOpts) ([]BulkResult, error) {
+	b := bulk.withDefaults()
+
+	rows := make([]map[string]interface{}, len(orders))
+	results := make([]BulkResult, len(orders))
+	seen := map[string]bool{}
+	for i := range orders {
+		opts := orders[i]
+		if !opts.ClOrdID.IsSet() || opts.ClOrdID.Value() == "" {
+			id, err := newClOrdID(b.ClOrdIDPrefix)
+			if err != nil {
+				return nil, err
+			}
+			opts.ClOrdID.Set(id)
+		}
+		id := opts.ClOrdID.Value()
+		if seen[id] {
+			return nil, reportError("duplicate clOrdID %q in the batch", id)
+		}
+		seen[id] = true
+		rows[i] = optionalValues(&opts)
+		rows[i]["symbol"] = symbol
+		results[i].ClOrdID = id
+	}
+
+	return results, a.batch(ctx, rows, b.ChunkSize, func(ctx context.Context, orders string) ([]Order, error) {
+		var opts OrderNewBulkOpts
+		opts.Orders.Set(orders)
+		placed, _, err := a.OrderNewBulk(ctx, &opts)
+		return placed, err
+	}, func(i int, placed []Order) (Order, bool) {
+		for _, o := range placed {
+			if o.ClOrdID == results[i].ClOrdID {
+				return o, true
+			}
+		}
+		return Order{}, false
+	}, results)
+}
+
+/*
+OrderAmendBatch amends orders like OrderAmendBulk, sending them in chunks of
+BulkOpts.ChunkSize orders. The returned orders are matched to their input by
+ClOrdID, the OrigClOrdID of an amendment without one, or else by OrderID.
+
+It returns one BulkResult per amendment, in the order of orders, reporting
+errors like OrderNewBatch.
+*/
+func (a *OrderApiService) OrderAmendBatch(ctx context.Context, orders []OrderAmendOpts, bulk *BulkOpts) ([]BulkResult, error) {
+	b := bulk.withDefaults()
+
+	rows := make([]map[string]interface{}, len(orders))
+	results := make([]BulkResult, len(orders))
+	for i := range orders {
+		opts := &orders[i]
+		if opts.OrderID.Value() == "" && opts.OrigClOrdID.Value() == "" {
+			return nil, reportError("amendment %d has neither an orderID nor an origClOrdID", i)
+		}
+		rows[i] = optionalValues(opts)
+		results[i].ClOrdID = opts.ClOrdID.Value()
+		if results[i].ClOrdID == "" {
+			results[i].ClOrdID = opts.OrigClOrdID.Value()
+		}
+	}
+
+	return results, a.batch(ctx, rows, b.ChunkSize, func(ctx context.Context, orders string) ([]Order, error) {
+		var opts OrderAmendBulkOpts
+		opts.Orders.Set(orders)
+		amended, _, err := a.OrderAmendBulk(ctx, &opts)
+		return amended, err
+	}, func(i int, amended []Order) (Order, bool) {
+		for _, o := range amended {
+			if results[i].ClOrdID != "" && o.ClOrdID == results[i].ClOrdID ||
+				results[i].ClOrdID == "" && o.OrderID == orders[i].OrderID.Value() {
+				return o, true
+			}
+		}
+		return Order{}, false
+	}, results)
+}
+
+// batch sends rows in chunks of size through send and completes results, one
+// per row, with the order match finds for each in the response of its chunk.
+// It returns the error of the first failed chunk.
+func (a *OrderApiService) batch(ctx context.Context, rows []map[string]interface{}, size int,
+	send func(ctx context.Context, orders string) ([]Order, error), match func(i int, orders []Order) (Order, bool), results []BulkResult) error {
+	var first error
+	for start := 0; start < len(rows); start += size {
+		end := start + size
+		if end > len(rows) {
+			end = len(rows)
+		}
+		b, err := json.Marshal(rows[start:end])
+		if err != nil {
+			return err
+		}
+		sctx, sub := a.withSubmission(ctx)
+		orders, err := send(sctx, string(b))
+		for i := start; i < end; i++ {
+			switch o, ok := match(i, orders); {
+			case err != nil:
+				results[i].Err = err
+			case !ok:
+				results[i].Err = fmt.Errorf("%w: order missing from the response", ErrUnknownOutcome)
+			default:
+				results[i].Order = o
+			}
+		}
+		if err == nil {
+			continue
+		}
+		if first == nil {
+			first = err
+		}
+		if outcomeUnknown(err, sub.resent()) || errors.Is(err, ErrRateLimited) || ctx.Err() != nil {
+			for i := end; i < len(rows); i++ {
+				results[i].Err = fmt.Errorf("%w: %v", ErrOrderNotSent, err)
+			}
+			break
+		}
+	}
+	return first
+}
@@ -0,0 +1,314 @@
+package bitmex_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-numb/go-bitmex"
+	"github.com/go-numb/go-bitmex/bitmextest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// bulkServer serves a bitmextest.Server, answering bulk requests with their
+// orders in reverse and failing the n-th one with fail(n).
+type bulkServer struct {
+	*httptest.Server
+	fake *bitmextest.Server
+
+	mu     sync.Mutex
+	chunks []int // orders per bulk request
+}
+
+func newBulkServer(t *testing.T, fail func(n int, w http.ResponseWriter, r *http.Request) bool) *bulkServer {
+	s := &bulkServer{fake: bitmextest.NewServer()}
+	t.Cleanup(s.fake.Close)
+	s.fake.AddAccount("key", "secret", 100000000)
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/order/bulk") {
+			s.fake.ServeHTTP(w, r)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		form, _ := url.ParseQuery(string(body))
+		var orders []json.RawMessage
+		json.Unmarshal([]byte(form.Get("orders")), &orders)
+		s.mu.Lock()
+		s.chunks = append(s.chunks, len(orders))
+		n := len(s.chunks)
+		s.mu.Unlock()
+		if fail != nil && fail(n, w, r) {
+			return
+		}
+
+		rec := httptest.NewRecorder()
+		s.fake.ServeHTTP(rec, r)
+		res := rec.Body.Bytes()
+		var rows []json.RawMessage
+		if rec.Code == http.StatusOK && json.Unmarshal(res, &rows) == nil {
+			for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+				rows[i], rows[j] = rows[j], rows[i]
+			}
+			res, _ = json.Marshal(rows)
+		}
+		for k, v := range rec.Header() {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(rec.Code)
+		w.Write(res)
+	}))
+	t.Cleanup(s.Server.Close)
+	return s
+}
+
+func (s *bulkServer) orders() *bitmex.OrderApiService {
+	cfg := bitmex.NewConfiguration()
+	cfg.BasePath = s.URL + "/api/v1"
+	return bitmex.NewAPIClient(cfg).OrderApi
+}
+
+// failWith fails the n-th bulk request with status.
+func failWith(n, status int) func(int, http.ResponseWriter, *http.Request) bool {
+	return func(i int, w http.ResponseWriter, r *http.Request) bool {
+		if i != n {
+			return false
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "1")
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(`{"error":{"message":"failed","name":"HTTPError"}}`))
+		return true
+	}
+}
+
+// limitOrders returns buy orders of XBTUSD far below the market, of 1 to n
+// contracts; the prices of the bad ones are off the tick size.
+func limitOrders(t *testing.T, n int, bad ...int) []bitmex.OrderNewOpts {
+	orders := make([]bitmex.OrderNewOpts, n)
+	for i := range orders {
+		opts, err := bitmex.LimitOrder("XBTUSD", bitmex.SideBuy, i+1, bitmex.FromFloat(1000)).NewOpts()
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		orders[i] = *opts
+	}
+	for _, i := range bad {
+		orders[i].Price.Set(bitmex.FromFloat(1000.3))
+	}
+	return orders
+}
+
+// status is the outcome of a BulkResult: "ok", "rejected", "not sent", or
+// the error of its request.
+func status(r bitmex.BulkResult) string {
+	switch {
+	case r.Rejected():
+		return "rejected"
+	case errors.Is(r.Err, bitmex.ErrOrderNotSent):
+		return "not sent"
+	case r.Err != nil:
+		return "error"
+	}
+	return "ok"
+}
+
+// checkBatchError checks that err is nil, or a *bitmex.APIError of status, or target.
+func checkBatchError(t *testing.T, err error, status int, target error, name string) {
+	t.Helper()
+	var apiErr *bitmex.APIError
+	switch {
+	case status != 0:
+		if assert.True(t, errors.As(err, &apiErr), "%s: %v", name, err) {
+			assert.Equal(t, status, apiErr.StatusCode, name)
+		}
+	case target != nil:
+		assert.ErrorIs(t, err, target, name)
+	default:
+		assert.NoError(t, err, name)
+	}
+}
+
+func TestOrderNewBatch(t *testing.T) {
+	canceled := func(cancel context.CancelFunc) func(int, http.ResponseWriter, *http.Request) bool {
+		return func(n int, w http.ResponseWriter, r *http.Request) bool {
+			if n != 2 {
+				return false
+			}
+			cancel()
+			<-r.Context().Done()
+			return true
+		}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		orders   []bitmex.OrderNewOpts
+		bulk     *bitmex.BulkOpts
+		fail     func(n int, w http.ResponseWriter, r *http.Request) bool
+		cancel   bool // ctx is canceled by the second request
+		chunks   []int
+		statuses []string // per chunk of ChunkSize orders
+		status   int      // of the *bitmex.APIError returned
+		err      error
+	}{
+		{name: "one request", orders: limitOrders(t, 25), chunks: []int{25}, statuses: []string{"ok"}},
+		{name: "chunks", orders: limitOrders(t, 25), bulk: &bitmex.BulkOpts{ChunkSize: 10, ClOrdIDPrefix: "b-"},
+			chunks: []int{10, 10, 5}, statuses: []string{"ok", "ok", "ok"}},
+		{name: "refused chunk", orders: limitOrders(t, 25, 3), bulk: &bitmex.BulkOpts{ChunkSize: 10},
+			chunks: []int{10, 10, 5}, statuses: []string{"error", "ok", "ok"}, status: http.StatusBadRequest},
+		{name: "bad gateway", orders: limitOrders(t, 25), bulk: &bitmex.BulkOpts{ChunkSize: 10}, fail: failWith(2, http.StatusBadGateway),
+			chunks: []int{10, 10}, statuses: []string{"ok", "error", "not sent"}, status: http.StatusBadGateway},
+		{name: "rate limited", orders: limitOrders(t, 25), bulk: &bitmex.BulkOpts{ChunkSize: 10}, fail: failWith(2, http.StatusTooManyRequests),
+			chunks: []int{10, 10}, statuses: []string{"ok", "error", "not sent"}, err: bitmex.ErrRateLimited},
+		{name: "canceled", orders: limitOrders(t, 25), bulk: &bitmex.BulkOpts{ChunkSize: 10}, cancel: true,
+			chunks: []int{10, 10}, statuses: []string{"ok", "error", "not sent"}, err: context.Canceled},
+	} {
+		ctx, cancel := context.WithCancel(bitmex.NewAPIKeyContext("key", "secret"))
+		fail := tt.fail
+		if tt.cancel {
+			fail = canceled(cancel)
+		}
+		srv := newBulkServer(t, fail)
+		results, err := srv.orders().OrderNewBatch(ctx, "XBTUSD", tt.orders, tt.bulk)
+		cancel()
+		checkBatchError(t, err, tt.status, tt.err, tt.name)
+		assert.Equal(t, tt.chunks, srv.chunks, tt.name)
+		if !assert.Len(t, results, len(tt.orders), tt.name) {
+			continue
+		}
+
+		size := len(tt.orders)
+		if tt.bulk != nil {
+			size = tt.bulk.ChunkSize
+		}
+		for i, r := range results {
+			assert.Equal(t, tt.statuses[i/size], status(r), "%s: order %d", tt.name, i)
+			assert.NotEmpty(t, r.ClOrdID, tt.name)
+			if tt.bulk != nil && tt.bulk.ClOrdIDPrefix != "" {
+				assert.True(t, strings.HasPrefix(r.ClOrdID, tt.bulk.ClOrdIDPrefix), tt.name)
+			}
+			if r.OK() {
+				// matched by clOrdID although the response is reversed
+				assert.Equal(t, r.ClOrdID, r.Order.ClOrdID, tt.name)
+				assert.Equal(t, i+1, r.Order.OrderQty, tt.name)
+			}
+		}
+	}
+}
+
+func TestOrderNewBatchRows(t *testing.T) {
+	srv := newBulkServer(t, nil)
+	ctx := bitmex.NewAPIKeyContext("key", "secret")
+
+	// The matching engine rejects the order the wallet can't margin, in its row.
+	orders := limitOrders(t, 3)
+	orders[1].OrderQty.Set(100000000)
+	orders[2].ClOrdID.Set("mine")
+	results, err := srv.orders().OrderNewBatch(ctx, "XBTUSD", orders, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, results, 3) {
+		assert.True(t, results[0].OK())
+		assert.True(t, results[1].Rejected())
+		assert.False(t, results[1].OK())
+		assert.Contains(t, results[1].Order.OrdRejReason, "insufficient Available Balance")
+		assert.True(t, results[2].OK())
+		assert.Equal(t, "mine", results[2].ClOrdID)
+		assert.Equal(t, 3, results[2].Order.OrderQty)
+	}
+
+	// A batch repeating a clOrdID is not sent.
+	orders = limitOrders(t, 2)
+	orders[0].ClOrdID.Set("twice")
+	orders[1].ClOrdID.Set("twice")
+	results, err = srv.orders().OrderNewBatch(ctx, "XBTUSD", orders, nil)
+	assert.Error(t, err)
+	assert.Nil(t, results)
+	assert.Equal(t, []int{3}, srv.chunks)
+
+	// An order missing from the response has an unknown outcome.
+	srv = newBulkServer(t, func(n int, w http.ResponseWriter, r *http.Request) bool {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"orderID":"o1","clOrdID":"first","ordStatus":"New"}]`))
+		return true
+	})
+	orders = limitOrders(t, 2)
+	orders[0].ClOrdID.Set("first")
+	results, err = srv.orders().OrderNewBatch(ctx, "XBTUSD", orders, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, results, 2) {
+		assert.True(t, results[0].OK())
+		assert.ErrorIs(t, results[1].Err, bitmex.ErrUnknownOutcome)
+	}
+}
+
+func TestOrderAmendBatch(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		fail     func(n int, w http.ResponseWriter, r *http.Request) bool
+		chunks   []int
+		statuses []string // per chunk of two amendments
+		status   int
+	}{
+		{name: "chunks", chunks: []int{2, 2, 1}, statuses: []string{"ok", "ok", "ok"}},
+		{name: "bad gateway", fail: failWith(3, http.StatusBadGateway), chunks: []int{2, 2},
+			statuses: []string{"ok", "error", "not sent"}, status: http.StatusBadGateway},
+	} {
+		// the first bulk request places the orders
+		srv := newBulkServer(t, tt.fail)
+		ctx := bitmex.NewAPIKeyContext("key", "secret")
+		placed, err := srv.orders().OrderNewBatch(ctx, "XBTUSD", limitOrders(t, 5), nil)
+		if !assert.NoError(t, err, tt.name) {
+			continue
+		}
+
+		amendments := make([]bitmex.OrderAmendOpts, 5)
+		for i := range amendments {
+			a := &amendments[i]
+			switch i % 3 {
+			case 0: // by origClOrdID
+				a.OrigClOrdID.Set(placed[i].ClOrdID)
+			case 1: // by orderID
+				a.OrderID.Set(placed[i].Order.OrderID)
+			case 2: // by origClOrdID, to a new clOrdID
+				a.OrigClOrdID.Set(placed[i].ClOrdID)
+				a.ClOrdID.Set("amended-" + placed[i].ClOrdID)
+			}
+			a.Price.Set(bitmex.FromFloat(float64(900 + i)))
+		}
+		results, err := srv.orders().OrderAmendBatch(ctx, amendments, &bitmex.BulkOpts{ChunkSize: 2})
+		checkBatchError(t, err, tt.status, nil, tt.name)
+		assert.Equal(t, tt.chunks, srv.chunks[1:], tt.name)
+		if !assert.Len(t, results, 5, tt.name) {
+			continue
+		}
+		for i, r := range results {
+			assert.Equal(t, tt.statuses[i/2], status(r), "%s: amendment %d", tt.name, i)
+			if !r.OK() {
+				continue
+			}
+			assert.Equal(t, placed[i].Order.OrderID, r.Order.OrderID, tt.name)
+			assert.Equal(t, float64(900+i), bitmex.ToFloat(r.Order.Price), tt.name)
+			if i%3 == 2 {
+				assert.Equal(t, "amended-"+placed[i].ClOrdID, r.ClOrdID, tt.name)
+			}
+		}
+	}
+
+	srv := newBulkServer(t, nil)
+	results, err := srv.orders().OrderAmendBatch(bitmex.NewAPIKeyContext("key", "secret"), make([]bitmex.OrderAmendOpts, 1), nil)
+	assert.Error(t, err)
+	assert.Nil(t, results)
+	assert.Empty(t, srv.chunks)
+}
@@ -1,16 +1,16 @@
 package bitmex
 
+//go:generate go run ./cmd/bitmex-gen -spec api/swagger.yaml -out .
+
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"net/url"
@@ -20,6 +20,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
 )
@@ -62,6 +63,12 @@ type APIClient struct {
 	cfg    *Configuration
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
+	// Request budgets shared by every service, see Limit and KeyLimit.
+	limitMu     sync.Mutex
+	keyLimits   map[string]*Limit // by API key ID
+	firstKey    string            // of the first authenticated request
+	publicLimit *Limit
+
 	// API Services
 
 	APIKeyApi *APIKeyApiService
@@ -114,9 +121,16 @@ func NewAPIClient(cfg *Configuration) *APIClient {
 		cfg.HTTPClient = http.DefaultClient
 	}
 
+	if cfg.Clock == nil {
+		cfg.Clock = NewClock()
+		cfg.Clock.Logger = cfg.Logger
+	}
+
 	c := &APIClient{}
 	c.cfg = cfg
 	c.common.client = c
+	c.keyLimits = map[string]*Limit{}
+	c.publicLimit = NewLimit(false)
 
 	// API Services
 	c.APIKeyApi = (*APIKeyApiService)(&c.common)
@@ -209,6 +223,15 @@ func parameterToString(obj interface{}, collectionFormat string) string {
 		delimiter = ","
 	}
 
+	// BitMEX expects timestamps in ISO 8601.
+	if t, ok := obj.(time.Time); ok {
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	// Float prices without exponent, as a Decimal writes them.
+	if f, ok := obj.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
 	if reflect.TypeOf(obj).Kind() == reflect.Slice {
 		return strings.Trim(strings.Replace(fmt.Sprint(obj), " ", delimiter, -1), "[]")
 	}
@@ -216,9 +239,190 @@ func parameterToString(obj interface{}, collectionFormat string) string {
 	return fmt.Sprintf("%v", obj)
 }
 
-// callAPI do the request.
-func (c *APIClient) callAPI(request *http.Request) (*http.Response, error) {
-	return c.cfg.HTTPClient.Do(request)
+// execute runs call through the interceptors: it signs and sends request,
+// charging weight against the request budget, and decodes a successful
+// response into result, a pointer to the model or nil when there is none.
+func (c *APIClient) execute(call *Call, request *http.Request, weight int, result interface{}) (*http.Response, error) {
+	call.Request = request
+	call.Meta, _ = RequestMetaFromContext(request.Context())
+
+	for {
+		call.Attempt++
+		call.Err = c.roundTrip(call, weight)
+		if call.Err == nil && call.Response.StatusCode >= 300 {
+			call.Err = newAPIError(call.Operation, call.Response, call.Body)
+		}
+		if call.Err == nil || !c.retry(call) {
+			break
+		}
+	}
+	var a abort
+	if errors.As(call.Err, &a) {
+		call.Err = a.err
+	}
+
+	if call.Err == nil && result != nil {
+		call.Err = c.decode(result, call.Body, call.Response.Header.Get("Content-Type"))
+		if call.Err == nil {
+			call.Result = result
+		}
+	}
+
+	if err := c.intercept(call, afterDecode); err != nil {
+		call.Err = err
+	}
+	return call.Response, call.Err
+}
+
+// retry waits for the backoff of the configured RetryPolicy and prepares
+// call for another attempt. It reports false when call must not be retried.
+func (c *APIClient) retry(call *Call) bool {
+	p := c.cfg.Retry
+	if p == nil || call.Attempt >= p.MaxAttempts || !shouldRetry(call, call.Err) {
+		return false
+	}
+
+	ctx := call.Request.Context()
+	timer := time.NewTimer(p.backoff(call.Attempt, call.Err))
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		timer.Stop()
+		return false
+	}
+
+	next := call.Request.Clone(ctx)
+	if call.Request.GetBody != nil {
+		body, err := call.Request.GetBody()
+		if err != nil {
+			return false
+		}
+		next.Body = body
+	}
+	c.logf(call.Request, "retrying after %v", call.Err)
+
+	call.Request = next
+	call.Response = nil
+	call.Body = nil
+	return true
+}
+
+// abort wraps the error of an interceptor or of the signer, which ends a call
+// instead of being retried.
+type abort struct{ err error }
+
+func (a abort) Error() string { return a.err.Error() }
+func (a abort) Unwrap() error { return a.err }
+
+// roundTrip signs and sends call.Request and reads the response into call.
+func (c *APIClient) roundTrip(call *Call, weight int) error {
+	if err := c.intercept(call, beforeSign); err != nil {
+		return abort{err}
+	}
+	if err := c.sign(call.Request); err != nil {
+		return abort{err}
+	}
+	if err := c.intercept(call, afterSign); err != nil {
+		return abort{err}
+	}
+
+	res, err := c.callAPI(call.Request, weight)
+	call.Response = res
+	if err != nil {
+		return err
+	}
+
+	call.Body, err = ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if err := c.intercept(call, afterResponse); err != nil {
+		return abort{err}
+	}
+	return nil
+}
+
+// callAPI do the request, charging weight against the request budget.
+func (c *APIClient) callAPI(request *http.Request, weight int) (*http.Response, error) {
+	limit := c.publicLimit
+	if key := request.Header.Get("api-key"); key != "" {
+		limit = c.keyLimit(key, true)
+	}
+	if c.cfg.RateLimit != RateLimitOff {
+		if err := limit.Take(request.Context(), weight, c.cfg.RateLimit == RateLimitBlock); err != nil {
+			return nil, err
+		}
+	}
+
+	sent := time.Now()
+	res, err := c.cfg.HTTPClient.Do(request)
+	if err != nil {
+		c.logf(request, "%v", err)
+		return res, err
+	}
+	c.cfg.Clock.observeResponse(res, sent, time.Now())
+	limit.FromHeader(res.Header)
+	if res.StatusCode >= 300 {
+		c.logf(request, "%s", res.Status)
+	}
+	return res, nil
+}
+
+// logf writes a line about request to the configured Logger, if any.
+func (c *APIClient) logf(request *http.Request, format string, a ...interface{}) {
+	if c.cfg.Logger == nil {
+		return
+	}
+	meta, _ := RequestMetaFromContext(request.Context())
+	c.cfg.Logger.Printf("%s %s request_id=%q tag=%q: %s",
+		request.Method, request.URL.Path, meta.RequestID, meta.Tag, fmt.Sprintf(format, a...))
+}
+
+// Limit returns the request budget the client keeps for public requests, or
+// for authenticated (isPrivate) ones that of the key of Configuration.Signer,
+// else of the first API key the client sent a request for. It is updated from
+// every response. A client sending requests for several keys, through
+// WithAPIKey or WithSigner, keeps a budget per key; see KeyLimit.
+func (c *APIClient) Limit(isPrivate bool) *Limit {
+	if !isPrivate {
+		return c.publicLimit
+	}
+	if c.cfg.Signer != nil {
+		return c.KeyLimit(c.cfg.Signer.KeyID())
+	}
+	c.limitMu.Lock()
+	key := c.firstKey
+	c.limitMu.Unlock()
+	return c.KeyLimit(key)
+}
+
+// KeyLimit returns the request budget the client keeps for the API key keyID,
+// which BitMEX counts requests against.
+func (c *APIClient) KeyLimit(keyID string) *Limit {
+	return c.keyLimit(keyID, false)
+}
+
+// keyLimit returns the budget of keyID, recording it as the first key of the
+// client when it sends the first authenticated request.
+func (c *APIClient) keyLimit(keyID string, sending bool) *Limit {
+	c.limitMu.Lock()
+	defer c.limitMu.Unlock()
+	if sending && c.firstKey == "" {
+		c.firstKey = keyID
+	}
+	limit, ok := c.keyLimits[keyID]
+	if !ok {
+		limit = NewLimit(true)
+		c.keyLimits[keyID] = limit
+	}
+	return limit
+}
+
+// Clock returns the estimate of the server time the client signs requests with.
+func (c *APIClient) Clock() *Clock {
+	return c.cfg.Clock
 }
 
 // Change base path to allow switching to mocks
@@ -280,37 +484,20 @@ func (c *APIClient) prepareRequest(
 	// Encode the parameters.
 	url.RawQuery = query.Encode()
 
-	// Generate a new request
+	// Generate a new request bound to ctx, so that cancellation and deadlines
+	// abort it wherever it is.
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	if body != nil {
-		localVarRequest, err = http.NewRequest(method, url.String(), body)
+		localVarRequest, err = http.NewRequestWithContext(ctx, method, url.String(), body)
 	} else {
-		localVarRequest, err = http.NewRequest(method, url.String(), nil)
+		localVarRequest, err = http.NewRequestWithContext(ctx, method, url.String(), nil)
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	// auth
-	if ctx != nil {
-		apiKey, ok := ctx.Value(ContextAPIKey).(APIKey)
-		if ok {
-			headerParams["api-key"] = apiKey.Key
-			expires := strconv.FormatInt(time.Now().Unix()+60, 10) // 60 seconds
-			headerParams["api-expires"] = expires
-			payload := method + url.Path
-			if url.RawQuery != "" {
-				payload += "?" + url.RawQuery
-			}
-			payload += expires
-			if body != nil {
-				payload += body.String()
-			}
-			h := hmac.New(sha256.New, []byte(apiKey.Secret))
-			h.Write([]byte(payload))
-			headerParams["api-signature"] = hex.EncodeToString(h.Sum(nil))
-		}
-	}
-
 	// add header parameters, if any
 	if len(headerParams) > 0 {
 		headers := http.Header{}
@@ -330,6 +517,66 @@ func (c *APIClient) prepareRequest(
 	return localVarRequest, nil
 }
 
+// expiryWindow is how long a signed request stays valid.
+func (c *APIClient) expiryWindow() time.Duration {
+	if c.cfg.ExpiryWindow > 0 {
+		return c.cfg.ExpiryWindow
+	}
+	return DefaultExpiryWindow
+}
+
+// signer returns the Signer of a request made with ctx: the one attached with
+// WithSigner or WithAPIKey, else Configuration.Signer.
+func (c *APIClient) signer(ctx context.Context) Signer {
+	if s, ok := ctx.Value(ContextSigner).(Signer); ok {
+		return s
+	}
+	if apiKey, ok := ctx.Value(ContextAPIKey).(APIKey); ok {
+		return NewHMACSigner(apiKey.Key, apiKey.Secret)
+	}
+	return c.cfg.Signer
+}
+
+// sign adds the api-key, api-expires and api-signature headers to request
+// when it is made on behalf of an API key.
+func (c *APIClient) sign(request *http.Request) error {
+	signer := c.signer(request.Context())
+	if signer == nil {
+		return nil
+	}
+
+	expires := c.cfg.Clock.Now().Add(c.expiryWindow()).Unix()
+	path := request.URL.Path
+	if request.URL.RawQuery != "" {
+		path += "?" + request.URL.RawQuery
+	}
+	var body []byte
+	if request.GetBody != nil {
+		r, err := request.GetBody()
+		if err != nil {
+			return err
+		}
+		body, err = ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return err
+		}
+	}
+	signature, err := signer.Sign(request.Method, path, expires, body)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("api-key", signer.KeyID())
+	request.Header.Set("api-expires", strconv.FormatInt(expires, 10))
+	request.Header.Set("api-signature", signature)
+	if sub, ok := request.Context().Value(contextSubmission).(*submission); ok {
+		sub.expires = time.Unix(expires, 0)
+		sub.sent++
+	}
+	return nil
+}
+
 func (c *APIClient) decode(v interface{}, b []byte, contentType string) (err error) {
 	if strings.Contains(contentType, "application/xml") {
 		if err = xml.Unmarshal(b, v); err != nil {
@@ -471,25 +718,3 @@ func CacheExpires(r *http.Response) time.Time {
 func strlen(s string) int {
 	return utf8.RuneCountInString(s)
 }
-
-// GenericSwaggerError Provides access to the body, error and model on returned errors.
-type GenericSwaggerError struct {
-	body  []byte
-	error string
-	model interface{}
-}
-
-// Error returns non-empty string if there was an error.
-func (e GenericSwaggerError) Error() string {
-	return e.error
-}
-
-// Body returns the raw bytes of the response
-func (e GenericSwaggerError) Body() []byte {
-	return e.body
-}
-
-// Model returns the unpacked model of the error
-func (e GenericSwaggerError) Model() interface{} {
-	return e.model
-}
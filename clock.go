@@ -0,0 +1,108 @@
+package bitmex
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultSkewThreshold is the clock skew above which a Clock warns.
+const DefaultSkewThreshold = 5 * time.Second
+
+// skewSmoothing is the weight of a new sample in the skew estimate.
+const skewSmoothing = 0.25
+
+// Clock estimates the offset between the local clock and the BitMEX servers,
+// so that api-expires is computed in server time. It is fed with the Date
+// header of every response, and with the timestamps of realtime messages when
+// shared with a realtime connection. A Clock is safe for concurrent use, and
+// a nil *Clock assumes no skew, its Now being time.Now.
+type Clock struct {
+	// Threshold is the skew above which Logger is warned, DefaultSkewThreshold when zero.
+	Threshold time.Duration
+	// Logger receives a line when the skew crosses Threshold.
+	Logger *log.Logger
+
+	mu       sync.Mutex
+	skew     time.Duration
+	samples  int
+	updated  time.Time
+	drifting bool
+}
+
+// NewClock returns a Clock assuming no skew until it sees a server time.
+func NewClock() *Clock {
+	return &Clock{}
+}
+
+// Now returns the current time of the server.
+func (c *Clock) Now() time.Time {
+	return time.Now().Add(c.Skew())
+}
+
+// Skew returns the estimated server time minus the local time.
+func (c *Clock) Skew() time.Duration {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.skew
+}
+
+// Estimate returns the skew estimate, the number of samples it is based on and
+// the time of the last one.
+func (c *Clock) Estimate() (skew time.Duration, samples int, updated time.Time) {
+	if c == nil {
+		return 0, 0, time.Time{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.skew, c.samples, c.updated
+}
+
+// Observe records that the server time was server at the local time local.
+func (c *Clock) Observe(server, local time.Time) {
+	if c == nil || server.IsZero() {
+		return
+	}
+	sample := server.Sub(local)
+
+	c.mu.Lock()
+	if c.samples == 0 {
+		c.skew = sample
+	} else {
+		c.skew += time.Duration(skewSmoothing * float64(sample-c.skew))
+	}
+	c.samples++
+	c.updated = local
+	skew := c.skew
+	threshold := c.Threshold
+	if threshold <= 0 {
+		threshold = DefaultSkewThreshold
+	}
+	drifting := skew > threshold || skew < -threshold
+	warn := drifting && !c.drifting
+	c.drifting = drifting
+	c.mu.Unlock()
+
+	if warn && c.Logger != nil {
+		if skew > 0 {
+			c.Logger.Printf("bitmex: local clock is %v behind the server (threshold %v)", skew, threshold)
+		} else {
+			c.Logger.Printf("bitmex: local clock is %v ahead of the server (threshold %v)", -skew, threshold)
+		}
+	}
+}
+
+// observeResponse records the Date header of res, received for a request sent
+// at sent. The header has a resolution of one second, so the server time is
+// taken half a second after it, at the midpoint of the round trip.
+func (c *Clock) observeResponse(res *http.Response, sent, received time.Time) {
+	date, err := http.ParseTime(res.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	c.Observe(date.Add(500*time.Millisecond), sent.Add(received.Sub(sent)/2))
+}
@@ -0,0 +1,30 @@
+package bitmex_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock(t *testing.T) {
+	var none *bitmex.Clock
+	assert.WithinDuration(t, time.Now(), none.Now(), time.Second)
+	none.Observe(time.Now().Add(time.Hour), time.Now())
+	skew, samples, updated := none.Estimate()
+	assert.Zero(t, skew)
+	assert.Zero(t, samples)
+	assert.True(t, updated.IsZero())
+
+	c := bitmex.NewClock()
+	local := time.Now()
+	c.Observe(local.Add(4*time.Second), local)
+	c.Observe(local.Add(8*time.Second), local)
+	skew, samples, updated = c.Estimate()
+	assert.Equal(t, 5*time.Second, skew, "a new sample weighs a quarter")
+	assert.Equal(t, 2, samples)
+	assert.Equal(t, local, updated)
+	assert.WithinDuration(t, time.Now().Add(5*time.Second), c.Now(), time.Second)
+}
@@ -0,0 +1,95 @@
+// Command bitmex-backfill downloads TradeBin history into one CSV or Parquet
+// file per symbol and bin size, resuming where the files end and fetching
+// only the missing bins:
+//
+//	bitmex-backfill -symbols XBTUSD,ETHUSD -bins 1h,4h -start 2018-01-01 -out data
+//
+// Parquet output needs a build with the parquet tag:
+//
+//	go build -tags parquet ./cmd/bitmex-backfill
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+	"github.com/go-numb/go-bitmex/backfill"
+)
+
+func main() {
+	symbols := flag.String("symbols", "XBTUSD", "comma separated symbols")
+	bins := flag.String("bins", "1h", "comma separated bin sizes: 1m, 5m, 1h, 1d or a multiple, e.g. 4h or 1w")
+	start := flag.String("start", "", "first bin, RFC 3339 or YYYY-MM-DD; required for new files")
+	end := flag.String("end", "", "last bin, RFC 3339 or YYYY-MM-DD; the last closed bin by default")
+	format := flag.String("format", "csv", "output format: csv or parquet")
+	out := flag.String("out", ".", "output directory")
+	testnet := flag.Bool("testnet", false, "download from testnet")
+	flag.Parse()
+	log.SetFlags(log.LstdFlags)
+	log.SetPrefix("bitmex-backfill: ")
+
+	job := backfill.Job{}
+	var err error
+	if job.Start, err = parseTime(*start); err != nil {
+		log.Fatalf("-start: %v", err)
+	}
+	if job.End, err = parseTime(*end); err != nil {
+		log.Fatalf("-end: %v", err)
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		log.Fatal(err)
+	}
+
+	cfg := bitmex.NewConfiguration()
+	if *testnet {
+		cfg = bitmex.NewTestnetConfiguration()
+	}
+	cfg.Logger = log.Default()
+	client := bitmex.NewAPIClient(cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	failed := false
+	for _, symbol := range strings.Split(*symbols, ",") {
+		for _, size := range strings.Split(*bins, ",") {
+			name := filepath.Join(*out, backfill.FileName(symbol, size, *format))
+			job.Symbol, job.BinSize = symbol, size
+			if job.Store, err = backfill.OpenStore(name); err != nil {
+				log.Fatal(err)
+			}
+
+			report, err := backfill.Run(ctx, client, job, log.Default())
+			if err != nil {
+				log.Printf("%s: %v", name, err)
+				failed = true
+				continue
+			}
+			log.Printf("%s: %d bins read, %d duplicates dropped, %d missing, %d fetched in %d requests",
+				name, report.Existing, report.Duplicates, report.Missing, report.Fetched, report.Requests)
+			for _, g := range report.Gaps {
+				log.Printf("%s: still missing %v", name, g)
+			}
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
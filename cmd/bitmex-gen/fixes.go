@@ -0,0 +1,132 @@
+package main
+
+// The tables below hold the corrections this fork applies on top of the
+// published swagger spec, which is wrong or too loose in a few places.
+
+// fieldTypes overrides the Go type of model fields, keyed by "Definition.property".
+var fieldTypes = map[string]string{
+	"APIKey.permissions":        "[]string",
+	"Affiliate.referrerAccount": "int",
+	"Chat.channelID":            "int",
+}
+
+// decimalFields are the number properties and parameters, besides the prices
+// and quantities decimalType recognizes by name, that hold a price or a
+// quantity and are Decimal rather than float64.
+var decimalFields = map[string]bool{
+	"tickSize":        true,
+	"open":            true,
+	"high":            true,
+	"low":             true,
+	"close":           true,
+	"vwap":            true,
+	"homeNotional":    true,
+	"foreignNotional": true,
+	"pegOffsetValue":  true,
+}
+
+// amountModels are the definitions whose integer properties, but for their
+// account and contract quantities, are Amounts in the currency property.
+var amountModels = map[string]bool{
+	"Margin":      true,
+	"Wallet":      true,
+	"Position":    true,
+	"Transaction": true,
+}
+
+// amountFields are the integer properties of other definitions that are
+// Amounts, keyed by "Definition.property".
+var amountFields = map[string]bool{
+	"Execution.execCost":           true,
+	"Execution.execComm":           true,
+	"Instrument.prevTotalTurnover": true,
+	"Instrument.totalTurnover":     true,
+	"Instrument.turnover":          true,
+	"Instrument.turnover24h":       true,
+	"Instrument.openValue":         true,
+	"Trade.grossValue":             true,
+	"TradeBin.turnover":            true,
+	"Insurance.walletBalance":      true,
+	"Stats.turnover24h":            true,
+	"Stats.openValue":              true,
+	"StatsHistory.turnover":        true,
+	"StatsUSD.turnover24h":         true,
+	"StatsUSD.turnover30d":         true,
+	"StatsUSD.turnover365d":        true,
+	"StatsUSD.turnover":            true,
+	"Affiliate.prevPayout":         true,
+	"Affiliate.prevTurnover":       true,
+	"Affiliate.prevComm":           true,
+	"Affiliate.execTurnover":       true,
+	"Affiliate.execComm":           true,
+	"Affiliate.totalTurnover":      true,
+	"Affiliate.totalComm":          true,
+	"Affiliate.pendingPayout":      true,
+}
+
+// currencyFields are the properties holding the currency of the amounts of a
+// definition, when it isn't its currency property. Amounts of a definition
+// with neither have no currency.
+var currencyFields = map[string]string{
+	"Execution":  "settlCurrency",
+	"Instrument": "settlCurrency",
+}
+
+// enumFields are the string properties and parameters holding one of the
+// values of an enum type, keyed by name or by "Definition.property".
+var enumFields = map[string]string{
+	"side":              "Side",
+	"ordType":           "OrdType",
+	"timeInForce":       "TimeInForce",
+	"execInst":          "ExecInst",
+	"ordStatus":         "OrdStatus",
+	"execType":          "ExecType",
+	"pegPriceType":      "PegPriceType",
+	"contingencyType":   "ContingencyType",
+	"tickDirection":     "TickDirection",
+	"lastTickDirection": "TickDirection",
+	"Instrument.state":  "InstrumentState",
+}
+
+// patchModels are the definitions of the realtime tables sending update
+// actions, which get a Patch type and a Merge method.
+var patchModels = map[string]bool{
+	"Instrument":  true,
+	"Liquidation": true,
+	"Margin":      true,
+	"Order":       true,
+	"OrderBookL2": true,
+	"Position":    true,
+	"Wallet":      true,
+}
+
+// returnTypes overrides the Go type decoded from a successful response, keyed by operation id.
+var returnTypes = map[string]string{
+	"APIKey.remove":         "InlineResponse200",
+	"Leaderboard.getName":   "InlineResponse2001",
+	"User.minWithdrawalFee": "UserWithdrawalFees",
+}
+
+// extraDefinitions are models missing from the spec.
+var extraDefinitions = map[string]*schema{
+	"UserWithdrawalFees": {
+		Type: "object",
+		Properties: properties{
+			{Name: "currency", schema: &schema{Type: "string"}},
+			{Name: "fee", schema: &schema{Type: "number", Format: "int64"}},
+			{Name: "minFee", schema: &schema{Type: "number", Format: "int64"}},
+			{Name: "maxFee", schema: &schema{Type: "number", Format: "int64"}},
+		},
+	},
+}
+
+// modelNames holds definition names that would clash with Go identifiers.
+var modelNames = map[string]string{
+	"Error": "ModelError",
+}
+
+// reserved names get an underscore appended when used as struct fields.
+var reserved = map[string]bool{
+	"Error": true,
+	"Type":  true,
+}
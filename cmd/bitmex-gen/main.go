@@ -0,0 +1,384 @@
+// Command bitmex-gen generates the services, their *Opts structs and the models
+// of package bitmex from the BitMEX swagger spec, with the fixes of this fork applied.
+//
+// Run it through go generate from the root of the repository:
+//
+//	go generate .
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const header = "// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.\n\n"
+
+func main() {
+	specPath := flag.String("spec", "api/swagger.yaml", "swagger spec to read")
+	out := flag.String("out", ".", "directory of package bitmex")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("bitmex-gen: ")
+
+	s, err := loadSpec(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for name, def := range extraDefinitions {
+		s.Definitions[name] = def
+	}
+
+	files := map[string][]byte{}
+	filterable := filterModels(s)
+	for name, def := range s.Definitions {
+		if name == "x-any" {
+			continue // placeholder for values of any type
+		}
+		files["model_"+snake(name)+".go"] = model(name, def, filterable[name])
+	}
+	for tag, ops := range services(s) {
+		files["api_"+snake(tag)+".go"] = service(tag, ops)
+	}
+
+	for name, src := range files {
+		b, err := format.Source(append([]byte(header), src...))
+		if err != nil {
+			log.Fatalf("%s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(*out, name), b, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// services groups the operations of s by their first tag, sorted by method name.
+func services(s *spec) map[string][]*operation {
+	m := map[string][]*operation{}
+	for _, ops := range s.Paths {
+		for _, op := range ops {
+			if len(op.Tags) == 0 {
+				continue
+			}
+			m[op.Tags[0]] = append(m[op.Tags[0]], op)
+		}
+	}
+	for _, ops := range m {
+		sort.Slice(ops, func(i, j int) bool { return methodName(ops[i]) < methodName(ops[j]) })
+	}
+	return m
+}
+
+// filterModels returns the definitions listed by an operation taking a filter parameter.
+func filterModels(s *spec) map[string]bool {
+	m := map[string]bool{}
+	for _, ops := range s.Paths {
+		for _, op := range ops {
+			r := op.Responses["200"]
+			if r == nil || r.Schema == nil || r.Schema.Type != "array" || r.Schema.Items == nil || r.Schema.Items.Ref == "" {
+				continue
+			}
+			for _, p := range op.Parameters {
+				if p.Name == "filter" {
+					m[refName(r.Schema.Items.Ref)] = true
+				}
+			}
+		}
+	}
+	return m
+}
+
+// filterTypes are the field types a filter builder gets a method matching.
+var filterTypes = map[string]bool{"string": true, "int": true, "float64": true, "Decimal": true, "bool": true}
+
+// filterMethods are the methods a filter builder gets from Filter, which no field method may shadow.
+var filterMethods = map[string]bool{"Field": true, "At": true, "Err": true, "Build": true, "String": true, "MarshalJSON": true}
+
+// model renders the struct of the definition name, with the filter builder of
+// the model when an operation filters on its fields.
+func model(name string, def *schema, filterable bool) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "package bitmex\n\n")
+	fields := &bytes.Buffer{}
+	filters := &bytes.Buffer{}
+	usesTime := false
+	var amounts []string
+	currency := currencyField(name, def)
+	builder := modelName(name) + "FilterBuilder"
+	for _, p := range def.Properties {
+		t, ok := fieldTypes[name+"."+p.Name]
+		if !ok {
+			t = enumType(name, p.Name, amountType(name, p.Name, decimalType(p.Name, fieldType(p.schema))))
+		}
+		if t == "Amount" && currency != "" {
+			amounts = append(amounts, "&o."+fieldName(p.Name))
+		}
+		if method := fieldName(p.Name); filterable && !filterMethods[method] {
+			switch {
+			case t == "time.Time":
+				fmt.Fprintf(filters, "\n// %sAt matches a part of %s, e.g. %sAt(TimeOfDay, \"12:00\").\n", method, p.Name, method)
+				fmt.Fprintf(filters, "func (f *%s) %sAt(part TimePart, value interface{}) *%s {\nf.Filter.At(%q, part, value)\nreturn f\n}\n", builder, method, builder, p.Name)
+				fallthrough
+			case filterTypes[t] || isEnum(t):
+				fmt.Fprintf(filters, "\n// %s matches %s against any of values.\n", method, p.Name)
+				fmt.Fprintf(filters, "func (f *%s) %s(values ...%s) *%s {\nsetField(f.Filter, %q, values)\nreturn f\n}\n", builder, method, t, builder, p.Name)
+			}
+		}
+		usesTime = usesTime || strings.Contains(t, "time.Time")
+		tag := p.Name
+		// omitempty would never omit the structs Decimal and Amount, and omit
+		// zero prices only with float prices
+		if !contains(def.Required, p.Name) && t != "Decimal" && t != "Amount" {
+			tag += ",omitempty"
+		}
+		comment(fields, p.Description)
+		fmt.Fprintf(fields, "%s %s `json:%q`\n", fieldName(p.Name), t, tag)
+	}
+	switch {
+	case usesTime && len(amounts) > 0:
+		fmt.Fprintf(&b, "import (\n\"encoding/json\"\n\"time\"\n)\n\n")
+	case usesTime:
+		fmt.Fprintf(&b, "import (\n\"time\"\n)\n\n")
+	case len(amounts) > 0:
+		fmt.Fprintf(&b, "import (\n\"encoding/json\"\n)\n\n")
+	}
+	comment(&b, def.Description)
+	fmt.Fprintf(&b, "type %s struct {\n%s}\n", modelName(name), fields)
+	if patchModels[name] {
+		fmt.Fprintf(&b, "\n// %sPatch is a partial %s, as sent by realtime updates.\n", modelName(name), modelName(name))
+		fmt.Fprintf(&b, "type %sPatch = Patch[%s]\n", modelName(name), modelName(name))
+		fmt.Fprintf(&b, "\n// Merge sets the fields of o present in p to their values in p.\n")
+		fmt.Fprintf(&b, "func (o *%s) Merge(p %sPatch) {\np.Apply(o)\n", modelName(name), modelName(name))
+		if len(amounts) > 0 {
+			fmt.Fprintf(&b, "setCurrency(o.%s, %s)\n", currency, strings.Join(amounts, ", "))
+		}
+		fmt.Fprintf(&b, "}\n")
+	}
+	if len(amounts) > 0 {
+		fmt.Fprintf(&b, "\n// UnmarshalJSON implements json.Unmarshaler, giving the amounts of o the currency of o.\n")
+		fmt.Fprintf(&b, "func (o *%s) UnmarshalJSON(b []byte) error {\ntype plain %s\n", modelName(name), modelName(name))
+		fmt.Fprintf(&b, "if err := json.Unmarshal(b, (*plain)(o)); err != nil {\nreturn err\n}\n")
+		fmt.Fprintf(&b, "setCurrency(o.%s, %s)\nreturn nil\n}\n", currency, strings.Join(amounts, ", "))
+	}
+	if filterable {
+		fmt.Fprintf(&b, "\n// %s builds a Filter on the fields of %s.\n", builder, modelName(name))
+		fmt.Fprintf(&b, "type %s struct {\n*Filter\n}\n", builder)
+		fmt.Fprintf(&b, "\n// %sFilter starts a Filter on the fields of %s.\n", modelName(name), modelName(name))
+		fmt.Fprintf(&b, "func %sFilter() *%s {\nreturn &%s{NewFilter[%s]()}\n}\n", modelName(name), builder, builder, modelName(name))
+		b.Write(filters.Bytes())
+	}
+	return b.Bytes()
+}
+
+func comment(b *bytes.Buffer, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(b, "// %s\n", line)
+	}
+}
+
+// fieldType returns the Go type of a model field.
+func fieldType(s *schema) string {
+	switch {
+	case s.Ref != "":
+		if refName(s.Ref) == "x-any" {
+			return "interface{}"
+		}
+		return "*" + modelName(refName(s.Ref))
+	case s.Type == "object":
+		return "*interface{}"
+	case s.Type == "array":
+		return "[]" + elemType(s.Items)
+	}
+	return scalarType(s.Type, s.Format)
+}
+
+// elemType returns the Go type of a response or of an array element, or ""
+// when there is nothing to decode.
+func elemType(s *schema) string {
+	switch {
+	case s == nil || s.Type == "null":
+		return ""
+	case s.Ref != "":
+		if refName(s.Ref) == "x-any" {
+			return "interface{}"
+		}
+		return modelName(refName(s.Ref))
+	case s.Type == "object":
+		return "interface{}"
+	case s.Type == "array":
+		return "[]" + elemType(s.Items)
+	}
+	return scalarType(s.Type, s.Format)
+}
+
+// scalarType maps swagger types to Go. BitMEX declares its integer quantities
+// and satoshi amounts as int32 or int64 numbers; they are all mapped to int.
+func scalarType(typ, format string) string {
+	switch typ {
+	case "number", "integer":
+		if format == "int32" || format == "int64" {
+			return "int"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "string":
+		if format == "date-time" {
+			return "time.Time"
+		}
+	}
+	return "string"
+}
+
+// decimalType returns Decimal for the float64 t of a price or a quantity: a
+// name containing "price" or ending in "Px" or "Qty", or one of decimalFields.
+func decimalType(name, t string) string {
+	lower := strings.ToLower(name)
+	if t == "float64" && (strings.Contains(lower, "price") || strings.HasSuffix(name, "Px") ||
+		strings.HasSuffix(name, "Qty") || decimalFields[name]) {
+		return "Decimal"
+	}
+	return t
+}
+
+// amountType returns Amount for the int t of a property of one of
+// amountModels, other than its account and its quantities, or of amountFields.
+func amountType(model, name, t string) string {
+	if t != "int" {
+		return t
+	}
+	if amountModels[model] && name != "account" && !strings.HasSuffix(name, "Qty") || amountFields[model+"."+name] {
+		return "Amount"
+	}
+	return t
+}
+
+// currencyField returns the field of the definition name holding the currency
+// of its amounts, or "" when it has none.
+func currencyField(name string, def *schema) string {
+	prop, ok := currencyFields[name]
+	if !ok {
+		prop = "currency"
+	}
+	for _, p := range def.Properties {
+		if p.Name == prop {
+			return fieldName(prop)
+		}
+	}
+	return ""
+}
+
+// enumType returns the enum type of enumFields for the string t of the
+// property name of model, or of the parameter name when model is "".
+func enumType(model, name, t string) string {
+	if t != "string" {
+		return t
+	}
+	if e, ok := enumFields[model+"."+name]; ok {
+		return e
+	}
+	if e, ok := enumFields[name]; ok {
+		return e
+	}
+	return t
+}
+
+// isEnum reports whether t is one of the enum types of enumFields.
+func isEnum(t string) bool {
+	for _, e := range enumFields {
+		if t == e {
+			return true
+		}
+	}
+	return false
+}
+
+// optionalType returns the type of the optional package holding a parameter.
+func optionalType(p *parameter) string {
+	t := enumType("", p.Name, decimalType(p.Name, scalarType(p.Type, p.Format)))
+	if isEnum(t) {
+		return "Enum[" + t + "]"
+	}
+	switch t {
+	case "int":
+		return "Int"
+	case "float64":
+		return "Float64"
+	case "Decimal":
+		return "Decimal"
+	case "bool":
+		return "Bool"
+	case "time.Time":
+		return "Time"
+	}
+	return "String"
+}
+
+// modelName returns the Go name of a definition, e.g. "ApiKey" for APIKey,
+// "StatsUsd" for StatsUSD or "InlineResponse2001" for inline_response_200_1.
+func modelName(name string) string {
+	if n, ok := modelNames[name]; ok {
+		return n
+	}
+	var b strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		orig := []rune(word)
+		r := []rune(word)
+		for i := range r {
+			switch {
+			case i == 0:
+				r[i] = unicode.ToUpper(r[i])
+			case unicode.IsUpper(orig[i-1]) && unicode.IsUpper(orig[i]) && (i+1 == len(r) || !unicode.IsLower(orig[i+1])):
+				r[i] = unicode.ToLower(r[i])
+			}
+		}
+		b.WriteString(string(r))
+	}
+	return b.String()
+}
+
+// fieldName returns the exported Go name of a property or parameter.
+func fieldName(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' }) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	if reserved[b.String()] {
+		b.WriteString("_")
+	}
+	return b.String()
+}
+
+// snake returns the file name stem of a definition or tag, e.g. "api_key" for APIKey.
+func snake(name string) string {
+	r := []rune(name)
+	var b strings.Builder
+	for i, c := range r {
+		if unicode.IsUpper(c) && i > 0 && r[i-1] != '_' &&
+			(unicode.IsLower(r[i-1]) || unicode.IsDigit(r[i-1]) || i+1 < len(r) && unicode.IsLower(r[i+1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(c))
+	}
+	return b.String()
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"html"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// service renders the file of the service tag.
+func service(tag string, ops []*operation) []byte {
+	d := struct {
+		Service string
+		HasOpts bool
+		Ops     []methodData
+	}{Service: tag + "ApiService"}
+	for _, op := range ops {
+		m := newMethodData(op)
+		d.HasOpts = d.HasOpts || len(m.Optional) > 0
+		d.Ops = append(d.Ops, m)
+	}
+
+	var b bytes.Buffer
+	if err := serviceTemplate.Execute(&b, d); err != nil {
+		panic(err)
+	}
+	return b.Bytes()
+}
+
+type methodData struct {
+	Name        string
+	Operation   string
+	Method      string // http.MethodXxx constant suffix, e.g. "Post"
+	Path        string
+	Bulk        bool
+	Summary     string
+	Notes       string
+	Return      string
+	Required    []paramData
+	Optional    []paramData
+	OptionsType string
+}
+
+type paramData struct {
+	Name     string // wire name
+	Var      string // Go argument name of a required parameter
+	Field    string // Go field name of an optional parameter
+	Type     string // Go type, or the optional type of an optional parameter
+	Doc      string
+	Optional bool
+}
+
+func newMethodData(op *operation) methodData {
+	m := methodData{
+		Name:      methodName(op),
+		Operation: op.OperationID,
+		Method:    op.Method[:1] + strings.ToLower(op.Method[1:]),
+		Path:      op.Path,
+		Bulk:      strings.HasSuffix(op.Path, "/bulk"),
+		Summary:   escape(op.Summary),
+		Notes:     escape(op.Description),
+	}
+	m.OptionsType = m.Name + "Opts"
+
+	if t, ok := returnTypes[op.OperationID]; ok {
+		m.Return = t
+	} else if r := op.Responses["200"]; r != nil {
+		m.Return = elemType(r.Schema)
+	}
+
+	for _, p := range op.Parameters {
+		d := paramData{Name: p.Name, Doc: escape(p.Description)}
+		if p.Required {
+			d.Var = p.Name
+			d.Type = scalarType(p.Type, p.Format)
+			m.Required = append(m.Required, d)
+			continue
+		}
+		d.Field = p.ExportName
+		if d.Field == "" {
+			d.Field = fieldName(p.Name)
+		}
+		d.Type = "optional." + optionalType(p)
+		m.Optional = append(m.Optional, d)
+	}
+	return m
+}
+
+// methodName returns the Go name of an operation, e.g. "OrderNew" for Order.new.
+func methodName(op *operation) string {
+	var b strings.Builder
+	for _, part := range strings.Split(op.OperationID, ".") {
+		r := []rune(part)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	return b.String()
+}
+
+// escape prepares a description for a doc comment the way swagger-codegen
+// does: on a single line, with quotes backslashed and markup HTML escaped.
+func escape(s string) string {
+	s = strings.NewReplacer("\n", " ", "\r", " ", "\t", " ").Replace(s)
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+	s = html.EscapeString(s)
+	return strings.NewReplacer("&#34;", "&quot;", "`", "&#x60;", "=", "&#x3D;").Replace(s)
+}
+
+var serviceTemplate = template.Must(template.New("service").Parse(`package bitmex
+
+import (
+	"context"
+	"net/http"
+{{if .HasOpts}}
+	"github.com/go-numb/go-bitmex/optional"
+{{end}})
+
+type {{.Service}} service
+{{range .Ops}}
+/*
+{{$.Service}} {{.Summary}}{{if .Notes}}
+{{.Notes}}{{end}}
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+{{- range .Required}}
+ * @param {{.Name}} {{.Doc}}
+{{- end}}
+{{- if .Optional}}
+ * @param optional nil or *{{.OptionsType}} - Optional Parameters:
+{{- range .Optional}}
+     * @param "{{.Field}}" ({{.Type}}) -  {{.Doc}}
+{{- end}}
+{{- end}}
+
+{{if .Return}}@return {{.Return}}{{end}}
+*/
+{{- if .Optional}}
+
+type {{.OptionsType}} struct {
+{{- range .Optional}}
+	{{.Field}} {{.Type}}
+{{- end}}
+}
+{{end}}
+func (a *{{$.Service}}) {{.Name}}(ctx context.Context{{range .Required}}, {{.Var}} {{.Type}}{{end}}{{if .Optional}}, localVarOptionals *{{.OptionsType}}{{end}}) ({{if .Return}}{{.Return}}, {{end}}*http.Response, error) {
+{{- if .Return}}
+	return do[{{.Return}}](ctx, a.client, {{template "endpoint" .}}, {{template "opts" .}}{{template "params" .}})
+{{- else}}
+	return a.client.invoke(ctx, {{template "endpoint" .}}, {{template "opts" .}}, nil{{template "params" .}})
+{{- end}}
+}
+{{end}}
+{{- define "endpoint"}}endpoint{Operation: "{{.Operation}}", Method: http.Method{{.Method}}, Path: "{{.Path}}"{{if .Bulk}}, Bulk: true{{end}}}{{end}}
+{{- define "opts"}}{{if .Optional}}localVarOptionals{{else}}nil{{end}}{{end}}
+{{- define "params"}}{{range .Required}}, param{"{{.Name}}", {{.Var}}}{{end}}{{end}}`))
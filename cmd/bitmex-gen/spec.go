@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// spec is the part of a swagger 2.0 document the generator reads.
+type spec struct {
+	Paths       map[string]map[string]*operation `yaml:"paths"`
+	Definitions map[string]*schema               `yaml:"definitions"`
+}
+
+type operation struct {
+	Tags        []string             `yaml:"tags"`
+	Summary     string               `yaml:"summary"`
+	Description string               `yaml:"description"`
+	OperationID string               `yaml:"operationId"`
+	Parameters  []*parameter         `yaml:"parameters"`
+	Responses   map[string]*response `yaml:"responses"`
+
+	// set while loading
+	Method string `yaml:"-"`
+	Path   string `yaml:"-"`
+}
+
+type parameter struct {
+	Name        string `yaml:"name"`
+	In          string `yaml:"in"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+	Type        string `yaml:"type"`
+	Format      string `yaml:"format"`
+	ExportName  string `yaml:"x-exportParamName"`
+}
+
+type response struct {
+	Schema *schema `yaml:"schema"`
+}
+
+type schema struct {
+	Ref         string     `yaml:"$ref"`
+	Type        string     `yaml:"type"`
+	Format      string     `yaml:"format"`
+	Description string     `yaml:"description"`
+	Required    []string   `yaml:"required"`
+	Items       *schema    `yaml:"items"`
+	Properties  properties `yaml:"properties"`
+}
+
+// property is a named schema of an object, kept in the order of the document.
+type property struct {
+	Name string
+	*schema
+}
+
+type properties []property
+
+func (p *properties) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: properties is not a mapping", n.Line)
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		s := &schema{}
+		if err := n.Content[i+1].Decode(s); err != nil {
+			return err
+		}
+		*p = append(*p, property{Name: n.Content[i].Value, schema: s})
+	}
+	return nil
+}
+
+// refName returns the definition name a "#/definitions/Name" reference points to.
+func refName(ref string) string {
+	return strings.TrimPrefix(ref, "#/definitions/")
+}
+
+func loadSpec(path string) (*spec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &spec{}
+	if err := yaml.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for p, ops := range s.Paths {
+		for m, op := range ops {
+			op.Method, op.Path = strings.ToUpper(m), p
+		}
+	}
+	return s, nil
+}
@@ -2,7 +2,9 @@ package bitmex
 
 import (
 	"context"
+	"log"
 	"net/http"
+	"time"
 )
 
 // contextKeys are used to identify the type of value in the context.
@@ -10,17 +12,49 @@ type contextKey string
 
 var ContextAPIKey = contextKey("apikey")
 
+// ContextSigner carries the Signer of a call, see WithSigner.
+var ContextSigner = contextKey("signer")
+
+// DefaultExpiryWindow is how long a signed request stays valid unless
+// Configuration.ExpiryWindow says otherwise.
+const DefaultExpiryWindow = 60 * time.Second
+
+// ContextRequestMeta carries the RequestMeta of a call, see WithRequestMeta.
+var ContextRequestMeta = contextKey("requestmeta")
+
 // APIKey provides API key based authentication to a request passed via context using ContextAPIKey
 type APIKey struct {
 	Key    string
 	Secret string
 }
 
+// RequestMeta is caller supplied metadata attached to a request through its
+// context. It is reported in hooks, log lines and *APIError.
+type RequestMeta struct {
+	RequestID string
+	Tag       string // e.g. the name of the strategy sending the request
+}
+
 type Configuration struct {
 	BasePath      string            `json:"basePath,omitempty"`
 	DefaultHeader map[string]string `json:"defaultHeader,omitempty"`
 	UserAgent     string            `json:"userAgent,omitempty"`
 	HTTPClient    *http.Client
+	// RateLimit selects whether requests wait or fail once the budget is spent.
+	RateLimit RateLimitPolicy `json:"rateLimit,omitempty"`
+	// Logger, when set, receives a line for every failed request.
+	Logger *log.Logger `json:"-"`
+	// Retry, when set, retries calls failing with an overload, a rate limit or a transport error.
+	Retry *RetryPolicy `json:"retry,omitempty"`
+	// Interceptors see every call made by the client, in order.
+	Interceptors []Interceptor `json:"-"`
+	// Signer authenticates the calls whose context carries no API key or Signer.
+	Signer Signer `json:"-"`
+	// ExpiryWindow is how long a signed request stays valid, DefaultExpiryWindow when zero.
+	ExpiryWindow time.Duration `json:"expiryWindow,omitempty"`
+	// Clock estimates the server time from the responses; api-expires is
+	// computed from it. NewAPIClient creates one, logging to Logger, when nil.
+	Clock *Clock `json:"-"`
 }
 
 func NewConfiguration() *Configuration {
@@ -40,5 +74,31 @@ func NewTestnetConfiguration() *Configuration {
 }
 
 func NewAPIKeyContext(key, secret string) context.Context {
-	return context.WithValue(context.TODO(), ContextAPIKey, APIKey{Key: key, Secret: secret})
+	return WithAPIKey(context.Background(), key, secret)
+}
+
+// WithAPIKey returns a copy of ctx that authenticates requests with key and secret,
+// keeping the cancellation and deadline of ctx.
+func WithAPIKey(ctx context.Context, key, secret string) context.Context {
+	return context.WithValue(ctx, ContextAPIKey, APIKey{Key: key, Secret: secret})
+}
+
+// WithSigner returns a copy of ctx that authenticates requests with signer,
+// keeping the cancellation and deadline of ctx.
+func WithSigner(ctx context.Context, signer Signer) context.Context {
+	return context.WithValue(ctx, ContextSigner, signer)
+}
+
+// WithRequestMeta returns a copy of ctx carrying meta for the requests made with it.
+func WithRequestMeta(ctx context.Context, meta RequestMeta) context.Context {
+	return context.WithValue(ctx, ContextRequestMeta, meta)
+}
+
+// RequestMetaFromContext returns the RequestMeta stored in ctx by WithRequestMeta.
+func RequestMetaFromContext(ctx context.Context) (RequestMeta, bool) {
+	if ctx == nil {
+		return RequestMeta{}, false
+	}
+	meta, ok := ctx.Value(ContextRequestMeta).(RequestMeta)
+	return meta, ok
 }
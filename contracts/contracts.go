@@ -0,0 +1,221 @@
+// Package contracts computes the value, notional, fees and profit of BitMEX
+// contracts from the specification of their Instrument, for the three kinds
+// of contract BitMEX lists:
+//
+//   - inverse, e.g. XBTUSD: quoted in USD, settled in XBT, one contract worth
+//     1 USD, so worth multiplier / price satoshis;
+//   - quanto, e.g. ETHUSD: quoted in USD, settled in XBT at a fixed rate, one
+//     contract worth multiplier × price satoshis;
+//   - linear, e.g. XBTUSDT: settled in its quote currency, one contract worth
+//     multiplier × price units of it.
+//
+// Values are signed as BitMEX signs costs: a long position of an inverse
+// contract costs a negative amount, and the profit of a position is its value
+// at the exit price minus its value at the entry price.
+//
+//	c, err := contracts.New(instrument)
+//	pnl := c.PnL(position.CurrentQty, position.AvgEntryPrice, exit)
+//	stop := c.BreakEven(position.CurrentQty, position.AvgEntryPrice, instrument.TakerFee, instrument.TakerFee)
+package contracts
+
+import (
+	"fmt"
+
+	"github.com/go-numb/go-bitmex"
+	"github.com/go-numb/go-bitmex/decimal"
+)
+
+// places is the number of decimal places of the prices and notionals computed
+// by division.
+const places = 10
+
+// Kind is the kind of a contract.
+type Kind int
+
+const (
+	Linear Kind = iota
+	Inverse
+	Quanto
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Inverse:
+		return "inverse"
+	case Quanto:
+		return "quanto"
+	}
+	return "linear"
+}
+
+// Contract is the specification of a contract, as its Instrument gives it.
+type Contract struct {
+	Symbol     string
+	Kind       Kind
+	Multiplier int64
+	Settle     bitmex.Currency
+	LotSize    int
+
+	// quoteToSettle converts quote coins of a linear contract to settle units.
+	quoteToSettle decimal.Decimal
+}
+
+// New returns the contract of inst. It fails for an instrument without a
+// multiplier or a settlement currency, such as an index.
+func New(inst bitmex.Instrument) (Contract, error) {
+	if inst.Multiplier == 0 || inst.SettlCurrency == "" {
+		return Contract{}, fmt.Errorf("contracts: %s is not a tradable contract", inst.Symbol)
+	}
+	c := Contract{
+		Symbol:     inst.Symbol,
+		Multiplier: int64(inst.Multiplier),
+		Settle:     bitmex.Currency(inst.SettlCurrency),
+		LotSize:    inst.LotSize,
+	}
+	switch {
+	case inst.IsInverse:
+		c.Kind = Inverse
+	case inst.IsQuanto:
+		c.Kind = Quanto
+	}
+	c.quoteToSettle = decimal.NewFromInt(1).Div(decimal.New(1, c.Settle.Places()), 0)
+	if inst.QuoteToSettleMultiplier > 0 {
+		c.quoteToSettle = decimal.NewFromInt(int64(inst.QuoteToSettleMultiplier))
+	}
+	return c, nil
+}
+
+// UnitValue returns the value of one long contract at price, in settle units,
+// unrounded: that of an inverse contract has places decimal places.
+func (c Contract) UnitValue(price bitmex.Decimal) decimal.Decimal {
+	return c.cost(1, bitmex.ToDecimal(price))
+}
+
+// Value returns the value of qty contracts at price, in settle units: the cost
+// of a position of qty contracts entered at price, or its mark value at the
+// mark price. qty is negative for a short position. As BitMEX computes it,
+// the value of all the contracts is rounded once, half away from zero, e.g.
+// -1423589 XBt for 100 XBTUSD at 7024.5 where 100 contracts of -14236 XBt
+// would make -1423600.
+func (c Contract) Value(qty int, price bitmex.Decimal) bitmex.Amount {
+	return c.amount(c.cost(qty, bitmex.ToDecimal(price)))
+}
+
+// PnL returns the profit of a position of qty contracts entered at entry and
+// exited at exit, in settle units, before fees.
+func (c Contract) PnL(qty int, entry, exit bitmex.Decimal) bitmex.Amount {
+	d, _ := c.Value(qty, exit).Sub(c.Value(qty, entry))
+	return d
+}
+
+// Fee returns the fee of trading qty contracts at price at rate, e.g. the
+// TakerFee of the Instrument, in settle units. A negative rate is a rebate.
+func (c Contract) Fee(qty int, price bitmex.Decimal, rate float64) bitmex.Amount {
+	v := c.cost(qty, bitmex.ToDecimal(price)).Abs()
+	return c.amount(v.Mul(decimal.NewFromFloat(rate)))
+}
+
+// BreakEven returns the exit price at which a position of qty contracts
+// entered at entry makes no profit once the fees of entering at entryFee and
+// exiting at exitFee are paid, or entry when qty is 0.
+func (c Contract) BreakEven(qty int, entry bitmex.Decimal, entryFee, exitFee float64) bitmex.Decimal {
+	if qty == 0 {
+		return entry
+	}
+	s := decimal.NewFromInt(1)
+	if qty < 0 {
+		s = s.Neg()
+	}
+	in, out := decimal.NewFromFloat(entryFee), decimal.NewFromFloat(exitFee)
+	e := bitmex.ToDecimal(entry)
+	// The profit is linear in the exit price p, or in 1/p for an inverse
+	// contract, and so is the fee of exiting: solve profit = fees for p.
+	if c.Kind == Inverse {
+		// s(1/e - 1/p) = in/e + out/p
+		return bitmex.FromDecimal(e.Mul(s.Add(out)).Div(s.Sub(in), places))
+	}
+	// s(p - e) = in*e + out*p
+	return bitmex.FromDecimal(e.Mul(s.Add(in)).Div(s.Sub(out), places))
+}
+
+// HomeNotional returns the notional of qty contracts at price in the home
+// currency, the underlying, e.g. XBT for XBTUSD. It is positive for a long
+// position, as in Position and Execution. BitMEX counts a quanto contract as
+// 1 USD of the underlying, e.g. 10 ETHUSD at 408.8 are 10/408.8 ETH, whatever
+// their value in XBT.
+func (c Contract) HomeNotional(qty int, price bitmex.Decimal) bitmex.Decimal {
+	q, p := decimal.NewFromInt(int64(qty)), bitmex.ToDecimal(price)
+	switch {
+	case c.Kind == Inverse:
+		return bitmex.FromDecimal(c.Value(qty, price).Neg().Coins())
+	case c.Kind == Quanto && !p.IsZero():
+		return bitmex.FromDecimal(q.Div(p, places))
+	case c.Kind == Linear:
+		return bitmex.FromDecimal(q.Mul(decimal.NewFromInt(c.Multiplier)).Div(c.quoteToSettle, places))
+	}
+	return bitmex.FromDecimal(decimal.Decimal{})
+}
+
+// ForeignNotional returns the notional of qty contracts at price in the
+// foreign currency, the quote, e.g. USD for XBTUSD. It is negative for a long
+// position, as in Position and Execution.
+func (c Contract) ForeignNotional(qty int, price bitmex.Decimal) bitmex.Decimal {
+	q := decimal.NewFromInt(int64(qty))
+	switch c.Kind {
+	case Inverse:
+		return bitmex.FromDecimal(q.Mul(c.face()).Neg())
+	case Quanto:
+		return bitmex.FromDecimal(q.Neg())
+	}
+	return bitmex.FromDecimal(bitmex.ToDecimal(c.HomeNotional(qty, price)).Mul(bitmex.ToDecimal(price)).Neg())
+}
+
+// ContractsForHome returns the number of contracts at price holding home in
+// the home currency, rounded toward zero to whole lots. It is negative for a
+// negative home.
+func (c Contract) ContractsForHome(home, price bitmex.Decimal) int {
+	h, p := bitmex.ToDecimal(home), bitmex.ToDecimal(price)
+	switch c.Kind {
+	case Inverse:
+		return c.lots(h.Mul(p).Div(c.face(), places))
+	case Quanto:
+		return c.lots(h.Mul(p))
+	}
+	return c.lots(h.Mul(c.quoteToSettle).Div(decimal.NewFromInt(c.Multiplier), places))
+}
+
+// ContractsForForeign returns the number of contracts at price holding
+// foreign in the foreign currency, e.g. 100 for 100 USD of XBTUSD, rounded
+// toward zero to whole lots. It is negative for a negative foreign.
+func (c Contract) ContractsForForeign(foreign, price bitmex.Decimal) int {
+	f, p := bitmex.ToDecimal(foreign), bitmex.ToDecimal(price)
+	switch {
+	case c.Kind == Inverse:
+		return c.lots(f.Div(c.face(), places))
+	case c.Kind == Quanto:
+		return c.lots(f)
+	case p.IsZero():
+		return 0
+	}
+	return c.ContractsForHome(bitmex.FromDecimal(f.Div(p, places)), price)
+}
+
+// face returns the quote coins one inverse contract is worth, e.g. 1 USD for
+// XBTUSD.
+func (c Contract) face() decimal.Decimal {
+	return decimal.New(c.Multiplier, c.Settle.Places()).Abs()
+}
+
+// lots returns qty rounded toward zero to whole lots.
+func (c Contract) lots(qty decimal.Decimal) int {
+	n := int(qty.IntPart())
+	if c.LotSize > 1 {
+		n = n / c.LotSize * c.LotSize
+	}
+	return n
+}
+
+// amount returns v, in settle units, rounded half away from zero.
+func (c Contract) amount(v decimal.Decimal) bitmex.Amount {
+	return bitmex.NewAmount(v.Round(0).IntPart(), c.Settle)
+}
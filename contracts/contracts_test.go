@@ -0,0 +1,130 @@
+package contracts_test
+
+import (
+	"testing"
+
+	"github.com/go-numb/go-bitmex"
+	"github.com/go-numb/go-bitmex/contracts"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The instruments as BitMEX lists them, but for their prices.
+var instruments = map[string]bitmex.Instrument{
+	"XBTUSD": {
+		Symbol: "XBTUSD", Multiplier: -100000000, IsInverse: true, SettlCurrency: "XBt", LotSize: 100,
+		TakerFee: 0.00075,
+	},
+	"ETHUSD": {
+		Symbol: "ETHUSD", Multiplier: 100, IsQuanto: true, SettlCurrency: "XBt", LotSize: 1,
+		TakerFee: 0.00075,
+	},
+	"XBTUSDT": {
+		Symbol: "XBTUSDT", Multiplier: 1, SettlCurrency: "USDt", LotSize: 1000,
+		UnderlyingToPositionMultiplier: 1000000, QuoteToSettleMultiplier: 1000000, TakerFee: 0.0005,
+	},
+}
+
+func contract(t *testing.T, symbol string) contracts.Contract {
+	t.Helper()
+	c, err := contracts.New(instruments[symbol])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestNotional(t *testing.T) {
+	// Executions: the signed lastQty, lastPx, homeNotional, foreignNotional
+	// and execCost BitMEX reports for them.
+	for _, tt := range []struct {
+		symbol  string
+		qty     int
+		price   float64
+		home    string
+		foreign string
+		cost    int64
+	}{
+		{"XBTUSD", 1, 7024.5, "0.00014236", "-1", -14236},
+		{"XBTUSD", 100, 7024.5, "0.01423589", "-100", -1423589},
+		{"XBTUSD", -100, 10000, "-0.01", "100", 1000000},
+		{"ETHUSD", 10, 408.8, "0.0244618395", "-10", 408800},
+		{"ETHUSD", -1, 129.6, "-0.0077160494", "1", -12960},
+		{"XBTUSDT", 1000, 30000, "0.001", "-30", 30000000},
+		{"XBTUSDT", -2000, 29999.5, "-0.002", "59.999", -59999000},
+	} {
+		c := contract(t, tt.symbol)
+		price := bitmex.FromFloat(tt.price)
+		name := tt.symbol + " " + bitmex.ToDecimal(price).String()
+		assert.Equal(t, tt.home, bitmex.ToDecimal(c.HomeNotional(tt.qty, price)).String(), "home "+name)
+		assert.Equal(t, tt.foreign, bitmex.ToDecimal(c.ForeignNotional(tt.qty, price)).String(), "foreign "+name)
+		assert.Equal(t, bitmex.NewAmount(tt.cost, c.Settle), c.Value(tt.qty, price), "value "+name)
+	}
+}
+
+func TestPnL(t *testing.T) {
+	// Positions closed at exit: the realisedPnl BitMEX reports for them,
+	// before fees.
+	for _, tt := range []struct {
+		name        string
+		symbol      string
+		qty         int
+		entry, exit float64
+		pnl         int64
+	}{
+		{"inverse long", "XBTUSD", 100, 10000, 11000, 90909},
+		{"inverse short", "XBTUSD", -100, 10000, 11000, -90909},
+		{"inverse rounded once", "XBTUSD", 100, 7024.5, 7025, 101},
+		{"quanto long", "ETHUSD", 10, 400, 410, 10000},
+		{"quanto short", "ETHUSD", -10, 400, 410, -10000},
+		{"linear long", "XBTUSDT", 1000, 30000, 30500, 500000},
+		{"linear short", "XBTUSDT", -1000, 30000, 30500, -500000},
+	} {
+		c := contract(t, tt.symbol)
+		got := c.PnL(tt.qty, bitmex.FromFloat(tt.entry), bitmex.FromFloat(tt.exit))
+		assert.Equal(t, bitmex.NewAmount(tt.pnl, c.Settle), got, tt.name)
+	}
+}
+
+func TestFee(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		symbol string
+		qty    int
+		price  float64
+		rate   float64
+		fee    int64
+	}{
+		{"inverse taker", "XBTUSD", 100, 7024.5, 0.00075, 1068},
+		{"inverse maker rebate", "XBTUSD", -100, 10000, -0.00025, -250},
+		{"quanto taker", "ETHUSD", 10, 408.8, 0.00075, 307},
+		{"linear taker", "XBTUSDT", 1000, 30000, 0.0005, 15000},
+	} {
+		c := contract(t, tt.symbol)
+		assert.Equal(t, bitmex.NewAmount(tt.fee, c.Settle), c.Fee(tt.qty, bitmex.FromFloat(tt.price), tt.rate), tt.name)
+	}
+}
+
+func TestContracts(t *testing.T) {
+	for _, tt := range []struct {
+		symbol  string
+		price   float64
+		home    float64
+		foreign float64
+		want    int
+	}{
+		{"XBTUSD", 10000, 0.01, 100, 100},
+		{"XBTUSD", 10000, 0.0149, 149, 100},
+		{"ETHUSD", 400, 0.025, 10, 10},
+		{"XBTUSDT", 30000, 0.0015, 45, 1000},
+		{"XBTUSDT", 30000, -0.002, -60, -2000},
+	} {
+		c := contract(t, tt.symbol)
+		price := bitmex.FromFloat(tt.price)
+		assert.Equal(t, tt.want, c.ContractsForHome(bitmex.FromFloat(tt.home), price), "home %s %v", tt.symbol, tt.home)
+		assert.Equal(t, tt.want, c.ContractsForForeign(bitmex.FromFloat(tt.foreign), price), "foreign %s %v", tt.symbol, tt.foreign)
+	}
+
+	_, err := contracts.New(bitmex.Instrument{Symbol: ".BXBT"})
+	assert.Error(t, err)
+}
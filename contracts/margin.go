@@ -0,0 +1,237 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-numb/go-bitmex"
+	"github.com/go-numb/go-bitmex/decimal"
+	"github.com/go-numb/go-bitmex/optional"
+)
+
+// ErrRiskLimit is returned by Simulate when the position would exceed its
+// risk limit.
+var ErrRiskLimit = errors.New("contracts: risk limit exceeded")
+
+// Scenario is a hypothetical order, change of leverage, or both, on a position.
+type Scenario struct {
+	Qty   int            // order quantity, negative to sell
+	Price bitmex.Decimal // order price, the mark price when zero
+	// Leverage is the leverage to set, as PositionUpdateLeverage takes it: 0
+	// for cross margin, up to 100 for isolated margin.
+	Leverage optional.Float64
+}
+
+// Projection is the state of a position and its account after a Scenario.
+type Projection struct {
+	Qty        int            // position quantity
+	EntryPrice bitmex.Decimal // average entry price, zero when flat
+	Cross      bool           // whether the position is cross margined
+	Leverage   float64        // isolated leverage, 0 for cross margin
+
+	// InitMarginReq and MaintMarginReq are the margin requirements of the
+	// position, stepped up by its risk limit.
+	InitMarginReq  float64
+	MaintMarginReq float64
+	RiskLimit      bitmex.Amount
+
+	InitMargin      bitmex.Amount // initial margin of the position
+	Fee             bitmex.Amount // commission of the order at the taker fee
+	Required        bitmex.Amount // margin the scenario takes from the available margin, negative when it frees some
+	AvailableMargin bitmex.Amount // available margin of the account
+
+	// LiquidationPrice and BankruptPrice are zero when flat or when the
+	// position can't lose its margin, e.g. a short inverse position with
+	// margin above its cost.
+	LiquidationPrice bitmex.Decimal
+	BankruptPrice    bitmex.Decimal
+}
+
+/*
+Simulate projects the position pos of inst, held in the account of margin,
+through s: the quantity, entry price and margin requirements of the position,
+the initial margin it needs and the margin left available, and the prices at
+which it would be liquidated and bankrupt, as BitMEX computes them.
+
+An isolated position is backed by its initial margin, a cross margined one by
+the margin balance of the account less the maintenance margin of its other
+positions and orders, which are held at their current values. The margin
+requirements step up by the maintenance margin of inst for every risk step of
+the risk limit of pos above that of inst; with no risk limit on pos, the
+smallest one holding the position applies.
+
+The projection is returned with ErrRiskLimit when the position would exceed
+its risk limit, and with bitmex.ErrInsufficientBalance when the account lacks
+the available margin, in which cases BitMEX would reject the order.
+*/
+func Simulate(inst bitmex.Instrument, margin bitmex.Margin, pos bitmex.Position, s Scenario) (Projection, error) {
+	c, err := New(inst)
+	if err != nil {
+		return Projection{}, err
+	}
+	mark := bitmex.ToDecimal(pos.MarkPrice)
+	if mark.IsZero() {
+		mark = bitmex.ToDecimal(inst.MarkPrice)
+	}
+	price := bitmex.ToDecimal(s.Price)
+	if price.IsZero() {
+		price = mark
+	}
+	if s.Qty != 0 && price.Sign() <= 0 {
+		return Projection{}, fmt.Errorf("contracts: %s has no price to simulate the order at", inst.Symbol)
+	}
+
+	p := Projection{Qty: pos.CurrentQty + s.Qty, Cross: pos.CrossMargin, Leverage: pos.Leverage}
+	if p.Cross {
+		p.Leverage = 0
+	}
+	if s.Leverage.IsSet() {
+		p.Cross, p.Leverage = s.Leverage.Value() == 0, s.Leverage.Value()
+	}
+
+	// The cost of the position after the order, its realised profit, and the
+	// fee of the order.
+	entry := bitmex.ToDecimal(pos.AvgEntryPrice)
+	cost := c.cost(pos.CurrentQty, entry)
+	realised := decimal.Decimal{}
+	switch closed := closedQty(pos.CurrentQty, s.Qty); {
+	case p.Qty == 0:
+		realised = c.cost(pos.CurrentQty, price).Sub(cost)
+		cost = decimal.Decimal{}
+	case closed != 0:
+		share := cost.Mul(decimal.NewFromInt(int64(closed))).Div(decimal.NewFromInt(int64(pos.CurrentQty)), places)
+		realised = c.cost(closed, price).Sub(share)
+		cost = cost.Sub(share).Add(c.cost(s.Qty+closed, price))
+	default:
+		cost = cost.Add(c.cost(s.Qty, price))
+	}
+	fee := c.cost(s.Qty, price).Abs().Mul(decimal.NewFromFloat(inst.TakerFee))
+	p.Fee = c.amount(fee)
+	if p.Qty != 0 {
+		p.EntryPrice = bitmex.FromDecimal(c.entryPrice(p.Qty, cost))
+	}
+
+	// The margin requirements of the risk limit holding the position.
+	risk := cost.Abs()
+	base, step := decimal.NewFromInt(int64(inst.RiskLimit)), decimal.NewFromInt(int64(inst.RiskStep))
+	limit := decimal.NewFromInt(pos.RiskLimit.Int64())
+	if limit.IsZero() {
+		limit = base
+		for !step.IsZero() && risk.GreaterThan(limit) {
+			limit = limit.Add(step)
+		}
+	}
+	p.RiskLimit = c.amount(limit)
+	p.InitMarginReq, p.MaintMarginReq = inst.InitMargin, inst.MaintMargin
+	if !step.IsZero() && limit.GreaterThan(base) {
+		steps := limit.Sub(base).Div(step, 0).Float64()
+		p.InitMarginReq += steps * inst.MaintMargin
+		p.MaintMarginReq += steps * inst.MaintMargin
+	}
+	initReq := decimal.NewFromFloat(p.InitMarginReq)
+	if !p.Cross && p.Leverage > 0 {
+		lev := decimal.NewFromInt(1).Div(decimal.NewFromFloat(p.Leverage), places)
+		if lev.LessThan(initReq) {
+			return p, fmt.Errorf("contracts: leverage %v above the maximum %v of %s", p.Leverage, 1/p.InitMarginReq, inst.Symbol)
+		}
+		initReq = lev
+	}
+
+	// The margin the position needs, against what it holds.
+	initMargin := risk.Mul(initReq)
+	p.InitMargin = c.amount(initMargin)
+	held := decimal.NewFromInt(pos.PosMargin.Int64())
+	if pos.CrossMargin {
+		held = c.cost(pos.CurrentQty, entry).Abs().Mul(decimal.NewFromFloat(pos.InitMarginReq))
+	}
+	required := initMargin.Sub(held).Add(fee).Sub(realised)
+	p.Required = c.amount(required)
+	p.AvailableMargin = c.amount(decimal.NewFromInt(margin.AvailableMargin.Int64()).Sub(required))
+
+	// The margin backing the position.
+	collateral := initMargin
+	if p.Cross {
+		others := decimal.NewFromInt(margin.MaintMargin.Int64() - pos.MaintMargin.Int64())
+		collateral = decimal.NewFromInt(margin.MarginBalance.Int64() - pos.UnrealisedPnl.Int64()).
+			Sub(others).Add(realised).Sub(fee)
+	}
+	if p.Qty != 0 {
+		k := decimal.NewFromFloat(p.MaintMarginReq + inst.TakerFee)
+		p.LiquidationPrice = bitmex.FromDecimal(c.liquidation(p.Qty, cost, collateral, k))
+		p.BankruptPrice = bitmex.FromDecimal(c.liquidation(p.Qty, cost, collateral, decimal.Decimal{}))
+	}
+
+	switch {
+	case !limit.IsZero() && risk.GreaterThan(limit):
+		return p, fmt.Errorf("%w: %s position of %v above %v", ErrRiskLimit, inst.Symbol, c.amount(risk), p.RiskLimit)
+	case s.Qty != 0 && p.AvailableMargin.Sign() < 0:
+		return p, fmt.Errorf("%w: %s order needs %v, %v available", bitmex.ErrInsufficientBalance, inst.Symbol,
+			p.Required, margin.AvailableMargin)
+	}
+	return p, nil
+}
+
+// closedQty returns the part of the order qty closing the position pos, with
+// the sign of pos.
+func closedQty(pos, qty int) int {
+	switch {
+	case pos > 0 && qty < 0:
+		return min(pos, -qty)
+	case pos < 0 && qty > 0:
+		return -min(-pos, qty)
+	}
+	return 0
+}
+
+// cost returns the value of qty contracts at price, in settle units, unrounded.
+func (c Contract) cost(qty int, price decimal.Decimal) decimal.Decimal {
+	if qty == 0 || price.IsZero() {
+		return decimal.Decimal{}
+	}
+	m := decimal.NewFromInt(int64(qty) * c.Multiplier)
+	if c.Kind == Inverse {
+		return m.Abs().Neg().Div(price, places).Mul(decimal.NewFromInt(int64(sign(qty))))
+	}
+	return m.Mul(price)
+}
+
+// entryPrice returns the average entry price of qty contracts costing cost.
+func (c Contract) entryPrice(qty int, cost decimal.Decimal) decimal.Decimal {
+	m := decimal.NewFromInt(int64(qty) * c.Multiplier)
+	if c.Kind == Inverse {
+		return m.Abs().Neg().Mul(decimal.NewFromInt(int64(sign(qty)))).Div(cost, places)
+	}
+	return cost.Div(m, places)
+}
+
+// liquidation returns the price at which a position of qty contracts costing
+// cost, backed by collateral, has lost all of it but k times its value, or
+// zero when there is none. The profit of the position at p is
+// cost(p) - cost, linear in p, or in 1/p for an inverse contract.
+func (c Contract) liquidation(qty int, cost, collateral, k decimal.Decimal) decimal.Decimal {
+	s := decimal.NewFromInt(int64(sign(qty)))
+	a := decimal.NewFromInt(int64(qty) * c.Multiplier).Abs()
+	var num, den decimal.Decimal
+	if c.Kind == Inverse {
+		// collateral + s·a/e - s·a/p = k·a/p
+		num, den = k.Add(s).Mul(a), collateral.Add(s.Mul(cost.Abs()))
+	} else {
+		// collateral + s·a·(p - e) = k·a·p
+		num, den = s.Mul(cost.Abs()).Sub(collateral), a.Mul(s.Sub(k))
+	}
+	if den.IsZero() {
+		return decimal.Decimal{}
+	}
+	p := num.Div(den, places)
+	if p.Sign() <= 0 {
+		return decimal.Decimal{}
+	}
+	return p
+}
+
+func sign(n int) int {
+	if n < 0 {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,145 @@
+package contracts_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-numb/go-bitmex"
+	"github.com/go-numb/go-bitmex/contracts"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// xbtusd is XBTUSD with its base risk limit of 200 XBT, stepping by 100 XBT.
+var xbtusd = bitmex.Instrument{
+	Symbol: "XBTUSD", Multiplier: -100000000, IsInverse: true, SettlCurrency: "XBt", LotSize: 100,
+	TickSize: bitmex.FromFloat(0.5), InitMargin: 0.01, MaintMargin: 0.005, TakerFee: 0.00075,
+	RiskLimit: 20000000000, RiskStep: 10000000000, MarkPrice: bitmex.FromFloat(10000),
+}
+
+func xbt(v int64) bitmex.Amount {
+	return bitmex.NewAmount(v, bitmex.XBt)
+}
+
+// account returns the margin of an account of balance XBt and no other
+// position.
+func account(balance int64) bitmex.Margin {
+	return bitmex.Margin{Currency: "XBt", WalletBalance: xbt(balance), MarginBalance: xbt(balance), AvailableMargin: xbt(balance)}
+}
+
+func TestSimulatePosition(t *testing.T) {
+	// Positions at a mark price of 10000, with the liquidation and bankruptcy
+	// prices BitMEX reports for them, rounded to the tick size.
+	for _, tt := range []struct {
+		name    string
+		balance int64
+		pos     bitmex.Position
+	}{
+		{"isolated long 10x", 100000000, bitmex.Position{
+			CurrentQty: 1000, AvgEntryPrice: bitmex.FromFloat(10000), Leverage: 10,
+			InitMarginReq: 0.1, MaintMarginReq: 0.005, PosMargin: xbt(1000000),
+			LiquidationPrice: bitmex.FromFloat(9143.5), BankruptPrice: bitmex.FromFloat(9091),
+		}},
+		{"isolated short 10x", 100000000, bitmex.Position{
+			CurrentQty: -1000, AvgEntryPrice: bitmex.FromFloat(10000), Leverage: 10,
+			InitMarginReq: 0.1, MaintMarginReq: 0.005, PosMargin: xbt(1000000),
+			LiquidationPrice: bitmex.FromFloat(11047), BankruptPrice: bitmex.FromFloat(11111),
+		}},
+		{"isolated long 100x", 100000000, bitmex.Position{
+			CurrentQty: 10000, AvgEntryPrice: bitmex.FromFloat(10000), Leverage: 100,
+			InitMarginReq: 0.01, MaintMarginReq: 0.005, PosMargin: xbt(1000000),
+			LiquidationPrice: bitmex.FromFloat(9958), BankruptPrice: bitmex.FromFloat(9901),
+		}},
+		{"isolated long above the base risk limit", 10000000000, bitmex.Position{
+			CurrentQty: 2500000, AvgEntryPrice: bitmex.FromFloat(10000), Leverage: 25, RiskLimit: xbt(30000000000),
+			InitMarginReq: 0.04, MaintMarginReq: 0.01, PosMargin: xbt(1000000000),
+			LiquidationPrice: bitmex.FromFloat(9719), BankruptPrice: bitmex.FromFloat(9615.5),
+		}},
+		{"cross long", 10000000, bitmex.Position{
+			CurrentQty: 1000, AvgEntryPrice: bitmex.FromFloat(10000), CrossMargin: true,
+			InitMarginReq: 0.01, MaintMarginReq: 0.005,
+			LiquidationPrice: bitmex.FromFloat(5029), BankruptPrice: bitmex.FromFloat(5000),
+		}},
+		{"cross short", 5000000, bitmex.Position{
+			CurrentQty: -1000, AvgEntryPrice: bitmex.FromFloat(10000), CrossMargin: true,
+			InitMarginReq: 0.01, MaintMarginReq: 0.005,
+			LiquidationPrice: bitmex.FromFloat(19885), BankruptPrice: bitmex.FromFloat(20000),
+		}},
+		// BitMEX reports its maximum price, 100000000, where Simulate reports 0.
+		{"cross short backed by more than its cost", 20000000, bitmex.Position{
+			CurrentQty: -1000, AvgEntryPrice: bitmex.FromFloat(10000), CrossMargin: true,
+			InitMarginReq: 0.01, MaintMarginReq: 0.005,
+		}},
+	} {
+		tt.pos.Symbol, tt.pos.Currency, tt.pos.MarkPrice = "XBTUSD", "XBt", bitmex.FromFloat(10000)
+		p, err := contracts.Simulate(xbtusd, account(tt.balance), tt.pos, contracts.Scenario{})
+		if !assert.NoError(t, err, tt.name) {
+			continue
+		}
+		assert.Equal(t, tt.pos.CurrentQty, p.Qty, tt.name)
+		assert.Equal(t, 10000.0, bitmex.ToFloat(p.EntryPrice), tt.name)
+		assert.Equal(t, tt.pos.CrossMargin, p.Cross, tt.name)
+		assert.Equal(t, tt.pos.MaintMarginReq, p.MaintMarginReq, tt.name)
+		tick := bitmex.ToFloat(xbtusd.TickSize)
+		assert.InDelta(t, bitmex.ToFloat(tt.pos.LiquidationPrice), bitmex.ToFloat(p.LiquidationPrice), tick, "%s: liquidation", tt.name)
+		assert.InDelta(t, bitmex.ToFloat(tt.pos.BankruptPrice), bitmex.ToFloat(p.BankruptPrice), tick, "%s: bankruptcy", tt.name)
+	}
+}
+
+func TestSimulateScenario(t *testing.T) {
+	long := bitmex.Position{
+		Symbol: "XBTUSD", Currency: "XBt", CurrentQty: 1000, AvgEntryPrice: bitmex.FromFloat(10000), Leverage: 10,
+		InitMarginReq: 0.1, MaintMarginReq: 0.005, PosMargin: xbt(1000000), MarkPrice: bitmex.FromFloat(10000),
+	}
+	leverage := func(l float64) contracts.Scenario {
+		var s contracts.Scenario
+		s.Leverage.Set(l)
+		return s
+	}
+	order := func(qty int, price float64, l float64) contracts.Scenario {
+		s := leverage(l)
+		s.Qty, s.Price = qty, bitmex.FromFloat(price)
+		return s
+	}
+	for _, tt := range []struct {
+		name       string
+		pos        bitmex.Position
+		scenario   contracts.Scenario
+		qty        int
+		initMargin int64
+		fee        int64
+		required   int64
+		available  int64
+		err        error
+	}{
+		{"isolated order", bitmex.Position{}, order(1000, 10000, 10),
+			1000, 1000000, 7500, 1007500, 8992500, nil},
+		{"cross order", bitmex.Position{}, order(1000, 10000, 0),
+			1000, 100000, 7500, 107500, 9892500, nil},
+		{"order at the mark price", bitmex.Position{}, order(-1000, 0, 10),
+			-1000, 1000000, 7500, 1007500, 8992500, nil},
+		{"close at a profit", long, order(-1000, 11000, 10),
+			0, 0, 6818, -1902273, 11902273, nil},
+		{"more leverage frees margin", long, leverage(25),
+			1000, 400000, 0, -600000, 10600000, nil},
+		{"insufficient balance", bitmex.Position{}, order(100000, 10000, 10),
+			100000, 100000000, 750000, 100750000, -90750000, bitmex.ErrInsufficientBalance},
+		{"risk limit", bitmex.Position{RiskLimit: xbt(20000000000)}, order(3000000, 10000, 0),
+			3000000, 300000000, 22500000, 322500000, -312500000, contracts.ErrRiskLimit},
+	} {
+		p, err := contracts.Simulate(xbtusd, account(10000000), tt.pos, tt.scenario)
+		if tt.err != nil {
+			assert.True(t, errors.Is(err, tt.err), "%s: %v", tt.name, err)
+		} else {
+			assert.NoError(t, err, tt.name)
+		}
+		assert.Equal(t, tt.qty, p.Qty, tt.name)
+		assert.Equal(t, xbt(tt.initMargin), p.InitMargin, tt.name)
+		assert.Equal(t, xbt(tt.fee), p.Fee, tt.name)
+		assert.Equal(t, xbt(tt.required), p.Required, tt.name)
+		assert.Equal(t, xbt(tt.available), p.AvailableMargin, tt.name)
+	}
+
+	_, err := contracts.Simulate(xbtusd, account(10000000), long, leverage(200))
+	assert.Error(t, err)
+}
@@ -0,0 +1,34 @@
+//go:build !bitmex_float
+
+package bitmex
+
+import "github.com/go-numb/go-bitmex/decimal"
+
+// Decimal is the type of the prices and quantities of the models and the
+// *Opts structs, an exact decimal.Decimal. Builds with the bitmex_float tag
+// make it float64 again, for code written against the float API:
+//
+//	go build -tags bitmex_float
+//
+// ToFloat, FromFloat, ToDecimal and FromDecimal convert in both builds.
+type Decimal = decimal.Decimal
+
+// ToFloat returns v as a float64.
+func ToFloat(v Decimal) float64 {
+	return v.Float64()
+}
+
+// FromFloat returns the Decimal nearest to f, e.g. 0.1 for 0.1.
+func FromFloat(f float64) Decimal {
+	return decimal.NewFromFloat(f)
+}
+
+// ToDecimal returns v as a decimal.Decimal.
+func ToDecimal(v Decimal) decimal.Decimal {
+	return v
+}
+
+// FromDecimal returns d as a Decimal.
+func FromDecimal(d decimal.Decimal) Decimal {
+	return d
+}
@@ -0,0 +1,474 @@
+// Package decimal provides Decimal, an exact fixed-point number for the prices
+// and quantities of BitMEX. Prices such as 7024.5 or a tick size of 0.01 have
+// no exact float64 representation; a Decimal keeps the digits sent by the
+// server and computes with them exactly.
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// MaxScale is the largest number of decimal places a Decimal keeps.
+const MaxScale = 18
+
+// Decimal is the number coef × 10^-scale. The zero value is 0. Decimals are
+// kept without trailing zeros, so that equal values compare equal with ==.
+//
+// A Decimal holds 19 significant digits. Arithmetic drops the decimal places
+// that don't fit, and saturates at Largest or Smallest when the integer part
+// of the result doesn't fit either. Parse reports numbers that don't fit
+// exactly as an error; ParseRounded, NewFromFloat and the JSON and text
+// decoding round their decimal places instead.
+type Decimal struct {
+	coef  int64
+	scale int32
+}
+
+var (
+	// Zero is 0.
+	Zero = Decimal{}
+	// Largest and Smallest are the results of arithmetic overflowing upward
+	// and downward, ±9223372036854775807.
+	Largest  = Decimal{coef: math.MaxInt64}
+	Smallest = Decimal{coef: -math.MaxInt64}
+)
+
+var errOverflow = errors.New("overflow")
+
+// New returns coef × 10^-scale, e.g. New(70245, 1) for 7024.5.
+func New(coef int64, scale int) Decimal {
+	return fromBig(big.NewInt(coef), scale)
+}
+
+// NewFromInt returns v.
+func NewFromInt(v int64) Decimal {
+	return Decimal{coef: v}
+}
+
+// NewFromFloat returns the shortest decimal that rounds to f, e.g. 0.1 for
+// 0.1, which is the value a float64 read from JSON was written as, rounded to
+// MaxScale decimal places. It saturates at Largest or Smallest, and returns
+// Zero for NaN and infinities.
+func NewFromFloat(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Zero
+	}
+	d, err := ParseRounded(strconv.FormatFloat(f, 'g', -1, 64))
+	switch {
+	case err == nil:
+		return d
+	case f < 0:
+		return Smallest
+	}
+	return Largest
+}
+
+// Parse parses a decimal such as "7024.5", "-0.01" or "1e-08". A number with
+// more than MaxScale decimal places, or 19 significant digits, is an error.
+func Parse(s string) (Decimal, error) {
+	coef, scale, err := parse(s)
+	if err != nil {
+		return Zero, err
+	}
+	d, err := fromBigExact(coef, scale)
+	if err != nil {
+		return Zero, fmt.Errorf("decimal: %q: %v", s, err)
+	}
+	return d, nil
+}
+
+// ParseRounded is Parse rounding the decimal places that don't fit half away
+// from zero, as JSON numbers such as 0.0014235888675350558 written from a
+// float need. An integer part that doesn't fit is still an error.
+func ParseRounded(s string) (Decimal, error) {
+	coef, scale, err := parse(s)
+	if err != nil {
+		return Zero, err
+	}
+	if !new(big.Int).Quo(coef, pow10(scale)).IsInt64() {
+		return Zero, fmt.Errorf("decimal: %q: %v", s, errOverflow)
+	}
+	return fromBig(coef, scale), nil
+}
+
+// parse parses s into coef × 10^-scale, with scale at least 0.
+func parse(s string) (*big.Int, int, error) {
+	mantissa, exp := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return nil, 0, fmt.Errorf("decimal: invalid %q", s)
+		}
+		mantissa, exp = s[:i], e
+	}
+	scale := 0
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		scale = len(mantissa) - i - 1
+		mantissa = mantissa[:i] + mantissa[i+1:]
+	}
+	if mantissa == "" || mantissa == "-" || mantissa == "+" || strings.ContainsAny(mantissa[1:], "+-") {
+		return nil, 0, fmt.Errorf("decimal: invalid %q", s)
+	}
+	coef, ok := new(big.Int).SetString(mantissa, 10)
+	if !ok {
+		return nil, 0, fmt.Errorf("decimal: invalid %q", s)
+	}
+	scale -= exp
+	if scale < -19 || scale > MaxScale+400 {
+		return nil, 0, fmt.Errorf("decimal: %q is out of range", s)
+	}
+	if scale < 0 {
+		coef.Mul(coef, pow10(-scale))
+		scale = 0
+	}
+	return coef, scale, nil
+}
+
+// MustParse is Parse panicking on an invalid s, for constants.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// String returns d without exponent, e.g. "7024.5" or "0.00000001".
+func (d Decimal) String() string {
+	s := strconv.FormatInt(d.coef, 10)
+	if d.scale == 0 {
+		return s
+	}
+	neg := d.coef < 0
+	if neg {
+		s = s[1:]
+	}
+	if n := int(d.scale) + 1 - len(s); n > 0 {
+		s = strings.Repeat("0", n) + s
+	}
+	s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// Float64 returns the float64 nearest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// IntPart returns d truncated toward zero.
+func (d Decimal) IntPart() int64 {
+	return d.coef / pow10(int(d.scale)).Int64()
+}
+
+// Scale returns the number of decimal places of d.
+func (d Decimal) Scale() int {
+	return int(d.scale)
+}
+
+// IsZero reports whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.coef == 0
+}
+
+// Sign returns -1, 0 or +1 as d is negative, zero or positive.
+func (d Decimal) Sign() int {
+	switch {
+	case d.coef < 0:
+		return -1
+	case d.coef > 0:
+		return 1
+	}
+	return 0
+}
+
+// Cmp returns -1, 0 or +1 as d is less than, equal to or greater than e.
+func (d Decimal) Cmp(e Decimal) int {
+	a, b := align(d, e)
+	return a.Cmp(b)
+}
+
+// Equal reports whether d and e are the same number.
+func (d Decimal) Equal(e Decimal) bool {
+	return d == e
+}
+
+// LessThan reports whether d < e.
+func (d Decimal) LessThan(e Decimal) bool {
+	return d.Cmp(e) < 0
+}
+
+// GreaterThan reports whether d > e.
+func (d Decimal) GreaterThan(e Decimal) bool {
+	return d.Cmp(e) > 0
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return fromBig(new(big.Int).Neg(big.NewInt(d.coef)), int(d.scale))
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	if d.coef < 0 {
+		return d.Neg()
+	}
+	return d
+}
+
+// Add returns d + e.
+func (d Decimal) Add(e Decimal) Decimal {
+	a, b := align(d, e)
+	return fromBig(a.Add(a, b), max(int(d.scale), int(e.scale)))
+}
+
+// Sub returns d - e.
+func (d Decimal) Sub(e Decimal) Decimal {
+	a, b := align(d, e)
+	return fromBig(a.Sub(a, b), max(int(d.scale), int(e.scale)))
+}
+
+// Mul returns d × e, rounded half away from zero to MaxScale places.
+func (d Decimal) Mul(e Decimal) Decimal {
+	p := new(big.Int).Mul(big.NewInt(d.coef), big.NewInt(e.coef))
+	return fromBig(p, int(d.scale+e.scale))
+}
+
+// Div returns d / e rounded half away from zero to places decimal places. It
+// panics when e is 0.
+func (d Decimal) Div(e Decimal, places int) Decimal {
+	if e.coef == 0 {
+		panic("decimal: division by zero")
+	}
+	places = min(max(places, 0), MaxScale)
+	// d/e = (dc × 10^(es+places+1) / ec) × 10^-(ds+places+1)
+	n := new(big.Int).Mul(big.NewInt(d.coef), pow10(int(e.scale)+places+1))
+	q := n.Quo(n, big.NewInt(e.coef))
+	return roundBig(q, int(d.scale)+places+1, places)
+}
+
+// Round returns d rounded half away from zero to places decimal places.
+func (d Decimal) Round(places int) Decimal {
+	if places < 0 || int(d.scale) <= places {
+		return d
+	}
+	return roundBig(big.NewInt(d.coef), int(d.scale), places)
+}
+
+// Truncate returns d with the decimal places beyond places dropped.
+func (d Decimal) Truncate(places int) Decimal {
+	if places < 0 || int(d.scale) <= places {
+		return d
+	}
+	q := new(big.Int).Quo(big.NewInt(d.coef), pow10(int(d.scale)-places))
+	return fromBig(q, places)
+}
+
+// RoundStep returns the multiple of step nearest to d, halves away from zero,
+// e.g. a price rounded to the tick size. A zero step returns d.
+func (d Decimal) RoundStep(step Decimal) Decimal {
+	return d.step(step, func(q, r, s *big.Int) {
+		if new(big.Int).Abs(new(big.Int).Mul(r, big.NewInt(2))).Cmp(new(big.Int).Abs(s)) >= 0 {
+			q.Add(q, big.NewInt(int64(r.Sign()*s.Sign())))
+		}
+	})
+}
+
+// FloorStep returns the largest multiple of step not above d.
+func (d Decimal) FloorStep(step Decimal) Decimal {
+	return d.step(step, func(q, r, s *big.Int) {
+		if r.Sign() != 0 && r.Sign() != s.Sign() {
+			q.Sub(q, big.NewInt(1))
+		}
+	})
+}
+
+// CeilStep returns the smallest multiple of step not below d.
+func (d Decimal) CeilStep(step Decimal) Decimal {
+	return d.step(step, func(q, r, s *big.Int) {
+		if r.Sign() != 0 && r.Sign() == s.Sign() {
+			q.Add(q, big.NewInt(1))
+		}
+	})
+}
+
+// IsMultipleOf reports whether d is a whole multiple of step, e.g. whether a
+// price is on the tick size. Every number is a multiple of a zero step.
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	if step.coef == 0 {
+		return true
+	}
+	a, b := align(d, step)
+	return new(big.Int).Rem(a, b).Sign() == 0
+}
+
+// step rounds d to a multiple of step, adjust correcting the truncated
+// quotient q of d / step given the remainder r and the step s.
+func (d Decimal) step(step Decimal, adjust func(q, r, s *big.Int)) Decimal {
+	if step.coef == 0 {
+		return d
+	}
+	a, s := align(d, step)
+	q, r := new(big.Int).QuoRem(a, s, new(big.Int))
+	adjust(q, r, s)
+	q.Mul(q, s)
+	return fromBig(q, max(int(d.scale), int(step.scale)))
+}
+
+// Min returns the smallest of d and others.
+func Min(d Decimal, others ...Decimal) Decimal {
+	for _, o := range others {
+		if o.Cmp(d) < 0 {
+			d = o
+		}
+	}
+	return d
+}
+
+// Max returns the largest of d and others.
+func Max(d Decimal, others ...Decimal) Decimal {
+	for _, o := range others {
+		if o.Cmp(d) > 0 {
+			d = o
+		}
+	}
+	return d
+}
+
+// Sum returns the sum of ds.
+func Sum(ds ...Decimal) Decimal {
+	s := Zero
+	for _, d := range ds {
+		s = s.Add(d)
+	}
+	return s
+}
+
+// MarshalJSON writes d as a JSON number with its exact digits.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number, a number in a string, or null as 0,
+// rounded as ParseRounded rounds it.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		*d = Zero
+		return nil
+	}
+	s = strings.Trim(s, `"`)
+	v, err := ParseRounded(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, rounding as
+// ParseRounded does.
+func (d *Decimal) UnmarshalText(b []byte) error {
+	v, err := ParseRounded(string(b))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// align returns the coefficients of d and e at the scale of the more precise one.
+func align(d, e Decimal) (*big.Int, *big.Int) {
+	a, b := big.NewInt(d.coef), big.NewInt(e.coef)
+	switch {
+	case d.scale < e.scale:
+		a.Mul(a, pow10(int(e.scale-d.scale)))
+	case e.scale < d.scale:
+		b.Mul(b, pow10(int(d.scale-e.scale)))
+	}
+	return a, b
+}
+
+// roundBig rounds coef × 10^-scale half away from zero to places decimal places.
+func roundBig(coef *big.Int, scale, places int) Decimal {
+	if scale <= places {
+		return fromBig(coef, scale)
+	}
+	return fromBig(roundDigits(coef, scale-places), places)
+}
+
+// roundDigits returns coef / 10^n rounded half away from zero.
+func roundDigits(coef *big.Int, n int) *big.Int {
+	unit := pow10(n)
+	q, r := new(big.Int).QuoRem(coef, unit, new(big.Int))
+	if new(big.Int).Abs(new(big.Int).Mul(r, big.NewInt(2))).Cmp(unit) >= 0 {
+		q.Add(q, big.NewInt(int64(coef.Sign())))
+	}
+	return q
+}
+
+// fromBig returns coef × 10^-scale, rounded to MaxScale places and to as many
+// places as fit in a Decimal. It returns Largest or Smallest when the integer
+// part does not fit in an int64.
+func fromBig(coef *big.Int, scale int) Decimal {
+	if scale > MaxScale {
+		coef, scale = roundDigits(coef, scale-MaxScale), MaxScale
+	}
+	for {
+		d, err := fromBigExact(coef, scale)
+		if err == nil {
+			return d
+		}
+		if scale == 0 {
+			if coef.Sign() < 0 {
+				return Smallest
+			}
+			return Largest
+		}
+		// drop the decimal places in excess of the 19 digits of an int64
+		n := min(max(len(new(big.Int).Abs(coef).String())-18, 1), scale)
+		coef, scale = roundDigits(coef, n), scale-n
+	}
+}
+
+// fromBigExact returns coef × 10^-scale without trailing zeros, or an error
+// when it does not fit.
+func fromBigExact(coef *big.Int, scale int) (Decimal, error) {
+	c := new(big.Int).Set(coef)
+	r := new(big.Int)
+	ten := big.NewInt(10)
+	for scale > 0 && c.Sign() != 0 {
+		q, m := new(big.Int).QuoRem(c, ten, r)
+		if m.Sign() != 0 {
+			break
+		}
+		c, scale = q, scale-1
+	}
+	if c.Sign() == 0 {
+		return Zero, nil
+	}
+	if scale > MaxScale {
+		return Zero, errors.New("too many decimal places")
+	}
+	if !c.IsInt64() {
+		return Zero, errOverflow
+	}
+	return Decimal{coef: c.Int64(), scale: int32(scale)}, nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,278 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/go-numb/go-bitmex/decimal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var d = decimal.MustParse
+
+func TestParse(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+		err  bool
+	}{
+		{in: "7024.5", want: "7024.5"},
+		{in: "-0.01", want: "-0.01"},
+		{in: "+5", want: "5"},
+		{in: "0.10", want: "0.1"},
+		{in: "100", want: "100"},
+		{in: "-0", want: "0"},
+		{in: "1e-08", want: "0.00000001"},
+		{in: "1.5E3", want: "1500"},
+		{in: ".5", want: "0.5"},
+		{in: "9223372036854775807", want: "9223372036854775807"},
+		{in: "-9223372036854775808", want: "-9223372036854775808"},
+		{in: "0.000000000000000001", want: "0.000000000000000001"},
+		{in: "1.0000000000000000000", want: "1"},
+		{in: "", err: true},
+		{in: "-", err: true},
+		{in: ".", err: true},
+		{in: "abc", err: true},
+		{in: "1.2.3", err: true},
+		{in: "1-2", err: true},
+		{in: "1e", err: true},
+		{in: "1e400", err: true},
+		{in: "9223372036854775808", err: true},
+		{in: "0.0000000000000000001", err: true},
+	} {
+		got, err := decimal.Parse(tt.in)
+		if tt.err {
+			assert.Error(t, err, tt.in)
+			continue
+		}
+		if assert.NoError(t, err, tt.in) {
+			assert.Equal(t, tt.want, got.String(), tt.in)
+		}
+	}
+}
+
+func TestParseRounded(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+		err  bool
+	}{
+		{in: "7024.5", want: "7024.5"},
+		{in: "0.0014235888675350558", want: "0.001423588867535056"},
+		{in: "-1.2345678901234567e-05", want: "-0.000012345678901235"},
+		{in: "3.3333333333333335e-07", want: "0.000000333333333333"},
+		{in: "0.0000000000000000005", want: "0.000000000000000001"},
+		{in: "0.0000000000000000004", want: "0"},
+		{in: "12345.678901234567890123", want: "12345.6789012345679"},
+		{in: "9223372036854775807.4", want: "9223372036854775807"},
+		{in: "9223372036854775808", err: true},
+		{in: "1e400", err: true},
+		{in: "abc", err: true},
+	} {
+		got, err := decimal.ParseRounded(tt.in)
+		if tt.err {
+			assert.Error(t, err, tt.in)
+			continue
+		}
+		if assert.NoError(t, err, tt.in) {
+			assert.Equal(t, tt.want, got.String(), tt.in)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	assert.Equal(t, d("7024.5"), decimal.New(70245, 1))
+	assert.Equal(t, d("0.00000001"), decimal.New(1, 8))
+	assert.Equal(t, d("-0.005"), decimal.New(-5000, 6))
+	assert.Equal(t, d("42"), decimal.NewFromInt(42))
+	assert.Equal(t, d("0.1"), decimal.NewFromFloat(0.1))
+	assert.Equal(t, d("7024.5"), decimal.NewFromFloat(7024.5))
+	assert.Equal(t, decimal.Zero, decimal.NewFromFloat(math.NaN()))
+	assert.Equal(t, decimal.Zero, decimal.NewFromFloat(math.Inf(1)))
+	assert.Equal(t, d("0.000000333333333333"), decimal.NewFromFloat(3.3333333333333335e-07))
+	assert.Equal(t, d("-0.000012345678901235"), decimal.NewFromFloat(-1.2345678901234567e-05))
+	assert.Equal(t, decimal.Largest, decimal.NewFromFloat(1e300))
+	assert.Equal(t, decimal.Smallest, decimal.NewFromFloat(-1e300))
+	assert.Equal(t, 0.1, d("0.1").Float64())
+	assert.Equal(t, int64(-7024), d("-7024.9").IntPart())
+	assert.Equal(t, 2, d("1.25").Scale())
+	assert.Panics(t, func() { decimal.MustParse("x") })
+}
+
+func TestArithmetic(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		got  decimal.Decimal
+		want string
+	}{
+		{"add", d("0.1").Add(d("0.2")), "0.3"},
+		{"add scales", d("7024.5").Add(d("0.25")), "7024.75"},
+		{"sub", d("0.3").Sub(d("0.1")), "0.2"},
+		{"sub to zero", d("1.10").Sub(d("1.1")), "0"},
+		{"mul", d("1.5").Mul(d("-0.2")), "-0.3"},
+		{"mul rounded to MaxScale", d("0.000000001").Mul(d("0.0000000015")), "0.000000000000000002"},
+		{"neg", d("1.5").Neg(), "-1.5"},
+		{"abs", d("-1.5").Abs(), "1.5"},
+		{"div", d("1").Div(d("3"), 4), "0.3333"},
+		{"div half up", d("1").Div(d("8"), 2), "0.13"},
+		{"div half away from zero", d("-1").Div(d("8"), 2), "-0.13"},
+		{"div exact", d("10").Div(d("4"), 8), "2.5"},
+		{"div by a fraction", d("1").Div(d("0.0001"), 0), "10000"},
+		{"div places capped", d("1").Div(d("3"), 30), "0.333333333333333333"},
+		{"div negative places", d("7").Div(d("2"), -1), "4"},
+		{"places dropped", d("1").Div(d("3"), 18).Add(d("100")), "100.333333333333333"},
+		{"min", decimal.Min(d("2"), d("-1"), d("0.5")), "-1"},
+		{"max", decimal.Max(d("2"), d("-1"), d("2.5")), "2.5"},
+		{"sum", decimal.Sum(d("0.1"), d("0.2"), d("-0.05")), "0.25"},
+		{"sum of none", decimal.Sum(), "0"},
+	} {
+		assert.Equal(t, tt.want, tt.got.String(), tt.name)
+	}
+	assert.Panics(t, func() { d("1").Div(decimal.Zero, 2) })
+}
+
+func TestCompare(t *testing.T) {
+	assert.True(t, d("0.10") == d("0.1"))
+	assert.True(t, d("0.1").Equal(d("0.100")))
+	assert.Equal(t, -1, d("-0.1").Cmp(d("0.01")))
+	assert.Equal(t, 0, d("2").Cmp(d("2.0")))
+	assert.Equal(t, 1, d("2.01").Cmp(d("2")))
+	assert.True(t, d("1").LessThan(d("1.5")))
+	assert.True(t, d("1.5").GreaterThan(d("1")))
+	assert.Equal(t, -1, d("-3").Sign())
+	assert.Equal(t, 0, decimal.Zero.Sign())
+	assert.True(t, d("0.000").IsZero())
+}
+
+func TestRound(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		got  decimal.Decimal
+		want string
+	}{
+		{"round half up", d("1.005").Round(2), "1.01"},
+		{"round half away from zero", d("-1.005").Round(2), "-1.01"},
+		{"round down", d("1.004").Round(2), "1"},
+		{"round to integer", d("2.5").Round(0), "3"},
+		{"round fewer places", d("1.5").Round(3), "1.5"},
+		{"round negative places", d("1.55").Round(-1), "1.55"},
+		{"truncate", d("1.99").Truncate(1), "1.9"},
+		{"truncate toward zero", d("-1.99").Truncate(1), "-1.9"},
+		{"truncate to integer", d("-0.5").Truncate(0), "0"},
+	} {
+		assert.Equal(t, tt.want, tt.got.String(), tt.name)
+	}
+}
+
+func TestStep(t *testing.T) {
+	for _, tt := range []struct {
+		value, step        string
+		round, floor, ceil string
+		multiple           bool
+	}{
+		{"7024.3", "0.5", "7024.5", "7024", "7024.5", false},
+		{"7024.25", "0.5", "7024.5", "7024", "7024.5", false},
+		{"7024.2", "0.5", "7024", "7024", "7024.5", false},
+		{"7024.5", "0.5", "7024.5", "7024.5", "7024.5", true},
+		{"-7024.25", "0.5", "-7024.5", "-7024.5", "-7024", false},
+		{"-7024.2", "0.5", "-7024", "-7024.5", "-7024", false},
+		{"0.123456", "0.01", "0.12", "0.12", "0.13", false},
+		{"0.125", "0.01", "0.13", "0.12", "0.13", false},
+		{"3.05", "0.05", "3.05", "3.05", "3.05", true},
+		{"150", "100", "200", "100", "200", false},
+		{"149", "100", "100", "100", "200", false},
+		{"1.23", "0", "1.23", "1.23", "1.23", true},
+	} {
+		v, step := d(tt.value), d(tt.step)
+		name := tt.value + " by " + tt.step
+		assert.Equal(t, tt.round, v.RoundStep(step).String(), "RoundStep "+name)
+		assert.Equal(t, tt.floor, v.FloorStep(step).String(), "FloorStep "+name)
+		assert.Equal(t, tt.ceil, v.CeilStep(step).String(), "CeilStep "+name)
+		assert.Equal(t, tt.multiple, v.IsMultipleOf(step), "IsMultipleOf "+name)
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	max := decimal.NewFromInt(math.MaxInt64)
+	for _, tt := range []struct {
+		name string
+		got  func() decimal.Decimal
+		want decimal.Decimal
+	}{
+		{"add", func() decimal.Decimal { return max.Add(d("1")) }, decimal.Largest},
+		{"sub", func() decimal.Decimal { return max.Neg().Sub(d("2")) }, decimal.Smallest},
+		{"mul", func() decimal.Decimal { return max.Mul(d("2")) }, decimal.Largest},
+		{"mul negative", func() decimal.Decimal { return max.Mul(d("-1.5")) }, decimal.Smallest},
+		{"div", func() decimal.Decimal { return max.Div(d("0.1"), 0) }, decimal.Largest},
+		{"neg of the smallest int64", func() decimal.Decimal { return d("-9223372036854775808").Neg() }, decimal.Largest},
+		{"saturated stays", func() decimal.Decimal { return decimal.Largest.Add(decimal.Largest) }, decimal.Largest},
+		{"fits once places are dropped", func() decimal.Decimal { return d("9.223372036854775807").Mul(d("11")) }, d("101.457092405402534")},
+	} {
+		var got decimal.Decimal
+		if assert.NotPanics(t, func() { got = tt.got() }, tt.name) {
+			assert.Equal(t, tt.want, got, tt.name)
+		}
+	}
+	assert.Equal(t, "9223372036854775807", decimal.Largest.String())
+	assert.Equal(t, "-9223372036854775807", decimal.Smallest.String())
+}
+
+func TestJSON(t *testing.T) {
+	type row struct {
+		Price decimal.Decimal `json:"price"`
+	}
+	for _, tt := range []struct {
+		in    string
+		price string
+		out   string
+		err   bool
+	}{
+		{in: `{"price":7024.5}`, price: "7024.5", out: `{"price":7024.5}`},
+		{in: `{"price":"7024.5"}`, price: "7024.5", out: `{"price":7024.5}`},
+		{in: `{"price":0.00000001}`, price: "0.00000001", out: `{"price":0.00000001}`},
+		{in: `{"price":1e-8}`, price: "0.00000001", out: `{"price":0.00000001}`},
+		{in: `{"price":-12.50}`, price: "-12.5", out: `{"price":-12.5}`},
+		{in: `{"price":null}`, price: "0", out: `{"price":0}`},
+		{in: `{"price":0.0014235888675350558}`, price: "0.001423588867535056", out: `{"price":0.001423588867535056}`},
+		{in: `{"price":-1.2345678901234567e-05}`, price: "-0.000012345678901235", out: `{"price":-0.000012345678901235}`},
+		{in: `{"price":1e400}`, err: true},
+		{in: `{}`, price: "0", out: `{"price":0}`},
+		{in: `{"price":"abc"}`, err: true},
+		{in: `{"price":true}`, err: true},
+	} {
+		var r row
+		err := json.Unmarshal([]byte(tt.in), &r)
+		if tt.err {
+			assert.Error(t, err, tt.in)
+			continue
+		}
+		if !assert.NoError(t, err, tt.in) {
+			continue
+		}
+		assert.Equal(t, tt.price, r.Price.String(), tt.in)
+		b, err := json.Marshal(r)
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.out, string(b), tt.in)
+
+		var back row
+		assert.NoError(t, json.Unmarshal(b, &back), tt.in)
+		assert.Equal(t, r, back, tt.in)
+	}
+}
+
+func TestText(t *testing.T) {
+	var v decimal.Decimal
+	assert.NoError(t, v.UnmarshalText([]byte("0.25")))
+	assert.Equal(t, d("0.25"), v)
+	b, err := v.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "0.25", string(b))
+	assert.Error(t, v.UnmarshalText([]byte("0.2.5")))
+
+	// as a map key
+	b, err = json.Marshal(map[decimal.Decimal]int{d("7024.5"): 100})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"7024.5":100}`, string(b))
+}
@@ -0,0 +1,29 @@
+//go:build bitmex_float
+
+package bitmex
+
+import "github.com/go-numb/go-bitmex/decimal"
+
+// Decimal is float64 in builds with the bitmex_float tag, as prices and
+// quantities were before decimal.Decimal.
+type Decimal = float64
+
+// ToFloat returns v.
+func ToFloat(v Decimal) float64 {
+	return v
+}
+
+// FromFloat returns f.
+func FromFloat(f float64) Decimal {
+	return f
+}
+
+// ToDecimal returns the decimal.Decimal nearest to v.
+func ToDecimal(v Decimal) decimal.Decimal {
+	return decimal.NewFromFloat(v)
+}
+
+// FromDecimal returns the float64 nearest to d.
+func FromDecimal(d decimal.Decimal) Decimal {
+	return d.Float64()
+}
@@ -0,0 +1,113 @@
+package bitmex_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-numb/go-bitmex"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// These tests run in both builds, with and without the bitmex_float tag.
+
+func TestDecimalConversions(t *testing.T) {
+	for _, tt := range []struct {
+		f float64
+		s string
+	}{
+		{7024.5, "7024.5"},
+		{0.1, "0.1"},
+		{0.00000001, "0.00000001"},
+		{-0.0001, "-0.0001"},
+		{0, "0"},
+	} {
+		v := bitmex.FromFloat(tt.f)
+		assert.Equal(t, tt.f, bitmex.ToFloat(v), tt.s)
+		assert.Equal(t, tt.s, bitmex.ToDecimal(v).String(), tt.s)
+		assert.Equal(t, v, bitmex.FromDecimal(bitmex.ToDecimal(v)), tt.s)
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var o bitmex.Order
+	err := json.Unmarshal([]byte(`{"orderID":"o1","price":7024.5,"avgPx":null,"stopPx":1e-8,"orderQty":100}`), &o)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 7024.5, bitmex.ToFloat(o.Price))
+	assert.Equal(t, 0.0, bitmex.ToFloat(o.AvgPx))
+	assert.Equal(t, "0.00000001", bitmex.ToDecimal(o.StopPx).String())
+
+	// Prices are written even when zero, in both builds.
+	b, err := json.Marshal(bitmex.Order{OrderID: "o1"})
+	assert.NoError(t, err)
+	var fields map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(b, &fields))
+	assert.Equal(t, "0", string(fields["price"]))
+	assert.NotContains(t, fields, "orderQty")
+
+	b, err = json.Marshal(o)
+	assert.NoError(t, err)
+	var back bitmex.Order
+	assert.NoError(t, json.Unmarshal(b, &back))
+	assert.Equal(t, o.Price, back.Price)
+	assert.Equal(t, o.StopPx, back.StopPx)
+}
+
+func TestDecimalLongFloats(t *testing.T) {
+	// Numbers as BitMEX writes them from a float, with more decimal places
+	// than a Decimal keeps.
+	for _, tt := range []struct {
+		json string
+		want float64
+	}{
+		{`{"homeNotional":-1.2345678901234567e-05}`, -1.2345678901234567e-05},
+		{`{"homeNotional":0.0014235888675350558}`, 0.0014235888675350558},
+		{`{"homeNotional":3.3333333333333335e-07}`, 3.3333333333333335e-07},
+	} {
+		var p bitmex.Position
+		if assert.NoError(t, json.Unmarshal([]byte(tt.json), &p), tt.json) {
+			assert.InDelta(t, tt.want, bitmex.ToFloat(p.HomeNotional), 1e-18, tt.json)
+			assert.NotZero(t, bitmex.ToDecimal(p.HomeNotional).Sign(), tt.json)
+		}
+	}
+}
+
+func TestOptionalDecimal(t *testing.T) {
+	var form url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = r.PostForm
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"orderID":"o1"}`))
+	}))
+	defer srv.Close()
+	cfg := bitmex.NewConfiguration()
+	cfg.BasePath = srv.URL
+	orders := bitmex.NewAPIClient(cfg).OrderApi
+	ctx := bitmex.NewAPIKeyContext("key", "secret")
+
+	for _, tt := range []struct {
+		name  string
+		set   func(o *bitmex.OrderNewOpts)
+		price string // "" when not sent
+	}{
+		{"unset", func(o *bitmex.OrderNewOpts) {}, ""},
+		{"price", func(o *bitmex.OrderNewOpts) { o.Price.Set(bitmex.FromFloat(7024.5)) }, "7024.5"},
+		{"small price", func(o *bitmex.OrderNewOpts) { o.Price.Set(bitmex.FromFloat(0.00000123)) }, "0.00000123"},
+		{"zero", func(o *bitmex.OrderNewOpts) { o.Price.Set(bitmex.FromFloat(0)) }, "0"},
+	} {
+		var opts bitmex.OrderNewOpts
+		tt.set(&opts)
+		assert.Equal(t, tt.price != "", opts.Price.IsSet(), tt.name)
+
+		_, _, err := orders.OrderNew(ctx, "XBTUSD", &opts)
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.price, form.Get("price"), tt.name)
+		assert.Equal(t, tt.price != "", form.Has("price"), tt.name)
+	}
+}
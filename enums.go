@@ -0,0 +1,342 @@
+package bitmex
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The enums below type the string fields of the models and *Opts structs
+// holding one of the values BitMEX documents. They are strings: they encode as
+// their value, and any value decodes, so that one BitMEX adds later does not
+// break a client; IsValid reports whether a value is a documented one.
+
+// Side is the side of an order or a trade.
+type Side string
+
+const (
+	SideBuy  Side = BUY
+	SideSell Side = SELL
+)
+
+// IsValid reports whether s is Buy or Sell.
+func (s Side) IsValid() bool {
+	return s == SideBuy || s == SideSell
+}
+
+// Opposite returns the other side, or s when it is not valid.
+func (s Side) Opposite() Side {
+	switch s {
+	case SideBuy:
+		return SideSell
+	case SideSell:
+		return SideBuy
+	}
+	return s
+}
+
+// OrdType is the type of an order.
+type OrdType string
+
+const (
+	OrdTypeMarket                    OrdType = MARKET
+	OrdTypeLimit                     OrdType = LIMIT
+	OrdTypeStop                      OrdType = STOP
+	OrdTypeStopLimit                 OrdType = STOPLIMIT
+	OrdTypeMarketIfTouched           OrdType = MARKETIFTOUCHED
+	OrdTypeLimitIfTouched            OrdType = LIMITIFTOUCHED
+	OrdTypeMarketWithLeftOverAsLimit OrdType = "MarketWithLeftOverAsLimit"
+	OrdTypePegged                    OrdType = "Pegged"
+)
+
+// IsValid reports whether t is a documented ordType.
+func (t OrdType) IsValid() bool {
+	switch t {
+	case OrdTypeMarket, OrdTypeLimit, OrdTypeStop, OrdTypeStopLimit, OrdTypeMarketIfTouched,
+		OrdTypeLimitIfTouched, OrdTypeMarketWithLeftOverAsLimit, OrdTypePegged:
+		return true
+	}
+	return false
+}
+
+// IsTriggered reports whether orders of type t wait for a trigger price, stopPx.
+func (t OrdType) IsTriggered() bool {
+	switch t {
+	case OrdTypeStop, OrdTypeStopLimit, OrdTypeMarketIfTouched, OrdTypeLimitIfTouched:
+		return true
+	}
+	return false
+}
+
+// TimeInForce is how long an order stays in the book.
+type TimeInForce string
+
+const (
+	TimeInForceDay               TimeInForce = "Day"
+	TimeInForceGoodTillCancel    TimeInForce = "GoodTillCancel"
+	TimeInForceImmediateOrCancel TimeInForce = IOC
+	TimeInForceFillOrKill        TimeInForce = "FillOrKill"
+)
+
+// IsValid reports whether t is a documented timeInForce.
+func (t TimeInForce) IsValid() bool {
+	switch t {
+	case TimeInForceDay, TimeInForceGoodTillCancel, TimeInForceImmediateOrCancel, TimeInForceFillOrKill:
+		return true
+	}
+	return false
+}
+
+// ExecInst is a set of execution instructions, their values separated by
+// commas, e.g. "ParticipateDoNotInitiate,ReduceOnly". A single instruction
+// is a set of one.
+type ExecInst string
+
+const (
+	ExecInstParticipateDoNotInitiate ExecInst = POSTONLY
+	ExecInstAllOrNone                ExecInst = "AllOrNone"
+	ExecInstMarkPrice                ExecInst = "MarkPrice"
+	ExecInstIndexPrice               ExecInst = "IndexPrice"
+	ExecInstLastPrice                ExecInst = "LastPrice"
+	ExecInstClose                    ExecInst = "Close"
+	ExecInstReduceOnly               ExecInst = "ReduceOnly"
+	ExecInstFixed                    ExecInst = "Fixed"
+	ExecInstLastWithinMark           ExecInst = "LastWithinMark"
+)
+
+// ParseExecInst parses a comma separated set of execution instructions,
+// dropping spaces and duplicates. An unknown instruction is an error.
+func ParseExecInst(s string) (ExecInst, error) {
+	var e ExecInst
+	for _, v := range strings.Split(s, ",") {
+		inst := ExecInst(strings.TrimSpace(v))
+		if inst == "" {
+			continue
+		}
+		if !inst.isKnown() {
+			return "", fmt.Errorf("bitmex: unknown execInst %q", inst)
+		}
+		e = e.With(inst)
+	}
+	return e, nil
+}
+
+// Split returns the instructions of e.
+func (e ExecInst) Split() []ExecInst {
+	if e == "" {
+		return nil
+	}
+	var insts []ExecInst
+	for _, v := range strings.Split(string(e), ",") {
+		insts = append(insts, ExecInst(v))
+	}
+	return insts
+}
+
+// Has reports whether e holds the instruction inst.
+func (e ExecInst) Has(inst ExecInst) bool {
+	for _, v := range e.Split() {
+		if v == inst {
+			return true
+		}
+	}
+	return false
+}
+
+// With returns e with the instructions insts added.
+func (e ExecInst) With(insts ...ExecInst) ExecInst {
+	for _, inst := range insts {
+		for _, v := range inst.Split() {
+			switch {
+			case e == "":
+				e = v
+			case !e.Has(v):
+				e += "," + v
+			}
+		}
+	}
+	return e
+}
+
+// IsValid reports whether every instruction of e is a documented one.
+func (e ExecInst) IsValid() bool {
+	for _, v := range e.Split() {
+		if !v.isKnown() {
+			return false
+		}
+	}
+	return true
+}
+
+func (e ExecInst) isKnown() bool {
+	switch e {
+	case ExecInstParticipateDoNotInitiate, ExecInstAllOrNone, ExecInstMarkPrice, ExecInstIndexPrice, ExecInstLastPrice,
+		ExecInstClose, ExecInstReduceOnly, ExecInstFixed, ExecInstLastWithinMark:
+		return true
+	}
+	return false
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, dropping the spaces
+// around the instructions. Unknown instructions are kept.
+func (e *ExecInst) UnmarshalText(b []byte) error {
+	*e = ""
+	for _, v := range strings.Split(string(b), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*e = e.With(ExecInst(v))
+		}
+	}
+	return nil
+}
+
+// OrdStatus is the status of an order.
+type OrdStatus string
+
+const (
+	OrdStatusNew             OrdStatus = "New"
+	OrdStatusPartiallyFilled OrdStatus = "PartiallyFilled"
+	OrdStatusFilled          OrdStatus = "Filled"
+	OrdStatusDoneForDay      OrdStatus = "DoneForDay"
+	OrdStatusCanceled        OrdStatus = "Canceled"
+	OrdStatusPendingCancel   OrdStatus = "PendingCancel"
+	OrdStatusPendingNew      OrdStatus = "PendingNew"
+	OrdStatusRejected        OrdStatus = "Rejected"
+	OrdStatusExpired         OrdStatus = "Expired"
+	OrdStatusStopped         OrdStatus = "Stopped"
+	OrdStatusUntriggered     OrdStatus = "Untriggered"
+	OrdStatusTriggered       OrdStatus = "Triggered"
+)
+
+// IsValid reports whether s is a documented ordStatus.
+func (s OrdStatus) IsValid() bool {
+	switch s {
+	case OrdStatusNew, OrdStatusPartiallyFilled, OrdStatusFilled, OrdStatusDoneForDay, OrdStatusCanceled,
+		OrdStatusPendingCancel, OrdStatusPendingNew, OrdStatusRejected, OrdStatusExpired, OrdStatusStopped,
+		OrdStatusUntriggered, OrdStatusTriggered:
+		return true
+	}
+	return false
+}
+
+// IsOpen reports whether an order of status s may still trade.
+func (s OrdStatus) IsOpen() bool {
+	switch s {
+	case OrdStatusNew, OrdStatusPartiallyFilled, OrdStatusPendingNew, OrdStatusPendingCancel,
+		OrdStatusUntriggered, OrdStatusTriggered:
+		return true
+	}
+	return false
+}
+
+// ExecType is the event an execution reports.
+type ExecType string
+
+const (
+	ExecTypeNew                          ExecType = "New"
+	ExecTypeTrade                        ExecType = "Trade"
+	ExecTypeCanceled                     ExecType = "Canceled"
+	ExecTypeCancelReject                 ExecType = "CancelReject"
+	ExecTypeReplaced                     ExecType = "Replaced"
+	ExecTypeRejected                     ExecType = "Rejected"
+	ExecTypeAmendReject                  ExecType = "AmendReject"
+	ExecTypeRestated                     ExecType = "Restated"
+	ExecTypeTriggeredOrActivatedBySystem ExecType = "TriggeredOrActivatedBySystem"
+	ExecTypeFunding                      ExecType = "Funding"
+	ExecTypeSettlement                   ExecType = "Settlement"
+	ExecTypeLiquidation                  ExecType = "Liquidation"
+	ExecTypeBankruptcy                   ExecType = "Bankruptcy"
+	ExecTypeInsurance                    ExecType = "Insurance"
+	ExecTypeRebalance                    ExecType = "Rebalance"
+	ExecTypeSuspended                    ExecType = "Suspended"
+	ExecTypeReleased                     ExecType = "Released"
+	ExecTypeCalculated                   ExecType = "Calculated"
+)
+
+// IsValid reports whether t is a documented execType.
+func (t ExecType) IsValid() bool {
+	switch t {
+	case ExecTypeNew, ExecTypeTrade, ExecTypeCanceled, ExecTypeCancelReject, ExecTypeReplaced, ExecTypeRejected,
+		ExecTypeAmendReject, ExecTypeRestated, ExecTypeTriggeredOrActivatedBySystem, ExecTypeFunding,
+		ExecTypeSettlement, ExecTypeLiquidation, ExecTypeBankruptcy, ExecTypeInsurance, ExecTypeRebalance,
+		ExecTypeSuspended, ExecTypeReleased, ExecTypeCalculated:
+		return true
+	}
+	return false
+}
+
+// PegPriceType is the price a pegged order follows.
+type PegPriceType string
+
+const (
+	PegPriceTypeLastPeg         PegPriceType = "LastPeg"
+	PegPriceTypeMidPricePeg     PegPriceType = "MidPricePeg"
+	PegPriceTypeMarketPeg       PegPriceType = "MarketPeg"
+	PegPriceTypePrimaryPeg      PegPriceType = "PrimaryPeg"
+	PegPriceTypeTrailingStopPeg PegPriceType = "TrailingStopPeg"
+)
+
+// IsValid reports whether t is a documented pegPriceType.
+func (t PegPriceType) IsValid() bool {
+	switch t {
+	case PegPriceTypeLastPeg, PegPriceTypeMidPricePeg, PegPriceTypeMarketPeg, PegPriceTypePrimaryPeg, PegPriceTypeTrailingStopPeg:
+		return true
+	}
+	return false
+}
+
+// ContingencyType links the orders of a clOrdLinkID.
+type ContingencyType string
+
+const (
+	ContingencyTypeOneCancelsTheOther             ContingencyType = "OneCancelsTheOther"
+	ContingencyTypeOneTriggersTheOther            ContingencyType = "OneTriggersTheOther"
+	ContingencyTypeOneUpdatesTheOtherAbsolute     ContingencyType = "OneUpdatesTheOtherAbsolute"
+	ContingencyTypeOneUpdatesTheOtherProportional ContingencyType = "OneUpdatesTheOtherProportional"
+)
+
+// IsValid reports whether t is a documented contingencyType.
+func (t ContingencyType) IsValid() bool {
+	switch t {
+	case ContingencyTypeOneCancelsTheOther, ContingencyTypeOneTriggersTheOther,
+		ContingencyTypeOneUpdatesTheOtherAbsolute, ContingencyTypeOneUpdatesTheOtherProportional:
+		return true
+	}
+	return false
+}
+
+// TickDirection is the direction of the price of a trade from the one before.
+type TickDirection string
+
+const (
+	TickDirectionPlusTick      TickDirection = "PlusTick"
+	TickDirectionZeroPlusTick  TickDirection = "ZeroPlusTick"
+	TickDirectionMinusTick     TickDirection = "MinusTick"
+	TickDirectionZeroMinusTick TickDirection = "ZeroMinusTick"
+)
+
+// IsValid reports whether d is a documented tickDirection.
+func (d TickDirection) IsValid() bool {
+	switch d {
+	case TickDirectionPlusTick, TickDirectionZeroPlusTick, TickDirectionMinusTick, TickDirectionZeroMinusTick:
+		return true
+	}
+	return false
+}
+
+// InstrumentState is the trading state of an instrument.
+type InstrumentState string
+
+const (
+	InstrumentStateOpen     InstrumentState = "Open"
+	InstrumentStateClosed   InstrumentState = "Closed"
+	InstrumentStateUnlisted InstrumentState = "Unlisted"
+	InstrumentStateSettled  InstrumentState = "Settled"
+)
+
+// IsValid reports whether s is a documented instrument state.
+func (s InstrumentState) IsValid() bool {
+	switch s {
+	case InstrumentStateOpen, InstrumentStateClosed, InstrumentStateUnlisted, InstrumentStateSettled:
+		return true
+	}
+	return false
+}
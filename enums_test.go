@@ -0,0 +1,156 @@
+package bitmex_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-numb/go-bitmex"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExecInst(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want bitmex.ExecInst
+		err  bool
+	}{
+		{in: "", want: ""},
+		{in: "ReduceOnly", want: bitmex.ExecInstReduceOnly},
+		{in: "ParticipateDoNotInitiate,ReduceOnly", want: "ParticipateDoNotInitiate,ReduceOnly"},
+		{in: " ParticipateDoNotInitiate , ReduceOnly ", want: "ParticipateDoNotInitiate,ReduceOnly"},
+		{in: "ReduceOnly,ReduceOnly,Close", want: "ReduceOnly,Close"},
+		{in: "MarkPrice,,LastWithinMark", want: "MarkPrice,LastWithinMark"},
+		{in: "ReduceOnly,PostOnly", err: true},
+		{in: "reduceonly", err: true},
+	} {
+		got, err := bitmex.ParseExecInst(tt.in)
+		if tt.err {
+			assert.Error(t, err, tt.in)
+			continue
+		}
+		if assert.NoError(t, err, tt.in) {
+			assert.Equal(t, tt.want, got, tt.in)
+		}
+	}
+}
+
+func TestExecInst(t *testing.T) {
+	postOnly := bitmex.ExecInstParticipateDoNotInitiate
+	for _, tt := range []struct {
+		name  string
+		e     bitmex.ExecInst
+		want  bitmex.ExecInst
+		has   []bitmex.ExecInst
+		hasnt []bitmex.ExecInst
+		split []bitmex.ExecInst
+		valid bool
+	}{
+		{"empty", "", "", nil, []bitmex.ExecInst{postOnly}, nil, true},
+		{"one", bitmex.ExecInst("").With(postOnly), "ParticipateDoNotInitiate",
+			[]bitmex.ExecInst{postOnly}, []bitmex.ExecInst{bitmex.ExecInstReduceOnly},
+			[]bitmex.ExecInst{postOnly}, true},
+		{"two", postOnly.With(bitmex.ExecInstReduceOnly), "ParticipateDoNotInitiate,ReduceOnly",
+			[]bitmex.ExecInst{postOnly, bitmex.ExecInstReduceOnly}, []bitmex.ExecInst{bitmex.ExecInstClose},
+			[]bitmex.ExecInst{postOnly, bitmex.ExecInstReduceOnly}, true},
+		{"duplicates dropped", bitmex.ExecInstClose.With(bitmex.ExecInstClose, "LastPrice,Close"), "Close,LastPrice",
+			[]bitmex.ExecInst{bitmex.ExecInstClose, bitmex.ExecInstLastPrice}, []bitmex.ExecInst{"Close,LastPrice"},
+			[]bitmex.ExecInst{bitmex.ExecInstClose, bitmex.ExecInstLastPrice}, true},
+		{"no partial match", "MarkPrice,LastWithinMark", "MarkPrice,LastWithinMark",
+			[]bitmex.ExecInst{bitmex.ExecInstMarkPrice}, []bitmex.ExecInst{"Mark", bitmex.ExecInstLastPrice},
+			[]bitmex.ExecInst{bitmex.ExecInstMarkPrice, bitmex.ExecInstLastWithinMark}, true},
+		{"unknown kept", bitmex.ExecInstReduceOnly.With("Future"), "ReduceOnly,Future",
+			[]bitmex.ExecInst{"Future"}, nil,
+			[]bitmex.ExecInst{bitmex.ExecInstReduceOnly, "Future"}, false},
+	} {
+		assert.Equal(t, tt.want, tt.e, tt.name)
+		for _, inst := range tt.has {
+			assert.True(t, tt.e.Has(inst), "%s: has %s", tt.name, inst)
+		}
+		for _, inst := range tt.hasnt {
+			assert.False(t, tt.e.Has(inst), "%s: has %s", tt.name, inst)
+		}
+		assert.Equal(t, tt.split, tt.e.Split(), tt.name)
+		assert.Equal(t, tt.valid, tt.e.IsValid(), tt.name)
+	}
+}
+
+func TestExecInstUnmarshalText(t *testing.T) {
+	for _, tt := range []struct {
+		json string
+		want bitmex.ExecInst
+	}{
+		{`{"execInst":""}`, ""},
+		{`{"execInst":"ReduceOnly"}`, bitmex.ExecInstReduceOnly},
+		{`{"execInst":"ParticipateDoNotInitiate,ReduceOnly"}`, "ParticipateDoNotInitiate,ReduceOnly"},
+		{`{"execInst":"Close, LastPrice"}`, "Close,LastPrice"},
+		{`{"execInst":"Close,Close"}`, bitmex.ExecInstClose},
+		{`{"execInst":"ReduceOnly,Future"}`, "ReduceOnly,Future"},
+	} {
+		o := bitmex.Order{ExecInst: "stale"}
+		if assert.NoError(t, json.Unmarshal([]byte(tt.json), &o), tt.json) {
+			assert.Equal(t, tt.want, o.ExecInst, tt.json)
+		}
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		valid func(v string) bool
+		good  []string
+		bad   []string
+	}{
+		{"Side", func(v string) bool { return bitmex.Side(v).IsValid() },
+			[]string{"Buy", "Sell"}, []string{"", "buy", "Both"}},
+		{"OrdType", func(v string) bool { return bitmex.OrdType(v).IsValid() },
+			[]string{"Market", "Limit", "Stop", "StopLimit", "MarketIfTouched", "LimitIfTouched", "MarketWithLeftOverAsLimit", "Pegged"},
+			[]string{"", "limit", "TrailingStop"}},
+		{"TimeInForce", func(v string) bool { return bitmex.TimeInForce(v).IsValid() },
+			[]string{"Day", "GoodTillCancel", "ImmediateOrCancel", "FillOrKill"}, []string{"", "GTC"}},
+		{"ExecInst", func(v string) bool { return bitmex.ExecInst(v).IsValid() },
+			[]string{"", "ParticipateDoNotInitiate", "AllOrNone", "MarkPrice", "IndexPrice", "LastPrice", "Close", "ReduceOnly", "Fixed", "LastWithinMark", "Close,LastPrice"},
+			[]string{"PostOnly", "Close,PostOnly"}},
+		{"OrdStatus", func(v string) bool { return bitmex.OrdStatus(v).IsValid() },
+			[]string{"New", "PartiallyFilled", "Filled", "DoneForDay", "Canceled", "PendingCancel", "PendingNew", "Rejected", "Expired", "Stopped", "Untriggered", "Triggered"},
+			[]string{"", "Cancelled"}},
+		{"ExecType", func(v string) bool { return bitmex.ExecType(v).IsValid() },
+			[]string{"New", "Trade", "Canceled", "CancelReject", "Replaced", "Rejected", "AmendReject", "Restated", "TriggeredOrActivatedBySystem", "Funding", "Settlement", "Liquidation", "Bankruptcy", "Insurance", "Rebalance", "Suspended", "Released", "Calculated"},
+			[]string{"", "Filled"}},
+		{"PegPriceType", func(v string) bool { return bitmex.PegPriceType(v).IsValid() },
+			[]string{"LastPeg", "MidPricePeg", "MarketPeg", "PrimaryPeg", "TrailingStopPeg"}, []string{"", "Peg"}},
+		{"ContingencyType", func(v string) bool { return bitmex.ContingencyType(v).IsValid() },
+			[]string{"OneCancelsTheOther", "OneTriggersTheOther", "OneUpdatesTheOtherAbsolute", "OneUpdatesTheOtherProportional"},
+			[]string{"", "OCO"}},
+		{"TickDirection", func(v string) bool { return bitmex.TickDirection(v).IsValid() },
+			[]string{"PlusTick", "ZeroPlusTick", "MinusTick", "ZeroMinusTick"}, []string{"", "Tick"}},
+		{"InstrumentState", func(v string) bool { return bitmex.InstrumentState(v).IsValid() },
+			[]string{"Open", "Closed", "Unlisted", "Settled"}, []string{"", "open"}},
+	} {
+		for _, v := range tt.good {
+			assert.True(t, tt.valid(v), "%s %q", tt.name, v)
+		}
+		for _, v := range tt.bad {
+			assert.False(t, tt.valid(v), "%s %q", tt.name, v)
+		}
+	}
+}
+
+func TestEnums(t *testing.T) {
+	assert.Equal(t, bitmex.SideSell, bitmex.SideBuy.Opposite())
+	assert.Equal(t, bitmex.SideBuy, bitmex.SideSell.Opposite())
+	assert.Equal(t, bitmex.Side(""), bitmex.Side("").Opposite())
+
+	for _, typ := range []bitmex.OrdType{bitmex.OrdTypeStop, bitmex.OrdTypeStopLimit, bitmex.OrdTypeMarketIfTouched, bitmex.OrdTypeLimitIfTouched} {
+		assert.True(t, typ.IsTriggered(), string(typ))
+	}
+	for _, typ := range []bitmex.OrdType{bitmex.OrdTypeMarket, bitmex.OrdTypeLimit, bitmex.OrdTypePegged} {
+		assert.False(t, typ.IsTriggered(), string(typ))
+	}
+	for _, s := range []bitmex.OrdStatus{bitmex.OrdStatusNew, bitmex.OrdStatusPartiallyFilled, bitmex.OrdStatusUntriggered} {
+		assert.True(t, s.IsOpen(), string(s))
+	}
+	for _, s := range []bitmex.OrdStatus{bitmex.OrdStatusFilled, bitmex.OrdStatusCanceled, bitmex.OrdStatusRejected} {
+		assert.False(t, s.IsOpen(), string(s))
+	}
+}
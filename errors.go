@@ -0,0 +1,139 @@
+package bitmex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors matched by *APIError through errors.Is.
+var (
+	// ErrRateLimited is returned for 429 responses and when the client side limiter holds a request back.
+	ErrRateLimited = errors.New("bitmex: rate limited")
+	// ErrOverloaded is returned for 503 "The system is currently overloaded" responses.
+	ErrOverloaded = errors.New("bitmex: system overloaded")
+	// ErrInsufficientBalance is returned when the account lacks available balance for an order.
+	ErrInsufficientBalance = errors.New("bitmex: insufficient available balance")
+	// ErrDuplicateClOrdID is returned when a clOrdID has already been used.
+	ErrDuplicateClOrdID = errors.New("bitmex: duplicate clOrdID")
+	// ErrInvalidOrdStatus is returned when an order cannot be amended or canceled in its current state.
+	ErrInvalidOrdStatus = errors.New("bitmex: invalid ordStatus")
+	// ErrAuth is returned when the request was rejected for missing, invalid or expired credentials.
+	ErrAuth = errors.New("bitmex: authentication failed")
+	// ErrNotFound is returned for 404 responses.
+	ErrNotFound = errors.New("bitmex: not found")
+)
+
+// APIError is the error returned by every service method for a non-2xx response.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Name       string // BitMEX error name, e.g. "HTTPError" or "ValidationError"
+	Message    string // BitMEX error message
+	Operation  string // swagger operation id, e.g. "Order.new"
+	Method     string
+	URL        string
+	Meta       RequestMeta // metadata attached with WithRequestMeta
+
+	// Rate limit headers of the response, zero when absent.
+	RateLimit          int
+	RateLimitRemaining int
+	RateLimitReset     time.Time
+	RetryAfter         time.Duration
+
+	body []byte
+}
+
+// newAPIError builds an *APIError from a response whose body has been read into body.
+func newAPIError(operation string, res *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		Operation:  operation,
+		body:       body,
+	}
+	if res.Request != nil {
+		e.Method = res.Request.Method
+		e.URL = res.Request.URL.String()
+		e.Meta, _ = RequestMetaFromContext(res.Request.Context())
+	}
+
+	var v ModelError
+	if err := json.Unmarshal(body, &v); err == nil && v.Error_ != nil {
+		e.Name = v.Error_.Name
+		e.Message = v.Error_.Message
+	} else {
+		e.Message = strings.TrimSpace(string(body))
+	}
+
+	e.RateLimit, _ = strconv.Atoi(res.Header.Get("x-ratelimit-limit"))
+	e.RateLimitRemaining, _ = strconv.Atoi(res.Header.Get("x-ratelimit-remaining"))
+	if reset, err := strconv.ParseInt(res.Header.Get("x-ratelimit-reset"), 10, 64); err == nil {
+		e.RateLimitReset = time.Unix(reset, 0)
+	}
+	if wait, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+		e.RetryAfter = time.Duration(wait) * time.Second
+	}
+	return e
+}
+
+// Error returns non-empty string if there was an error.
+func (e *APIError) Error() string {
+	msg := e.Status
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Meta.RequestID != "" {
+		return fmt.Sprintf("bitmex: %s %s (request %s): %s", e.Operation, e.URL, e.Meta.RequestID, msg)
+	}
+	return fmt.Sprintf("bitmex: %s %s: %s", e.Operation, e.URL, msg)
+}
+
+// Body returns the raw bytes of the response
+func (e *APIError) Body() []byte {
+	return e.body
+}
+
+// Model returns the unpacked model of the error, a ModelError.
+func (e *APIError) Model() interface{} {
+	return ModelError{Error_: &ErrorError{Name: e.Name, Message: e.Message}}
+}
+
+// GenericSwaggerError is the error the service methods returned before
+// *APIError, which implements it.
+//
+// Deprecated: use errors.As with *APIError.
+type GenericSwaggerError interface {
+	error
+	Body() []byte
+	Model() interface{}
+}
+
+var _ GenericSwaggerError = (*APIError)(nil)
+
+// Is lets errors.Is match e against the sentinel errors of this package.
+func (e *APIError) Is(target error) bool {
+	msg := strings.ToLower(e.Message)
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrOverloaded:
+		// BitMEX also answers 503 while in maintenance, which is not a load shed.
+		return e.StatusCode == http.StatusServiceUnavailable && strings.Contains(msg, "system is currently overloaded")
+	case ErrAuth:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrInsufficientBalance:
+		return strings.Contains(msg, "insufficient available balance")
+	case ErrDuplicateClOrdID:
+		return strings.Contains(msg, "duplicate clordid")
+	case ErrInvalidOrdStatus:
+		return strings.Contains(msg, "invalid ordstatus")
+	}
+	return false
+}
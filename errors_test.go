@@ -0,0 +1,82 @@
+package bitmex_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-numb/go-bitmex"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	for _, tt := range []struct {
+		status  int
+		message string
+		is      []error
+		isNot   []error
+	}{
+		{503, "The system is currently overloaded. Please try again later.",
+			[]error{bitmex.ErrOverloaded}, []error{bitmex.ErrRateLimited}},
+		{503, "BitMEX is currently undergoing maintenance.",
+			nil, []error{bitmex.ErrOverloaded}},
+		{429, "Rate limit exceeded, retry in 1 seconds.",
+			[]error{bitmex.ErrRateLimited}, []error{bitmex.ErrOverloaded}},
+		{400, "Account has insufficient Available Balance, 20 XBt required",
+			[]error{bitmex.ErrInsufficientBalance}, []error{bitmex.ErrAuth}},
+		{400, "Duplicate clOrdID",
+			[]error{bitmex.ErrDuplicateClOrdID}, nil},
+		{400, "Invalid ordStatus",
+			[]error{bitmex.ErrInvalidOrdStatus}, nil},
+		{401, "Signature not valid.",
+			[]error{bitmex.ErrAuth}, []error{bitmex.ErrNotFound}},
+		{404, "Not Found",
+			[]error{bitmex.ErrNotFound}, nil},
+	} {
+		err := errorFor(t, tt.status, tt.message)
+		for _, target := range tt.is {
+			assert.ErrorIs(t, err, target, tt.message)
+		}
+		for _, target := range tt.isNot {
+			assert.NotErrorIs(t, err, target, tt.message)
+		}
+	}
+}
+
+func TestGenericSwaggerError(t *testing.T) {
+	err := errorFor(t, 400, "Invalid ordStatus")
+
+	gse, ok := err.(bitmex.GenericSwaggerError)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Contains(t, string(gse.Body()), "Invalid ordStatus")
+	model, ok := gse.Model().(bitmex.ModelError)
+	if assert.True(t, ok) {
+		assert.Equal(t, "Invalid ordStatus", model.Error_.Message)
+	}
+}
+
+// errorFor returns the error of a call answered with status and message.
+func errorFor(t *testing.T, status int, message string) error {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"error":{"message":%q,"name":"HTTPError"}}`, message)
+	}))
+	defer srv.Close()
+
+	cfg := bitmex.NewConfiguration()
+	cfg.BasePath = srv.URL
+	_, _, err := bitmex.NewAPIClient(cfg).OrderApi.OrderGetOrders(bitmex.NewAPIKeyContext("key", "secret"), nil)
+	var apiErr *bitmex.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("%v is not an *APIError", err)
+	}
+	assert.Equal(t, status, apiErr.StatusCode)
+	return err
+}
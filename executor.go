@@ -0,0 +1,116 @@
+package bitmex
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// consumes and produces are the content types listed by every operation of the swagger spec.
+	consumes = []string{"application/json", "application/x-www-form-urlencoded"}
+	produces = []string{"application/json", "application/xml", "text/xml", "application/javascript", "text/javascript"}
+)
+
+// endpoint describes a swagger operation.
+type endpoint struct {
+	Operation string // swagger operation id, e.g. "Order.new"
+	Method    string
+	Path      string // relative to Configuration.BasePath
+	Bulk      bool   // charged ceil(0.1 * orders) against the request budget
+}
+
+// param is a required parameter of an operation.
+type param struct {
+	name  string
+	value interface{}
+}
+
+// do calls the operation e with the required params and the set fields of the
+// *XxxOpts struct opts, and decodes a successful response into a T.
+func do[T any](ctx context.Context, c *APIClient, e endpoint, opts interface{}, params ...param) (T, *http.Response, error) {
+	var v T
+	res, err := c.invoke(ctx, e, opts, &v, params...)
+	return v, res, err
+}
+
+// invoke builds the request of the operation e and executes it, decoding a
+// successful response into result unless it is nil.
+func (c *APIClient) invoke(ctx context.Context, e endpoint, opts interface{}, result interface{}, params ...param) (*http.Response, error) {
+	headerParams := map[string]string{
+		"Content-Type": selectHeaderContentType(consumes),
+		"Accept":       selectHeaderAccept(produces),
+	}
+
+	// GET operations take their parameters in the query, all others in the form.
+	values := url.Values{}
+	for _, p := range params {
+		values.Add(p.name, parameterToString(p.value, ""))
+	}
+	addOptionals(values, opts)
+	queryParams, formParams := values, url.Values{}
+	if e.Method != http.MethodGet {
+		queryParams, formParams = formParams, queryParams
+	}
+
+	r, err := c.prepareRequest(ctx, c.cfg.BasePath+e.Path, e.Method, nil, headerParams, queryParams, formParams, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	weight := 1
+	if e.Bulk {
+		weight = bulkWeight(values.Get("orders"))
+	}
+	return c.execute(&Call{Operation: e.Operation, Options: opts}, r, weight, result)
+}
+
+// addOptionals adds every set field of the *XxxOpts struct opts to values,
+// named after the field with its first letter in lower case.
+func addOptionals(values url.Values, opts interface{}) {
+	v := reflect.ValueOf(opts)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	v = v.Elem()
+
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i).Addr()
+		o, ok := f.Interface().(interface{ IsSet() bool })
+		if !ok || !o.IsSet() {
+			continue
+		}
+		value := f.MethodByName("Value").Call(nil)[0].Interface()
+		values.Add(paramName(v.Type().Field(i).Name), parameterToString(value, ""))
+	}
+}
+
+// optionalValues returns the values set in the *XxxOpts struct opts by
+// parameter name, as the order objects of the bulk endpoints hold them.
+func optionalValues(opts interface{}) map[string]interface{} {
+	m := map[string]interface{}{}
+	v := reflect.ValueOf(opts)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return m
+	}
+	v = v.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i).Addr()
+		if o, ok := f.Interface().(interface{ IsSet() bool }); ok && o.IsSet() {
+			m[paramName(v.Type().Field(i).Name)] = f.MethodByName("Value").Call(nil)[0].Interface()
+		}
+	}
+	return m
+}
+
+// paramName returns the swagger name of the *XxxOpts field name, e.g. "clOrdID"
+// for ClOrdID or "type" for Type_.
+func paramName(field string) string {
+	field = strings.TrimSuffix(field, "_")
+	r, n := utf8.DecodeRuneInString(field)
+	return string(unicode.ToLower(r)) + field[n:]
+}
@@ -0,0 +1,171 @@
+package bitmex
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-numb/go-bitmex/optional"
+)
+
+// TimePart is a component of a timestamp a filter can match, as in
+// {"timestamp.time": "12:00"}.
+type TimePart string
+
+const (
+	TimeDate   TimePart = "date"  // "2006-01-02"
+	TimeMonth  TimePart = "month" // "2006-01"
+	TimeOfDay  TimePart = "time"  // "15:04" or "15:04:05.000"
+	TimeYear   TimePart = "yy"
+	TimeMonths TimePart = "mm" // month of the year
+	TimeWeek   TimePart = "ww"
+	TimeDay    TimePart = "dd" // day of the month
+	TimeHour   TimePart = "hh"
+	TimeMinute TimePart = "uu"
+	TimeSecond TimePart = "ss"
+)
+
+// Filter is the JSON object of a filter parameter, checked against the fields
+// of the model the endpoint returns. Start it with the XxxFilter function of
+// the model, e.g.
+//
+//	opts.Filter, err = bitmex.OrderFilter().OrdStatus("New", "PartiallyFilled").Side("Buy").Build()
+//
+// or with NewFilter for any model. A field given several values matches any of them.
+type Filter struct {
+	model  reflect.Type
+	values map[string]interface{}
+	err    error
+}
+
+// NewFilter starts a filter on the fields of the model T.
+func NewFilter[T any]() *Filter {
+	return &Filter{model: reflect.TypeOf((*T)(nil)).Elem(), values: map[string]interface{}{}}
+}
+
+// virtualFields are the keys BitMEX accepts in the filter of a model without
+// being fields of it, by model.
+var virtualFields = map[reflect.Type]map[string]bool{
+	reflect.TypeOf(Order{}): {"open": true}, // orders that are New or PartiallyFilled
+}
+
+// Field matches the field name, its JSON name in the model, against values. A
+// time field takes a TimePart suffix, e.g. Field("timestamp.time", "12:00").
+// The keys of virtualFields, such as "open" for Order, are accepted as well.
+// An unknown name is reported by Build.
+func (f *Filter) Field(name string, values ...interface{}) *Filter {
+	field, part, _ := strings.Cut(name, ".")
+	sf, ok := jsonFields(f.model)[field]
+	switch {
+	case !ok && part == "" && virtualFields[f.model][field]:
+		setField(f, name, values)
+	case !ok:
+		f.fail(fmt.Errorf("bitmex: %s has no field %q", f.model.Name(), field))
+	case part != "" && sf.Type != reflect.TypeOf(time.Time{}):
+		f.fail(fmt.Errorf("bitmex: %s.%s is not a timestamp", f.model.Name(), field))
+	default:
+		setField(f, name, values)
+	}
+	return f
+}
+
+// At matches the part of the time field name, e.g. At("timestamp", TimeOfDay, "12:00").
+func (f *Filter) At(name string, part TimePart, value interface{}) *Filter {
+	return f.Field(name+"."+string(part), value)
+}
+
+// Err returns the first invalid field given.
+func (f *Filter) Err() error {
+	return f.err
+}
+
+// Build returns the filter as the Filter of an *XxxOpts struct, or the first
+// invalid field given.
+func (f *Filter) Build() (optional.String, error) {
+	var o optional.String
+	if f.err != nil {
+		return o, f.err
+	}
+	o.Set(f.String())
+	return o, nil
+}
+
+// String returns the filter in JSON.
+func (f *Filter) String() string {
+	b, _ := f.MarshalJSON()
+	return string(b)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (f *Filter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.values)
+}
+
+func (f *Filter) fail(err error) {
+	if f.err == nil {
+		f.err = err
+	}
+}
+
+// setField matches name against values, a single value being sent as is and
+// several as an array.
+func setField[V any](f *Filter, name string, values []V) {
+	switch len(values) {
+	case 0:
+		delete(f.values, name)
+	case 1:
+		f.values[name] = values[0]
+	default:
+		f.values[name] = values
+	}
+}
+
+// Open matches the orders that are open, New or PartiallyFilled, or those
+// that are not, as the open filter of OrderGetOrders does.
+func (f *OrderFilterBuilder) Open(open bool) *OrderFilterBuilder {
+	setField(f.Filter, "open", []bool{open})
+	return f
+}
+
+// Columns returns the columns parameter selecting the fields names, their JSON
+// names in the model T, e.g.
+//
+//	opts.Columns, err = bitmex.Columns[bitmex.Trade]("price", "size")
+func Columns[T any](names ...string) (optional.String, error) {
+	var o optional.String
+	model := reflect.TypeOf((*T)(nil)).Elem()
+	fields := jsonFields(model)
+	for _, name := range names {
+		if _, ok := fields[name]; !ok {
+			return o, fmt.Errorf("bitmex: %s has no field %q", model.Name(), name)
+		}
+	}
+	b, err := json.Marshal(names)
+	if err != nil {
+		return o, err
+	}
+	o.Set(string(b))
+	return o, nil
+}
+
+// modelFields caches jsonFields by model type.
+var modelFields sync.Map
+
+// jsonFields returns the fields of the struct t by JSON name.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	if v, ok := modelFields.Load(t); ok {
+		return v.(map[string]reflect.StructField)
+	}
+	fields := map[string]reflect.StructField{}
+	for i := 0; t.Kind() == reflect.Struct && i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = t.Field(i)
+		}
+	}
+	modelFields.Store(t, fields)
+	return fields
+}
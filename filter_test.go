@@ -0,0 +1,95 @@
+package bitmex_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	noon := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		name   string
+		filter *bitmex.Filter
+		want   string
+	}{
+		{"empty", bitmex.OrderFilter().Filter, `{}`},
+		{"one value", bitmex.OrderFilter().OrdStatus(bitmex.OrdStatusNew).Filter,
+			`{"ordStatus":"New"}`},
+		{"any of values", bitmex.OrderFilter().OrdStatus(bitmex.OrdStatusNew, bitmex.OrdStatusPartiallyFilled).Filter,
+			`{"ordStatus":["New","PartiallyFilled"]}`},
+		{"several fields", bitmex.OrderFilter().Side(bitmex.SideBuy).Symbol("XBTUSD").OrderQty(100, 200).Filter,
+			`{"orderQty":[100,200],"side":"Buy","symbol":"XBTUSD"}`},
+		{"no values clears a field", bitmex.OrderFilter().Side(bitmex.SideBuy).Symbol("XBTUSD").Side().Filter,
+			`{"symbol":"XBTUSD"}`},
+		{"last values win", bitmex.OrderFilter().Side(bitmex.SideBuy).Side(bitmex.SideSell).Filter,
+			`{"side":"Sell"}`},
+		{"prices", bitmex.OrderFilter().Price(bitmex.FromFloat(9000.5), bitmex.FromFloat(9001)).Filter,
+			`{"price":[9000.5,9001]}`},
+		{"several execInst", bitmex.OrderFilter().ExecInst(bitmex.ExecInstParticipateDoNotInitiate.With(bitmex.ExecInstReduceOnly)).Filter,
+			`{"execInst":"ParticipateDoNotInitiate,ReduceOnly"}`},
+		{"timestamp", bitmex.OrderFilter().Timestamp(noon).Filter,
+			`{"timestamp":"2024-01-02T12:00:00Z"}`},
+		{"time of day", bitmex.OrderFilter().TimestampAt(bitmex.TimeOfDay, "12:00").Filter,
+			`{"timestamp.time":"12:00"}`},
+		{"hour and minute", bitmex.TradeFilter().TimestampAt(bitmex.TimeHour, 12).TimestampAt(bitmex.TimeMinute, 0).Filter,
+			`{"timestamp.hh":12,"timestamp.uu":0}`},
+		{"parts of two times", bitmex.OrderFilter().TimestampAt(bitmex.TimeDate, "2024-01-02").TransactTimeAt(bitmex.TimeMonth, "2024-01").Filter,
+			`{"timestamp.date":"2024-01-02","transactTime.month":"2024-01"}`},
+		{"any field", bitmex.NewFilter[bitmex.Trade]().Field("side", "Buy").Field("size", 1, 2),
+			`{"side":"Buy","size":[1,2]}`},
+		{"any time part", bitmex.NewFilter[bitmex.Trade]().Field("timestamp.dd", 2).At("timestamp", bitmex.TimeWeek, 1),
+			`{"timestamp.dd":2,"timestamp.ww":1}`},
+		{"open orders", bitmex.OrderFilter().Open(true).Symbol("XBTUSD").Filter,
+			`{"open":true,"symbol":"XBTUSD"}`},
+		{"closed orders", bitmex.OrderFilter().Open(true).Open(false).Filter,
+			`{"open":false}`},
+		{"open as a field", bitmex.NewFilter[bitmex.Order]().Field("open", true),
+			`{"open":true}`},
+	} {
+		assert.JSONEq(t, tt.want, tt.filter.String(), tt.name)
+		o, err := tt.filter.Build()
+		if assert.NoError(t, err, tt.name) {
+			assert.JSONEq(t, tt.want, o.Value(), tt.name)
+		}
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		filter *bitmex.Filter
+		err    string
+	}{
+		{"unknown field", bitmex.NewFilter[bitmex.Order]().Field("size", 1),
+			`bitmex: Order has no field "size"`},
+		{"time part of another field", bitmex.NewFilter[bitmex.Order]().Field("side.time", "12:00"),
+			`bitmex: Order.side is not a timestamp`},
+		{"first error kept", bitmex.NewFilter[bitmex.Order]().Field("a", 1).Field("b", 2),
+			`bitmex: Order has no field "a"`},
+		{"unknown field of a builder", bitmex.TradeFilter().Side(bitmex.SideBuy).Field("orderID", "x"),
+			`bitmex: Trade has no field "orderID"`},
+		{"open of another model", bitmex.NewFilter[bitmex.Trade]().Field("open", true),
+			`bitmex: Trade has no field "open"`},
+		{"time part of open", bitmex.NewFilter[bitmex.Order]().Field("open.time", "12:00"),
+			`bitmex: Order has no field "open"`},
+	} {
+		o, err := tt.filter.Build()
+		assert.EqualError(t, err, tt.err, tt.name)
+		assert.False(t, o.IsSet(), tt.name)
+		assert.EqualError(t, tt.filter.Err(), tt.err, tt.name)
+	}
+}
+
+func TestColumns(t *testing.T) {
+	o, err := bitmex.Columns[bitmex.Trade]("price", "size")
+	assert.NoError(t, err)
+	assert.Equal(t, `["price","size"]`, o.Value())
+
+	o, err = bitmex.Columns[bitmex.Trade]("price", "orderID")
+	assert.EqualError(t, err, `bitmex: Trade has no field "orderID"`)
+	assert.False(t, o.IsSet())
+}
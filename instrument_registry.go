@@ -0,0 +1,230 @@
+package bitmex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrUnknownSymbol is returned by InstrumentRegistry for a symbol it holds no
+// instrument of.
+var ErrUnknownSymbol = errors.New("bitmex: unknown symbol")
+
+// InstrumentRegistry caches the contract specifications of the active
+// instruments, their tick and lot sizes, multipliers, limits and fees, for
+// the services sizing and rounding orders. It is loaded by Refresh, kept up
+// to date by Run or by the rows of the realtime instrument table given to
+// Update, and safe for concurrent use:
+//
+//	reg := bitmex.NewInstrumentRegistry(client)
+//	if err := reg.Refresh(ctx); err != nil {
+//		return err
+//	}
+//	go reg.Run(ctx, time.Hour)
+//	price, err := reg.RoundPrice("XBTUSD", price)
+type InstrumentRegistry struct {
+	client *APIClient
+
+	mu          sync.RWMutex
+	instruments map[string]Instrument
+	err         error
+}
+
+// NewInstrumentRegistry returns an empty registry loading the instruments
+// through client.
+func NewInstrumentRegistry(client *APIClient) *InstrumentRegistry {
+	return &InstrumentRegistry{client: client, instruments: map[string]Instrument{}}
+}
+
+// Refresh replaces the instruments of r with the active instruments. An
+// instrument Update gave a later Timestamp than the snapshot, while the
+// request was under way, is kept, as are instruments newer than every one of
+// the snapshot. On failure r keeps the instruments it holds.
+func (r *InstrumentRegistry) Refresh(ctx context.Context) error {
+	active, _, err := r.client.InstrumentApi.InstrumentGetActive(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err = err; err != nil {
+		return err
+	}
+	instruments := make(map[string]Instrument, len(active))
+	var latest time.Time
+	for _, inst := range active {
+		if inst.Timestamp.After(latest) {
+			latest = inst.Timestamp
+		}
+		if held, ok := r.instruments[inst.Symbol]; ok && held.Timestamp.After(inst.Timestamp) {
+			inst = held
+		}
+		instruments[inst.Symbol] = inst
+	}
+	for symbol, held := range r.instruments {
+		if _, ok := instruments[symbol]; !ok && held.Timestamp.After(latest) {
+			instruments[symbol] = held
+		}
+	}
+	r.instruments = instruments
+	return nil
+}
+
+// Run refreshes r at once and then every interval until ctx is done, and
+// returns the error of ctx. The error of the last refresh is reported by Err.
+func (r *InstrumentRegistry) Run(ctx context.Context, interval time.Duration) error {
+	r.Refresh(ctx)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+			r.Refresh(ctx)
+		}
+	}
+}
+
+// Err returns the error of the last refresh, nil when it succeeded.
+func (r *InstrumentRegistry) Err() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.err
+}
+
+// Update applies an action of the realtime instrument table: the rows of
+// partial and insert actions are stored, the patches of update actions merged
+// into the instruments they name, and the rows of delete actions removed.
+//
+//	reg.Update(res.Action, res.Instrument, res.InstrumentPatch)
+func (r *InstrumentRegistry) Update(action string, rows []Instrument, patches []InstrumentPatch) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch action {
+	case "partial", "insert":
+		for _, inst := range rows {
+			r.instruments[inst.Symbol] = inst
+		}
+	case "update":
+		for _, p := range patches {
+			if inst, ok := r.instruments[p.Value.Symbol]; ok {
+				inst.Merge(p)
+				r.instruments[inst.Symbol] = inst
+			}
+		}
+	case "delete":
+		for _, inst := range rows {
+			delete(r.instruments, inst.Symbol)
+		}
+	}
+}
+
+// Get returns the instrument symbol.
+func (r *InstrumentRegistry) Get(symbol string) (Instrument, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	inst, ok := r.instruments[symbol]
+	return inst, ok
+}
+
+// Symbols returns the symbols of the instruments of r, sorted.
+func (r *InstrumentRegistry) Symbols() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	symbols := make([]string, 0, len(r.instruments))
+	for symbol := range r.instruments {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+func (r *InstrumentRegistry) lookup(symbol string) (Instrument, error) {
+	inst, ok := r.Get(symbol)
+	if !ok {
+		return Instrument{}, fmt.Errorf("%w %q", ErrUnknownSymbol, symbol)
+	}
+	return inst, nil
+}
+
+// RoundPrice returns price rounded to the nearest tick of symbol.
+func (r *InstrumentRegistry) RoundPrice(symbol string, price Decimal) (Decimal, error) {
+	inst, err := r.lookup(symbol)
+	if err != nil {
+		return price, err
+	}
+	return FromDecimal(ToDecimal(price).RoundStep(ToDecimal(inst.TickSize))), nil
+}
+
+// RoundQty returns qty rounded toward zero to a whole number of lots of symbol.
+func (r *InstrumentRegistry) RoundQty(symbol string, qty int) (int, error) {
+	inst, err := r.lookup(symbol)
+	if err != nil || inst.LotSize <= 0 {
+		return qty, err
+	}
+	return qty / inst.LotSize * inst.LotSize, nil
+}
+
+// ValidateOrder checks the quantities and prices of an order of symbol
+// against the specification of the instrument: whole lots up to maxOrderQty,
+// whole ticks up to maxPrice, and an open instrument. It returns
+// ErrInvalidOrder, or ErrUnknownSymbol.
+func (r *InstrumentRegistry) ValidateOrder(symbol string, opts *OrderNewOpts) error {
+	inst, err := r.lookup(symbol)
+	if err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = &OrderNewOpts{}
+	}
+	invalid := func(format string, a ...interface{}) error {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidOrder, symbol, fmt.Sprintf(format, a...))
+	}
+	if inst.State != "" && inst.State != InstrumentStateOpen {
+		return invalid("instrument is %s", inst.State)
+	}
+
+	for _, q := range []struct {
+		name string
+		qty  int
+		set  bool
+	}{
+		{"orderQty", opts.OrderQty.Value(), opts.OrderQty.IsSet()},
+		{"displayQty", opts.DisplayQty.Value(), opts.DisplayQty.IsSet() && opts.DisplayQty.Value() != 0},
+	} {
+		qty := q.qty
+		if qty < 0 {
+			qty = -qty
+		}
+		switch {
+		case !q.set:
+		case inst.LotSize > 0 && qty%inst.LotSize != 0:
+			return invalid("%s %d is not a multiple of the lot size %d", q.name, q.qty, inst.LotSize)
+		case inst.MaxOrderQty > 0 && qty > inst.MaxOrderQty:
+			return invalid("%s %d above the maximum %d", q.name, q.qty, inst.MaxOrderQty)
+		}
+	}
+
+	tick, maxPrice := ToDecimal(inst.TickSize), ToDecimal(inst.MaxPrice)
+	for _, p := range []struct {
+		name  string
+		price Decimal
+		set   bool
+	}{
+		{"price", opts.Price.Value(), opts.Price.IsSet()},
+		{"stopPx", opts.StopPx.Value(), opts.StopPx.IsSet()},
+	} {
+		price := ToDecimal(p.price)
+		switch {
+		case !p.set:
+		case price.Sign() <= 0:
+			return invalid("%s %v not positive", p.name, price)
+		case !price.IsMultipleOf(tick):
+			return invalid("%s %v is not a multiple of the tick size %v", p.name, price, tick)
+		case !maxPrice.IsZero() && price.GreaterThan(maxPrice):
+			return invalid("%s %v above the maximum %v", p.name, price, maxPrice)
+		}
+	}
+	return nil
+}
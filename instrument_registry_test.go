@@ -0,0 +1,193 @@
+package bitmex_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+	"github.com/go-numb/go-bitmex/bitmextest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var snapshotTime = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// newRegistry returns an empty registry loading from a bitmextest.Server
+// listing XBTUSD, ETHUSD, and XBTZ of 100 contract lots stamped snapshotTime.
+func newRegistry(t *testing.T) *bitmex.InstrumentRegistry {
+	srv := bitmextest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.AddInstrument(bitmex.Instrument{
+		Symbol:        "XBTZ",
+		State:         bitmex.InstrumentStateOpen,
+		LotSize:       100,
+		TickSize:      bitmex.FromFloat(0.5),
+		MaxOrderQty:   1000,
+		MaxPrice:      bitmex.FromFloat(100000),
+		LastPrice:     bitmex.FromFloat(7000),
+		SettlCurrency: "XBt",
+		Timestamp:     snapshotTime,
+	})
+	client := bitmex.NewAPIClient(bitmex.NewConfiguration())
+	client.ChangeBasePath(srv.URL)
+	return bitmex.NewInstrumentRegistry(client)
+}
+
+func instrumentPatch(t *testing.T, s string) bitmex.InstrumentPatch {
+	var p bitmex.InstrumentPatch
+	if !assert.NoError(t, json.Unmarshal([]byte(s), &p)) {
+		t.FailNow()
+	}
+	return p
+}
+
+func TestInstrumentRegistryRound(t *testing.T) {
+	reg := newRegistry(t)
+	if !assert.NoError(t, reg.Refresh(context.Background())) {
+		return
+	}
+	assert.Equal(t, []string{"ETHUSD", "XBTUSD", "XBTZ"}, reg.Symbols())
+
+	for _, tt := range []struct {
+		symbol string
+		price  float64
+		want   float64
+	}{
+		{"XBTUSD", 7000.2, 7000},
+		{"XBTUSD", 7000.25, 7000.5},
+		{"XBTUSD", 7000.74, 7000.5},
+		{"ETHUSD", 150.024, 150},
+		{"ETHUSD", 150.026, 150.05},
+	} {
+		got, err := reg.RoundPrice(tt.symbol, bitmex.FromFloat(tt.price))
+		assert.NoError(t, err, tt.symbol)
+		assert.Equal(t, tt.want, bitmex.ToFloat(got), "%s %v", tt.symbol, tt.price)
+	}
+
+	for _, tt := range []struct {
+		symbol string
+		qty    int
+		want   int
+	}{
+		{"XBTUSD", 123, 123},
+		{"XBTZ", 250, 200},
+		{"XBTZ", -250, -200},
+		{"XBTZ", 99, 0},
+	} {
+		got, err := reg.RoundQty(tt.symbol, tt.qty)
+		assert.NoError(t, err, tt.symbol)
+		assert.Equal(t, tt.want, got, "%s %d", tt.symbol, tt.qty)
+	}
+
+	_, err := reg.RoundPrice("XBTH", bitmex.FromFloat(7000))
+	assert.ErrorIs(t, err, bitmex.ErrUnknownSymbol)
+	_, err = reg.RoundQty("XBTH", 100)
+	assert.ErrorIs(t, err, bitmex.ErrUnknownSymbol)
+}
+
+func TestInstrumentRegistryValidateOrder(t *testing.T) {
+	reg := newRegistry(t)
+	if !assert.NoError(t, reg.Refresh(context.Background())) {
+		return
+	}
+	reg.Update("insert", []bitmex.Instrument{{Symbol: "XBTM", State: "Settled", LotSize: 1, TickSize: bitmex.FromFloat(0.5)}}, nil)
+
+	for _, tt := range []struct {
+		name   string
+		symbol string
+		order  *bitmex.OrderBuilder
+		err    string // "" when valid
+	}{
+		{"limit", "XBTZ", bitmex.LimitOrder("XBTZ", bitmex.SideBuy, 200, px(7000.5)), ""},
+		{"short", "XBTZ", bitmex.MarketOrder("XBTZ", bitmex.SideSell, 1000), ""},
+		{"odd lot", "XBTZ", bitmex.MarketOrder("XBTZ", bitmex.SideBuy, 250), "orderQty 250 is not a multiple of the lot size 100"},
+		{"above max qty", "XBTZ", bitmex.MarketOrder("XBTZ", bitmex.SideBuy, 1100), "orderQty 1100 above the maximum 1000"},
+		{"odd display lot", "XBTZ", bitmex.LimitOrder("XBTZ", bitmex.SideBuy, 200, px(7000)).DisplayQty(150), "displayQty 150 is not a multiple"},
+		{"hidden", "XBTZ", bitmex.LimitOrder("XBTZ", bitmex.SideBuy, 200, px(7000)).DisplayQty(0), ""},
+		{"off tick", "XBTZ", bitmex.LimitOrder("XBTZ", bitmex.SideBuy, 200, px(7000.25)), "price 7000.25 is not a multiple of the tick size 0.5"},
+		{"off tick stop", "XBTZ", bitmex.StopMarketOrder("XBTZ", bitmex.SideSell, 200, px(6500.1)), "stopPx 6500.1 is not a multiple"},
+		{"above max price", "XBTZ", bitmex.LimitOrder("XBTZ", bitmex.SideSell, 200, px(100000.5)), "price 100000.5 above the maximum 100000"},
+		{"settled", "XBTM", bitmex.LimitOrder("XBTM", bitmex.SideBuy, 1, px(7000)), "instrument is Settled"},
+	} {
+		opts, err := tt.order.NewOpts()
+		if !assert.NoError(t, err, tt.name) {
+			continue
+		}
+		err = reg.ValidateOrder(tt.symbol, opts)
+		if tt.err == "" {
+			assert.NoError(t, err, tt.name)
+			continue
+		}
+		assert.ErrorIs(t, err, bitmex.ErrInvalidOrder, tt.name)
+		assert.ErrorContains(t, err, tt.err, tt.name)
+	}
+
+	var opts bitmex.OrderNewOpts
+	opts.Price.Set(px(-1))
+	assert.ErrorContains(t, reg.ValidateOrder("XBTUSD", &opts), "price -1 not positive")
+	assert.NoError(t, reg.ValidateOrder("XBTUSD", nil))
+	assert.ErrorIs(t, reg.ValidateOrder("XBTH", nil), bitmex.ErrUnknownSymbol)
+}
+
+func TestInstrumentRegistryUpdate(t *testing.T) {
+	reg := newRegistry(t)
+	xbtz := func() bitmex.Instrument {
+		inst, _ := reg.Get("XBTZ")
+		return inst
+	}
+
+	reg.Update("partial", []bitmex.Instrument{{Symbol: "XBTZ", LotSize: 100, TickSize: px(0.5), Timestamp: snapshotTime}}, nil)
+	reg.Update("insert", []bitmex.Instrument{{Symbol: "XBTH", LotSize: 1}}, nil)
+	assert.Equal(t, []string{"XBTH", "XBTZ"}, reg.Symbols())
+
+	reg.Update("update", nil, []bitmex.InstrumentPatch{
+		instrumentPatch(t, `{"symbol":"XBTZ","lastPrice":7100.5,"timestamp":"2024-03-01T12:00:01.000Z"}`),
+		instrumentPatch(t, `{"symbol":"XBTU","lastPrice":1}`), // unknown, ignored
+	})
+	assert.Equal(t, 7100.5, bitmex.ToFloat(xbtz().LastPrice))
+	assert.Equal(t, 100, xbtz().LotSize)
+	assert.Equal(t, []string{"XBTH", "XBTZ"}, reg.Symbols())
+
+	reg.Update("delete", []bitmex.Instrument{{Symbol: "XBTH"}}, nil)
+	_, ok := reg.Get("XBTH")
+	assert.False(t, ok)
+
+	// The snapshot, older than the update, leaves XBTZ as updated, and keeps
+	// an instrument inserted after it.
+	reg.Update("insert", []bitmex.Instrument{{Symbol: "XBTH", Timestamp: snapshotTime.Add(time.Second)}}, nil)
+	assert.NoError(t, reg.Refresh(context.Background()))
+	assert.Equal(t, []string{"ETHUSD", "XBTH", "XBTUSD", "XBTZ"}, reg.Symbols())
+	assert.Equal(t, 7100.5, bitmex.ToFloat(xbtz().LastPrice))
+
+	// A newer snapshot replaces them, dropping instruments it doesn't list.
+	reg.Update("partial", []bitmex.Instrument{
+		{Symbol: "XBTZ", LastPrice: px(6900), Timestamp: snapshotTime.Add(-time.Second)},
+		{Symbol: "XBTH", Timestamp: snapshotTime.Add(-time.Second)},
+	}, nil)
+	assert.NoError(t, reg.Refresh(context.Background()))
+	assert.Equal(t, []string{"ETHUSD", "XBTUSD", "XBTZ"}, reg.Symbols())
+	assert.Equal(t, 7000.0, bitmex.ToFloat(xbtz().LastPrice))
+	assert.NoError(t, reg.Err())
+}
+
+func TestInstrumentRegistryRun(t *testing.T) {
+	// Run loads the instruments without waiting for the first interval.
+	reg := newRegistry(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- reg.Run(ctx, time.Hour) }()
+	assert.Eventually(t, func() bool { return len(reg.Symbols()) == 3 }, time.Second, 5*time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	// A failed refresh keeps the instruments and is reported by Err.
+	client := bitmex.NewAPIClient(bitmex.NewConfiguration())
+	client.ChangeBasePath("http://127.0.0.1:1/api/v1")
+	reg = bitmex.NewInstrumentRegistry(client)
+	reg.Update("partial", []bitmex.Instrument{{Symbol: "XBTZ"}}, nil)
+	assert.Error(t, reg.Refresh(context.Background()))
+	assert.Error(t, reg.Err())
+	assert.Equal(t, []string{"XBTZ"}, reg.Symbols())
+}
@@ -0,0 +1,59 @@
+package bitmex
+
+import (
+	"net/http"
+)
+
+// Call describes one service method invocation as it passes through the
+// interceptors of an APIClient.
+type Call struct {
+	// Operation is the swagger operation id, e.g. "Order.new".
+	Operation string
+	// Options is the *XxxOpts struct passed to the service method, or nil.
+	Options interface{}
+	// Meta is the RequestMeta attached to the context of the call.
+	Meta RequestMeta
+	// Attempt counts the requests sent for the call, starting at 1.
+	Attempt int
+
+	// Request is the outgoing request. Interceptors may modify it before it is sent.
+	Request *http.Request
+	// Response and Body are set once the response has been read.
+	Response *http.Response
+	Body     []byte
+	// Result points to the decoded model once the call has succeeded.
+	Result interface{}
+	// Err is the outcome of the call: a transport error, an *APIError or a decode error.
+	Err error
+}
+
+// Interceptor hooks into every Call made by an APIClient. Nil hooks are skipped.
+// BeforeSign, AfterSign and AfterResponse run once per attempt when the call is retried.
+// A hook returning an error aborts the call without retrying it, and the caller receives that error.
+type Interceptor struct {
+	// BeforeSign runs once the request is built, before the authentication headers are added.
+	BeforeSign func(*Call) error
+	// AfterSign runs on the signed request, just before it is sent.
+	AfterSign func(*Call) error
+	// AfterResponse runs once the response body has been read, before it is decoded.
+	AfterResponse func(*Call) error
+	// AfterDecode runs last for every call, including failed ones; check Call.Err.
+	AfterDecode func(*Call) error
+}
+
+// intercept runs the hook picked by phase of every interceptor in order.
+func (c *APIClient) intercept(call *Call, phase func(Interceptor) func(*Call) error) error {
+	for _, i := range c.cfg.Interceptors {
+		if hook := phase(i); hook != nil {
+			if err := hook(call); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func beforeSign(i Interceptor) func(*Call) error    { return i.BeforeSign }
+func afterSign(i Interceptor) func(*Call) error     { return i.AfterSign }
+func afterResponse(i Interceptor) func(*Call) error { return i.AfterResponse }
+func afterDecode(i Interceptor) func(*Call) error   { return i.AfterDecode }
@@ -0,0 +1,292 @@
+package bitmex
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/go-numb/go-bitmex/optional"
+)
+
+// MaxCount is the largest Count a history endpoint accepts.
+const MaxCount = 1000
+
+// Iterator walks the rows of a history endpoint page by page:
+//
+//	it := client.TradeApi.TradeIter(ctx, &opts)
+//	for it.Next() {
+//		trade := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// Pages hold Count rows of the options, MaxCount when unset. Rows come oldest
+// first, or newest first with Reverse. Timestamped rows are paged by time:
+// each page starts at the timestamp of the last row received, skipping the rows
+// of that timestamp already returned, and the rows seen at the boundary are
+// de-duplicated by trdMatchID, execID or the like; quotes, which have no such
+// ID, by their position within the timestamp. The iteration stops at
+// EndTime, or StartTime with Reverse. Rows without a time filter are paged
+// with Start.
+//
+// Every page goes through the rate limiter of the client, so a long iteration
+// waits for the request budget under RateLimitBlock instead of running into 429s.
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, opts reflect.Value) ([]T, error)
+	opts  reflect.Value // the *XxxOpts struct the iteration started with
+	stamp func(T) time.Time
+	key   func(T) string // nil to key rows by their position within the timestamp
+
+	count   int
+	reverse bool
+	first   int       // Start of the first page
+	bound   time.Time // EndTime, or StartTime with reverse
+
+	pages  int
+	cursor time.Time       // timestamp of the last row returned
+	skip   int             // rows returned at cursor, or in all with offset paging
+	seen   map[string]bool // keys of the rows returned at cursor, or of the last page
+
+	buf  []T
+	cur  T
+	err  error
+	done bool
+}
+
+// newIterator iterates over the rows returned by get for the *XxxOpts struct
+// opts. stamp is nil for rows paged with Start, key nil for timestamped rows
+// without an ID.
+func newIterator[T any, O any](ctx context.Context, opts *O, get func(context.Context, *O) ([]T, *http.Response, error), stamp func(T) time.Time, key func(T) string) *Iterator[T] {
+	o := new(O)
+	if opts != nil {
+		*o = *opts
+	}
+	it := &Iterator[T]{
+		ctx: ctx,
+		fetch: func(ctx context.Context, v reflect.Value) ([]T, error) {
+			rows, _, err := get(ctx, v.Interface().(*O))
+			return rows, err
+		},
+		opts:  reflect.ValueOf(o),
+		stamp: stamp,
+		key:   key,
+		count: MaxCount,
+		seen:  map[string]bool{},
+	}
+
+	if v, ok := optionalValue(it.opts, "Count"); ok && v.(int) > 0 && v.(int) < MaxCount {
+		it.count = v.(int)
+	}
+	if v, ok := optionalValue(it.opts, "Start"); ok {
+		it.first = v.(int)
+	}
+	if v, ok := optionalValue(it.opts, "Reverse"); ok {
+		it.reverse = v.(bool)
+	}
+	bound := "EndTime"
+	if it.reverse {
+		bound = "StartTime"
+	}
+	if v, ok := optionalValue(it.opts, bound); ok {
+		it.bound = v.(time.Time)
+	}
+	return it
+}
+
+// Next advances to the next row, fetching a page when needed. It returns false
+// at the end of the rows or on an error, see Err.
+func (it *Iterator[T]) Next() bool {
+	for len(it.buf) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetchPage()
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Value returns the current row.
+func (it *Iterator[T]) Value() T {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Pages returns the number of pages fetched so far.
+func (it *Iterator[T]) Pages() int {
+	return it.pages
+}
+
+// All returns the remaining rows.
+func (it *Iterator[T]) All() ([]T, error) {
+	var rows []T
+	for it.Next() {
+		rows = append(rows, it.Value())
+	}
+	return rows, it.Err()
+}
+
+func (it *Iterator[T]) fetchPage() {
+	opts := reflect.New(it.opts.Elem().Type())
+	opts.Elem().Set(it.opts.Elem())
+	setOptional(opts, "Count", it.count)
+	switch {
+	case it.stamp == nil:
+		setOptional(opts, "Start", it.first+it.skip)
+	case it.cursor.IsZero():
+		setOptional(opts, "Start", it.first)
+	default:
+		setOptional(opts, "Start", it.skip)
+		if it.reverse {
+			setOptional(opts, "EndTime", it.cursor)
+		} else {
+			setOptional(opts, "StartTime", it.cursor)
+		}
+	}
+
+	rows, err := it.fetch(it.ctx, opts)
+	it.pages++
+	if err != nil {
+		it.err = err
+		return
+	}
+	if len(rows) < it.count {
+		it.done = true
+	}
+
+	if it.stamp == nil {
+		seen := make(map[string]bool, len(rows))
+		for _, row := range rows {
+			k := it.key(row)
+			seen[k] = true
+			if !it.seen[k] {
+				it.buf = append(it.buf, row)
+			}
+		}
+		it.seen = seen
+		it.skip += len(rows)
+		return
+	}
+
+	for _, row := range rows {
+		t := it.stamp(row)
+		if !it.bound.IsZero() && (it.reverse && t.Before(it.bound) || !it.reverse && t.After(it.bound)) {
+			it.done = true
+			break
+		}
+		if !t.Equal(it.cursor) {
+			it.cursor, it.skip, it.seen = t, 0, map[string]bool{}
+		}
+		k := strconv.Itoa(it.skip)
+		if it.key != nil {
+			k = it.key(row)
+		}
+		// the row counts toward Start even when returned already
+		it.skip++
+		if it.seen[k] {
+			continue
+		}
+		it.seen[k] = true
+		it.buf = append(it.buf, row)
+	}
+}
+
+// optionalValue returns the value of the optional field name of the *XxxOpts struct opts, if set.
+func optionalValue(opts reflect.Value, name string) (interface{}, bool) {
+	f := opts.Elem().FieldByName(name)
+	if !f.IsValid() {
+		return nil, false
+	}
+	f = f.Addr()
+	if !f.Interface().(interface{ IsSet() bool }).IsSet() {
+		return nil, false
+	}
+	return f.MethodByName("Value").Call(nil)[0].Interface(), true
+}
+
+// setOptional sets the optional field name of the *XxxOpts struct opts, if it has one.
+func setOptional(opts reflect.Value, name string, value interface{}) {
+	f := opts.Elem().FieldByName(name)
+	if !f.IsValid() {
+		return
+	}
+	f.Addr().MethodByName("Set").Call([]reflect.Value{reflect.ValueOf(value)})
+}
+
+// walletHistoryOpts pages UserGetWalletHistoryOpts, whose spec lacks the count
+// and start parameters the endpoint accepts.
+type walletHistoryOpts struct {
+	Currency optional.String
+	Count    optional.Int
+	Start    optional.Int
+}
+
+// TradeIter iterates over the trades matching opts, see Iterator.
+func (a *TradeApiService) TradeIter(ctx context.Context, opts *TradeGetOpts) *Iterator[Trade] {
+	return newIterator(ctx, opts, a.TradeGet,
+		func(t Trade) time.Time { return t.Timestamp },
+		func(t Trade) string { return t.TrdMatchID })
+}
+
+// TradeBucketedIter iterates over the trade bins matching opts, see Iterator.
+func (a *TradeApiService) TradeBucketedIter(ctx context.Context, opts *TradeGetBucketedOpts) *Iterator[TradeBin] {
+	return newIterator(ctx, opts, a.TradeGetBucketed,
+		func(b TradeBin) time.Time { return b.Timestamp },
+		func(b TradeBin) string { return b.Symbol })
+}
+
+// TradeHistoryIter iterates over the executions of ExecutionGetTradeHistory, see Iterator.
+func (a *ExecutionApiService) TradeHistoryIter(ctx context.Context, opts *ExecutionGetTradeHistoryOpts) *Iterator[Execution] {
+	return newIterator(ctx, opts, a.ExecutionGetTradeHistory,
+		func(e Execution) time.Time { return e.Timestamp },
+		func(e Execution) string { return e.ExecID })
+}
+
+// OrdersIter iterates over the orders matching opts, see Iterator.
+func (a *OrderApiService) OrdersIter(ctx context.Context, opts *OrderGetOrdersOpts) *Iterator[Order] {
+	return newIterator(ctx, opts, a.OrderGetOrders,
+		func(o Order) time.Time { return o.Timestamp },
+		func(o Order) string { return o.OrderID })
+}
+
+// FundingIter iterates over the funding history matching opts, see Iterator.
+func (a *FundingApiService) FundingIter(ctx context.Context, opts *FundingGetOpts) *Iterator[Funding] {
+	return newIterator(ctx, opts, a.FundingGet,
+		func(f Funding) time.Time { return f.Timestamp },
+		func(f Funding) string { return f.Symbol })
+}
+
+// LiquidationIter iterates over the liquidation orders matching opts, see
+// Iterator. They carry no timestamp and are paged with Start.
+func (a *LiquidationApiService) LiquidationIter(ctx context.Context, opts *LiquidationGetOpts) *Iterator[Liquidation] {
+	return newIterator(ctx, opts, a.LiquidationGet, nil,
+		func(l Liquidation) string { return l.OrderID })
+}
+
+// WalletHistoryIter iterates over the wallet history, newest first, see
+// Iterator. It is paged with Start.
+func (a *UserApiService) WalletHistoryIter(ctx context.Context, opts *UserGetWalletHistoryOpts) *Iterator[Transaction] {
+	o := &walletHistoryOpts{}
+	if opts != nil {
+		o.Currency = opts.Currency
+	}
+	get := func(ctx context.Context, opts *walletHistoryOpts) ([]Transaction, *http.Response, error) {
+		return do[[]Transaction](ctx, a.client, endpoint{Operation: "User.getWalletHistory", Method: http.MethodGet, Path: "/user/walletHistory"}, opts)
+	}
+	return newIterator(ctx, o, get, nil,
+		func(t Transaction) string { return t.TransactID })
+}
+
+// QuoteIter iterates over the quotes matching opts, see Iterator.
+func (a *QuoteApiService) QuoteIter(ctx context.Context, opts *QuoteGetOpts) *Iterator[Quote] {
+	return newIterator(ctx, opts, a.QuoteGet,
+		func(q Quote) time.Time { return q.Timestamp }, nil)
+}
@@ -0,0 +1,79 @@
+package bitmex_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+	"github.com/go-numb/go-bitmex/bitmextest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteIter(t *testing.T) {
+	t0 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := func(ms int, bidSize int) bitmex.Quote {
+		return bitmex.Quote{
+			Timestamp: t0.Add(time.Duration(ms) * time.Millisecond),
+			Symbol:    "XBTUSD",
+			BidSize:   bidSize,
+			BidPrice:  bitmex.FromFloat(42000),
+			AskPrice:  bitmex.FromFloat(42000.5),
+			AskSize:   100,
+		}
+	}
+	// Identical quotes at 1ms and 3ms, which a page boundary splits.
+	quotes := []bitmex.Quote{
+		at(0, 10), at(1, 20), at(1, 20), at(1, 20), at(1, 30), at(2, 40), at(3, 50), at(3, 50),
+	}
+	srv := bitmextest.NewServer()
+	defer srv.Close()
+	srv.AddQuotes(quotes...)
+	client := bitmex.NewAPIClient(bitmex.NewConfiguration())
+	client.ChangeBasePath(srv.URL)
+
+	reversed := make([]bitmex.Quote, len(quotes))
+	for i, q := range quotes {
+		reversed[len(quotes)-1-i] = q
+	}
+	for _, tt := range []struct {
+		name    string
+		count   int
+		reverse bool
+		bound   time.Time
+		want    []bitmex.Quote
+		pages   int
+	}{
+		{"one page", 100, false, time.Time{}, quotes, 1},
+		{"boundary inside a timestamp", 2, false, time.Time{}, quotes, 5},
+		{"page of one", 1, false, time.Time{}, quotes, 9},
+		{"page within a timestamp", 3, false, time.Time{}, quotes, 3},
+		{"reverse", 2, true, time.Time{}, reversed, 5},
+		{"end time", 2, false, t0.Add(time.Millisecond), quotes[:5], 3},
+		{"start time reverse", 2, true, t0.Add(2 * time.Millisecond), reversed[:3], 2},
+	} {
+		var opts bitmex.QuoteGetOpts
+		opts.Symbol.Set("XBTUSD")
+		opts.Count.Set(tt.count)
+		opts.Reverse.Set(tt.reverse)
+		if !tt.bound.IsZero() {
+			if tt.reverse {
+				opts.StartTime.Set(tt.bound)
+			} else {
+				opts.EndTime.Set(tt.bound)
+			}
+		}
+		it := client.QuoteApi.QuoteIter(context.Background(), &opts)
+		got, err := it.All()
+		assert.NoError(t, err, tt.name)
+		assert.Len(t, got, len(tt.want), tt.name)
+		for i := range got {
+			if i < len(tt.want) {
+				assert.True(t, tt.want[i].Timestamp.Equal(got[i].Timestamp), "%s: row %d", tt.name, i)
+				assert.Equal(t, tt.want[i].BidSize, got[i].BidSize, "%s: row %d", tt.name, i)
+			}
+		}
+		assert.Equal(t, tt.pages, it.Pages(), tt.name)
+	}
+}
@@ -1,23 +1,36 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
+	"encoding/json"
 	"time"
 )
 
 type Affiliate struct {
 	Account         int       `json:"account"`
 	Currency        string    `json:"currency"`
-	PrevPayout      int       `json:"prevPayout,omitempty"`
-	PrevTurnover    int       `json:"prevTurnover,omitempty"`
-	PrevComm        int       `json:"prevComm,omitempty"`
+	PrevPayout      Amount    `json:"prevPayout"`
+	PrevTurnover    Amount    `json:"prevTurnover"`
+	PrevComm        Amount    `json:"prevComm"`
 	PrevTimestamp   time.Time `json:"prevTimestamp,omitempty"`
-	ExecTurnover    int       `json:"execTurnover,omitempty"`
-	ExecComm        int       `json:"execComm,omitempty"`
+	ExecTurnover    Amount    `json:"execTurnover"`
+	ExecComm        Amount    `json:"execComm"`
 	TotalReferrals  int       `json:"totalReferrals,omitempty"`
-	TotalTurnover   int       `json:"totalTurnover,omitempty"`
-	TotalComm       int       `json:"totalComm,omitempty"`
+	TotalTurnover   Amount    `json:"totalTurnover"`
+	TotalComm       Amount    `json:"totalComm"`
 	PayoutPcnt      float64   `json:"payoutPcnt,omitempty"`
-	PendingPayout   int       `json:"pendingPayout,omitempty"`
+	PendingPayout   Amount    `json:"pendingPayout"`
 	Timestamp       time.Time `json:"timestamp,omitempty"`
 	ReferrerAccount int       `json:"referrerAccount,omitempty"`
 }
+
+// UnmarshalJSON implements json.Unmarshaler, giving the amounts of o the currency of o.
+func (o *Affiliate) UnmarshalJSON(b []byte) error {
+	type plain Affiliate
+	if err := json.Unmarshal(b, (*plain)(o)); err != nil {
+		return err
+	}
+	setCurrency(o.Currency, &o.PrevPayout, &o.PrevTurnover, &o.PrevComm, &o.ExecTurnover, &o.ExecComm, &o.TotalTurnover, &o.TotalComm, &o.PendingPayout)
+	return nil
+}
@@ -1,56 +1,361 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
+	"encoding/json"
 	"time"
 )
 
 // Raw Order and Balance Data
 type Execution struct {
-	ExecID                string    `json:"execID"`
-	OrderID               string    `json:"orderID,omitempty"`
-	ClOrdID               string    `json:"clOrdID,omitempty"`
-	ClOrdLinkID           string    `json:"clOrdLinkID,omitempty"`
-	Account               int       `json:"account,omitempty"`
-	Symbol                string    `json:"symbol,omitempty"`
-	Side                  string    `json:"side,omitempty"`
-	LastQty               int       `json:"lastQty,omitempty"`
-	LastPx                float64   `json:"lastPx,omitempty"`
-	UnderlyingLastPx      float64   `json:"underlyingLastPx,omitempty"`
-	LastMkt               string    `json:"lastMkt,omitempty"`
-	LastLiquidityInd      string    `json:"lastLiquidityInd,omitempty"`
-	SimpleOrderQty        float64   `json:"simpleOrderQty,omitempty"`
-	OrderQty              int       `json:"orderQty,omitempty"`
-	Price                 float64   `json:"price,omitempty"`
-	DisplayQty            int       `json:"displayQty,omitempty"`
-	StopPx                float64   `json:"stopPx,omitempty"`
-	PegOffsetValue        float64   `json:"pegOffsetValue,omitempty"`
-	PegPriceType          string    `json:"pegPriceType,omitempty"`
-	Currency              string    `json:"currency,omitempty"`
-	SettlCurrency         string    `json:"settlCurrency,omitempty"`
-	ExecType              string    `json:"execType,omitempty"`
-	OrdType               string    `json:"ordType,omitempty"`
-	TimeInForce           string    `json:"timeInForce,omitempty"`
-	ExecInst              string    `json:"execInst,omitempty"`
-	ContingencyType       string    `json:"contingencyType,omitempty"`
-	ExDestination         string    `json:"exDestination,omitempty"`
-	OrdStatus             string    `json:"ordStatus,omitempty"`
-	Triggered             string    `json:"triggered,omitempty"`
-	WorkingIndicator      bool      `json:"workingIndicator,omitempty"`
-	OrdRejReason          string    `json:"ordRejReason,omitempty"`
-	SimpleLeavesQty       float64   `json:"simpleLeavesQty,omitempty"`
-	LeavesQty             int       `json:"leavesQty,omitempty"`
-	SimpleCumQty          float64   `json:"simpleCumQty,omitempty"`
-	CumQty                int       `json:"cumQty,omitempty"`
-	AvgPx                 float64   `json:"avgPx,omitempty"`
-	Commission            float64   `json:"commission,omitempty"`
-	TradePublishIndicator string    `json:"tradePublishIndicator,omitempty"`
-	MultiLegReportingType string    `json:"multiLegReportingType,omitempty"`
-	Text                  string    `json:"text,omitempty"`
-	TrdMatchID            string    `json:"trdMatchID,omitempty"`
-	ExecCost              int       `json:"execCost,omitempty"`
-	ExecComm              int       `json:"execComm,omitempty"`
-	HomeNotional          float64   `json:"homeNotional,omitempty"`
-	ForeignNotional       float64   `json:"foreignNotional,omitempty"`
-	TransactTime          time.Time `json:"transactTime,omitempty"`
-	Timestamp             time.Time `json:"timestamp,omitempty"`
+	ExecID                string          `json:"execID"`
+	OrderID               string          `json:"orderID,omitempty"`
+	ClOrdID               string          `json:"clOrdID,omitempty"`
+	ClOrdLinkID           string          `json:"clOrdLinkID,omitempty"`
+	Account               int             `json:"account,omitempty"`
+	Symbol                string          `json:"symbol,omitempty"`
+	Side                  Side            `json:"side,omitempty"`
+	LastQty               int             `json:"lastQty,omitempty"`
+	LastPx                Decimal         `json:"lastPx"`
+	UnderlyingLastPx      Decimal         `json:"underlyingLastPx"`
+	LastMkt               string          `json:"lastMkt,omitempty"`
+	LastLiquidityInd      string          `json:"lastLiquidityInd,omitempty"`
+	SimpleOrderQty        Decimal         `json:"simpleOrderQty"`
+	OrderQty              int             `json:"orderQty,omitempty"`
+	Price                 Decimal         `json:"price"`
+	DisplayQty            int             `json:"displayQty,omitempty"`
+	StopPx                Decimal         `json:"stopPx"`
+	PegOffsetValue        Decimal         `json:"pegOffsetValue"`
+	PegPriceType          PegPriceType    `json:"pegPriceType,omitempty"`
+	Currency              string          `json:"currency,omitempty"`
+	SettlCurrency         string          `json:"settlCurrency,omitempty"`
+	ExecType              ExecType        `json:"execType,omitempty"`
+	OrdType               OrdType         `json:"ordType,omitempty"`
+	TimeInForce           TimeInForce     `json:"timeInForce,omitempty"`
+	ExecInst              ExecInst        `json:"execInst,omitempty"`
+	ContingencyType       ContingencyType `json:"contingencyType,omitempty"`
+	ExDestination         string          `json:"exDestination,omitempty"`
+	OrdStatus             OrdStatus       `json:"ordStatus,omitempty"`
+	Triggered             string          `json:"triggered,omitempty"`
+	WorkingIndicator      bool            `json:"workingIndicator,omitempty"`
+	OrdRejReason          string          `json:"ordRejReason,omitempty"`
+	SimpleLeavesQty       Decimal         `json:"simpleLeavesQty"`
+	LeavesQty             int             `json:"leavesQty,omitempty"`
+	SimpleCumQty          Decimal         `json:"simpleCumQty"`
+	CumQty                int             `json:"cumQty,omitempty"`
+	AvgPx                 Decimal         `json:"avgPx"`
+	Commission            float64         `json:"commission,omitempty"`
+	TradePublishIndicator string          `json:"tradePublishIndicator,omitempty"`
+	MultiLegReportingType string          `json:"multiLegReportingType,omitempty"`
+	Text                  string          `json:"text,omitempty"`
+	TrdMatchID            string          `json:"trdMatchID,omitempty"`
+	ExecCost              Amount          `json:"execCost"`
+	ExecComm              Amount          `json:"execComm"`
+	HomeNotional          Decimal         `json:"homeNotional"`
+	ForeignNotional       Decimal         `json:"foreignNotional"`
+	TransactTime          time.Time       `json:"transactTime,omitempty"`
+	Timestamp             time.Time       `json:"timestamp,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, giving the amounts of o the currency of o.
+func (o *Execution) UnmarshalJSON(b []byte) error {
+	type plain Execution
+	if err := json.Unmarshal(b, (*plain)(o)); err != nil {
+		return err
+	}
+	setCurrency(o.SettlCurrency, &o.ExecCost, &o.ExecComm)
+	return nil
+}
+
+// ExecutionFilterBuilder builds a Filter on the fields of Execution.
+type ExecutionFilterBuilder struct {
+	*Filter
+}
+
+// ExecutionFilter starts a Filter on the fields of Execution.
+func ExecutionFilter() *ExecutionFilterBuilder {
+	return &ExecutionFilterBuilder{NewFilter[Execution]()}
+}
+
+// ExecID matches execID against any of values.
+func (f *ExecutionFilterBuilder) ExecID(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "execID", values)
+	return f
+}
+
+// OrderID matches orderID against any of values.
+func (f *ExecutionFilterBuilder) OrderID(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "orderID", values)
+	return f
+}
+
+// ClOrdID matches clOrdID against any of values.
+func (f *ExecutionFilterBuilder) ClOrdID(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "clOrdID", values)
+	return f
+}
+
+// ClOrdLinkID matches clOrdLinkID against any of values.
+func (f *ExecutionFilterBuilder) ClOrdLinkID(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "clOrdLinkID", values)
+	return f
+}
+
+// Account matches account against any of values.
+func (f *ExecutionFilterBuilder) Account(values ...int) *ExecutionFilterBuilder {
+	setField(f.Filter, "account", values)
+	return f
+}
+
+// Symbol matches symbol against any of values.
+func (f *ExecutionFilterBuilder) Symbol(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "symbol", values)
+	return f
+}
+
+// Side matches side against any of values.
+func (f *ExecutionFilterBuilder) Side(values ...Side) *ExecutionFilterBuilder {
+	setField(f.Filter, "side", values)
+	return f
+}
+
+// LastQty matches lastQty against any of values.
+func (f *ExecutionFilterBuilder) LastQty(values ...int) *ExecutionFilterBuilder {
+	setField(f.Filter, "lastQty", values)
+	return f
+}
+
+// LastPx matches lastPx against any of values.
+func (f *ExecutionFilterBuilder) LastPx(values ...Decimal) *ExecutionFilterBuilder {
+	setField(f.Filter, "lastPx", values)
+	return f
+}
+
+// UnderlyingLastPx matches underlyingLastPx against any of values.
+func (f *ExecutionFilterBuilder) UnderlyingLastPx(values ...Decimal) *ExecutionFilterBuilder {
+	setField(f.Filter, "underlyingLastPx", values)
+	return f
+}
+
+// LastMkt matches lastMkt against any of values.
+func (f *ExecutionFilterBuilder) LastMkt(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "lastMkt", values)
+	return f
+}
+
+// LastLiquidityInd matches lastLiquidityInd against any of values.
+func (f *ExecutionFilterBuilder) LastLiquidityInd(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "lastLiquidityInd", values)
+	return f
+}
+
+// SimpleOrderQty matches simpleOrderQty against any of values.
+func (f *ExecutionFilterBuilder) SimpleOrderQty(values ...Decimal) *ExecutionFilterBuilder {
+	setField(f.Filter, "simpleOrderQty", values)
+	return f
+}
+
+// OrderQty matches orderQty against any of values.
+func (f *ExecutionFilterBuilder) OrderQty(values ...int) *ExecutionFilterBuilder {
+	setField(f.Filter, "orderQty", values)
+	return f
+}
+
+// Price matches price against any of values.
+func (f *ExecutionFilterBuilder) Price(values ...Decimal) *ExecutionFilterBuilder {
+	setField(f.Filter, "price", values)
+	return f
+}
+
+// DisplayQty matches displayQty against any of values.
+func (f *ExecutionFilterBuilder) DisplayQty(values ...int) *ExecutionFilterBuilder {
+	setField(f.Filter, "displayQty", values)
+	return f
+}
+
+// StopPx matches stopPx against any of values.
+func (f *ExecutionFilterBuilder) StopPx(values ...Decimal) *ExecutionFilterBuilder {
+	setField(f.Filter, "stopPx", values)
+	return f
+}
+
+// PegOffsetValue matches pegOffsetValue against any of values.
+func (f *ExecutionFilterBuilder) PegOffsetValue(values ...Decimal) *ExecutionFilterBuilder {
+	setField(f.Filter, "pegOffsetValue", values)
+	return f
+}
+
+// PegPriceType matches pegPriceType against any of values.
+func (f *ExecutionFilterBuilder) PegPriceType(values ...PegPriceType) *ExecutionFilterBuilder {
+	setField(f.Filter, "pegPriceType", values)
+	return f
+}
+
+// Currency matches currency against any of values.
+func (f *ExecutionFilterBuilder) Currency(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "currency", values)
+	return f
+}
+
+// SettlCurrency matches settlCurrency against any of values.
+func (f *ExecutionFilterBuilder) SettlCurrency(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "settlCurrency", values)
+	return f
+}
+
+// ExecType matches execType against any of values.
+func (f *ExecutionFilterBuilder) ExecType(values ...ExecType) *ExecutionFilterBuilder {
+	setField(f.Filter, "execType", values)
+	return f
+}
+
+// OrdType matches ordType against any of values.
+func (f *ExecutionFilterBuilder) OrdType(values ...OrdType) *ExecutionFilterBuilder {
+	setField(f.Filter, "ordType", values)
+	return f
+}
+
+// TimeInForce matches timeInForce against any of values.
+func (f *ExecutionFilterBuilder) TimeInForce(values ...TimeInForce) *ExecutionFilterBuilder {
+	setField(f.Filter, "timeInForce", values)
+	return f
+}
+
+// ExecInst matches execInst against any of values.
+func (f *ExecutionFilterBuilder) ExecInst(values ...ExecInst) *ExecutionFilterBuilder {
+	setField(f.Filter, "execInst", values)
+	return f
+}
+
+// ContingencyType matches contingencyType against any of values.
+func (f *ExecutionFilterBuilder) ContingencyType(values ...ContingencyType) *ExecutionFilterBuilder {
+	setField(f.Filter, "contingencyType", values)
+	return f
+}
+
+// ExDestination matches exDestination against any of values.
+func (f *ExecutionFilterBuilder) ExDestination(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "exDestination", values)
+	return f
+}
+
+// OrdStatus matches ordStatus against any of values.
+func (f *ExecutionFilterBuilder) OrdStatus(values ...OrdStatus) *ExecutionFilterBuilder {
+	setField(f.Filter, "ordStatus", values)
+	return f
+}
+
+// Triggered matches triggered against any of values.
+func (f *ExecutionFilterBuilder) Triggered(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "triggered", values)
+	return f
+}
+
+// WorkingIndicator matches workingIndicator against any of values.
+func (f *ExecutionFilterBuilder) WorkingIndicator(values ...bool) *ExecutionFilterBuilder {
+	setField(f.Filter, "workingIndicator", values)
+	return f
+}
+
+// OrdRejReason matches ordRejReason against any of values.
+func (f *ExecutionFilterBuilder) OrdRejReason(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "ordRejReason", values)
+	return f
+}
+
+// SimpleLeavesQty matches simpleLeavesQty against any of values.
+func (f *ExecutionFilterBuilder) SimpleLeavesQty(values ...Decimal) *ExecutionFilterBuilder {
+	setField(f.Filter, "simpleLeavesQty", values)
+	return f
+}
+
+// LeavesQty matches leavesQty against any of values.
+func (f *ExecutionFilterBuilder) LeavesQty(values ...int) *ExecutionFilterBuilder {
+	setField(f.Filter, "leavesQty", values)
+	return f
+}
+
+// SimpleCumQty matches simpleCumQty against any of values.
+func (f *ExecutionFilterBuilder) SimpleCumQty(values ...Decimal) *ExecutionFilterBuilder {
+	setField(f.Filter, "simpleCumQty", values)
+	return f
+}
+
+// CumQty matches cumQty against any of values.
+func (f *ExecutionFilterBuilder) CumQty(values ...int) *ExecutionFilterBuilder {
+	setField(f.Filter, "cumQty", values)
+	return f
+}
+
+// AvgPx matches avgPx against any of values.
+func (f *ExecutionFilterBuilder) AvgPx(values ...Decimal) *ExecutionFilterBuilder {
+	setField(f.Filter, "avgPx", values)
+	return f
+}
+
+// Commission matches commission against any of values.
+func (f *ExecutionFilterBuilder) Commission(values ...float64) *ExecutionFilterBuilder {
+	setField(f.Filter, "commission", values)
+	return f
+}
+
+// TradePublishIndicator matches tradePublishIndicator against any of values.
+func (f *ExecutionFilterBuilder) TradePublishIndicator(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "tradePublishIndicator", values)
+	return f
+}
+
+// MultiLegReportingType matches multiLegReportingType against any of values.
+func (f *ExecutionFilterBuilder) MultiLegReportingType(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "multiLegReportingType", values)
+	return f
+}
+
+// Text matches text against any of values.
+func (f *ExecutionFilterBuilder) Text(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "text", values)
+	return f
+}
+
+// TrdMatchID matches trdMatchID against any of values.
+func (f *ExecutionFilterBuilder) TrdMatchID(values ...string) *ExecutionFilterBuilder {
+	setField(f.Filter, "trdMatchID", values)
+	return f
+}
+
+// HomeNotional matches homeNotional against any of values.
+func (f *ExecutionFilterBuilder) HomeNotional(values ...Decimal) *ExecutionFilterBuilder {
+	setField(f.Filter, "homeNotional", values)
+	return f
+}
+
+// ForeignNotional matches foreignNotional against any of values.
+func (f *ExecutionFilterBuilder) ForeignNotional(values ...Decimal) *ExecutionFilterBuilder {
+	setField(f.Filter, "foreignNotional", values)
+	return f
+}
+
+// TransactTimeAt matches a part of transactTime, e.g. TransactTimeAt(TimeOfDay, "12:00").
+func (f *ExecutionFilterBuilder) TransactTimeAt(part TimePart, value interface{}) *ExecutionFilterBuilder {
+	f.Filter.At("transactTime", part, value)
+	return f
+}
+
+// TransactTime matches transactTime against any of values.
+func (f *ExecutionFilterBuilder) TransactTime(values ...time.Time) *ExecutionFilterBuilder {
+	setField(f.Filter, "transactTime", values)
+	return f
+}
+
+// TimestampAt matches a part of timestamp, e.g. TimestampAt(TimeOfDay, "12:00").
+func (f *ExecutionFilterBuilder) TimestampAt(part TimePart, value interface{}) *ExecutionFilterBuilder {
+	f.Filter.At("timestamp", part, value)
+	return f
+}
+
+// Timestamp matches timestamp against any of values.
+func (f *ExecutionFilterBuilder) Timestamp(values ...time.Time) *ExecutionFilterBuilder {
+	setField(f.Filter, "timestamp", values)
+	return f
 }
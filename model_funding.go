@@ -1,3 +1,5 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
@@ -12,3 +14,55 @@ type Funding struct {
 	FundingRate      float64   `json:"fundingRate,omitempty"`
 	FundingRateDaily float64   `json:"fundingRateDaily,omitempty"`
 }
+
+// FundingFilterBuilder builds a Filter on the fields of Funding.
+type FundingFilterBuilder struct {
+	*Filter
+}
+
+// FundingFilter starts a Filter on the fields of Funding.
+func FundingFilter() *FundingFilterBuilder {
+	return &FundingFilterBuilder{NewFilter[Funding]()}
+}
+
+// TimestampAt matches a part of timestamp, e.g. TimestampAt(TimeOfDay, "12:00").
+func (f *FundingFilterBuilder) TimestampAt(part TimePart, value interface{}) *FundingFilterBuilder {
+	f.Filter.At("timestamp", part, value)
+	return f
+}
+
+// Timestamp matches timestamp against any of values.
+func (f *FundingFilterBuilder) Timestamp(values ...time.Time) *FundingFilterBuilder {
+	setField(f.Filter, "timestamp", values)
+	return f
+}
+
+// Symbol matches symbol against any of values.
+func (f *FundingFilterBuilder) Symbol(values ...string) *FundingFilterBuilder {
+	setField(f.Filter, "symbol", values)
+	return f
+}
+
+// FundingIntervalAt matches a part of fundingInterval, e.g. FundingIntervalAt(TimeOfDay, "12:00").
+func (f *FundingFilterBuilder) FundingIntervalAt(part TimePart, value interface{}) *FundingFilterBuilder {
+	f.Filter.At("fundingInterval", part, value)
+	return f
+}
+
+// FundingInterval matches fundingInterval against any of values.
+func (f *FundingFilterBuilder) FundingInterval(values ...time.Time) *FundingFilterBuilder {
+	setField(f.Filter, "fundingInterval", values)
+	return f
+}
+
+// FundingRate matches fundingRate against any of values.
+func (f *FundingFilterBuilder) FundingRate(values ...float64) *FundingFilterBuilder {
+	setField(f.Filter, "fundingRate", values)
+	return f
+}
+
+// FundingRateDaily matches fundingRateDaily against any of values.
+func (f *FundingFilterBuilder) FundingRateDaily(values ...float64) *FundingFilterBuilder {
+	setField(f.Filter, "fundingRateDaily", values)
+	return f
+}
@@ -1,3 +1,5 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
@@ -9,7 +11,71 @@ type IndexComposite struct {
 	Symbol      string    `json:"symbol,omitempty"`
 	IndexSymbol string    `json:"indexSymbol,omitempty"`
 	Reference   string    `json:"reference,omitempty"`
-	LastPrice   float64   `json:"lastPrice,omitempty"`
+	LastPrice   Decimal   `json:"lastPrice"`
 	Weight      float64   `json:"weight,omitempty"`
 	Logged      time.Time `json:"logged,omitempty"`
 }
+
+// IndexCompositeFilterBuilder builds a Filter on the fields of IndexComposite.
+type IndexCompositeFilterBuilder struct {
+	*Filter
+}
+
+// IndexCompositeFilter starts a Filter on the fields of IndexComposite.
+func IndexCompositeFilter() *IndexCompositeFilterBuilder {
+	return &IndexCompositeFilterBuilder{NewFilter[IndexComposite]()}
+}
+
+// TimestampAt matches a part of timestamp, e.g. TimestampAt(TimeOfDay, "12:00").
+func (f *IndexCompositeFilterBuilder) TimestampAt(part TimePart, value interface{}) *IndexCompositeFilterBuilder {
+	f.Filter.At("timestamp", part, value)
+	return f
+}
+
+// Timestamp matches timestamp against any of values.
+func (f *IndexCompositeFilterBuilder) Timestamp(values ...time.Time) *IndexCompositeFilterBuilder {
+	setField(f.Filter, "timestamp", values)
+	return f
+}
+
+// Symbol matches symbol against any of values.
+func (f *IndexCompositeFilterBuilder) Symbol(values ...string) *IndexCompositeFilterBuilder {
+	setField(f.Filter, "symbol", values)
+	return f
+}
+
+// IndexSymbol matches indexSymbol against any of values.
+func (f *IndexCompositeFilterBuilder) IndexSymbol(values ...string) *IndexCompositeFilterBuilder {
+	setField(f.Filter, "indexSymbol", values)
+	return f
+}
+
+// Reference matches reference against any of values.
+func (f *IndexCompositeFilterBuilder) Reference(values ...string) *IndexCompositeFilterBuilder {
+	setField(f.Filter, "reference", values)
+	return f
+}
+
+// LastPrice matches lastPrice against any of values.
+func (f *IndexCompositeFilterBuilder) LastPrice(values ...Decimal) *IndexCompositeFilterBuilder {
+	setField(f.Filter, "lastPrice", values)
+	return f
+}
+
+// Weight matches weight against any of values.
+func (f *IndexCompositeFilterBuilder) Weight(values ...float64) *IndexCompositeFilterBuilder {
+	setField(f.Filter, "weight", values)
+	return f
+}
+
+// LoggedAt matches a part of logged, e.g. LoggedAt(TimeOfDay, "12:00").
+func (f *IndexCompositeFilterBuilder) LoggedAt(part TimePart, value interface{}) *IndexCompositeFilterBuilder {
+	f.Filter.At("logged", part, value)
+	return f
+}
+
+// Logged matches logged against any of values.
+func (f *IndexCompositeFilterBuilder) Logged(values ...time.Time) *IndexCompositeFilterBuilder {
+	setField(f.Filter, "logged", values)
+	return f
+}
@@ -1,110 +1,814 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
+	"encoding/json"
 	"time"
 )
 
 // Tradeable Contracts, Indices, and History
 type Instrument struct {
-	Symbol                         string    `json:"symbol"`
-	RootSymbol                     string    `json:"rootSymbol,omitempty"`
-	State                          string    `json:"state,omitempty"`
-	Typ                            string    `json:"typ,omitempty"`
-	Listing                        time.Time `json:"listing,omitempty"`
-	Front                          time.Time `json:"front,omitempty"`
-	Expiry                         time.Time `json:"expiry,omitempty"`
-	Settle                         time.Time `json:"settle,omitempty"`
-	RelistInterval                 time.Time `json:"relistInterval,omitempty"`
-	InverseLeg                     string    `json:"inverseLeg,omitempty"`
-	SellLeg                        string    `json:"sellLeg,omitempty"`
-	BuyLeg                         string    `json:"buyLeg,omitempty"`
-	OptionStrikePcnt               float64   `json:"optionStrikePcnt,omitempty"`
-	OptionStrikeRound              float64   `json:"optionStrikeRound,omitempty"`
-	OptionStrikePrice              float64   `json:"optionStrikePrice,omitempty"`
-	OptionMultiplier               float64   `json:"optionMultiplier,omitempty"`
-	PositionCurrency               string    `json:"positionCurrency,omitempty"`
-	Underlying                     string    `json:"underlying,omitempty"`
-	QuoteCurrency                  string    `json:"quoteCurrency,omitempty"`
-	UnderlyingSymbol               string    `json:"underlyingSymbol,omitempty"`
-	Reference                      string    `json:"reference,omitempty"`
-	ReferenceSymbol                string    `json:"referenceSymbol,omitempty"`
-	CalcInterval                   time.Time `json:"calcInterval,omitempty"`
-	PublishInterval                time.Time `json:"publishInterval,omitempty"`
-	PublishTime                    time.Time `json:"publishTime,omitempty"`
-	MaxOrderQty                    int       `json:"maxOrderQty,omitempty"`
-	MaxPrice                       float64   `json:"maxPrice,omitempty"`
-	LotSize                        int       `json:"lotSize,omitempty"`
-	TickSize                       float64   `json:"tickSize,omitempty"`
-	Multiplier                     int       `json:"multiplier,omitempty"`
-	SettlCurrency                  string    `json:"settlCurrency,omitempty"`
-	UnderlyingToPositionMultiplier int       `json:"underlyingToPositionMultiplier,omitempty"`
-	UnderlyingToSettleMultiplier   int       `json:"underlyingToSettleMultiplier,omitempty"`
-	QuoteToSettleMultiplier        int       `json:"quoteToSettleMultiplier,omitempty"`
-	IsQuanto                       bool      `json:"isQuanto,omitempty"`
-	IsInverse                      bool      `json:"isInverse,omitempty"`
-	InitMargin                     float64   `json:"initMargin,omitempty"`
-	MaintMargin                    float64   `json:"maintMargin,omitempty"`
-	RiskLimit                      int       `json:"riskLimit,omitempty"`
-	RiskStep                       int       `json:"riskStep,omitempty"`
-	Limit                          float64   `json:"limit,omitempty"`
-	Capped                         bool      `json:"capped,omitempty"`
-	Taxed                          bool      `json:"taxed,omitempty"`
-	Deleverage                     bool      `json:"deleverage,omitempty"`
-	MakerFee                       float64   `json:"makerFee,omitempty"`
-	TakerFee                       float64   `json:"takerFee,omitempty"`
-	SettlementFee                  float64   `json:"settlementFee,omitempty"`
-	InsuranceFee                   float64   `json:"insuranceFee,omitempty"`
-	FundingBaseSymbol              string    `json:"fundingBaseSymbol,omitempty"`
-	FundingQuoteSymbol             string    `json:"fundingQuoteSymbol,omitempty"`
-	FundingPremiumSymbol           string    `json:"fundingPremiumSymbol,omitempty"`
-	FundingTimestamp               time.Time `json:"fundingTimestamp,omitempty"`
-	FundingInterval                time.Time `json:"fundingInterval,omitempty"`
-	FundingRate                    float64   `json:"fundingRate,omitempty"`
-	IndicativeFundingRate          float64   `json:"indicativeFundingRate,omitempty"`
-	RebalanceTimestamp             time.Time `json:"rebalanceTimestamp,omitempty"`
-	RebalanceInterval              time.Time `json:"rebalanceInterval,omitempty"`
-	OpeningTimestamp               time.Time `json:"openingTimestamp,omitempty"`
-	ClosingTimestamp               time.Time `json:"closingTimestamp,omitempty"`
-	SessionInterval                time.Time `json:"sessionInterval,omitempty"`
-	PrevClosePrice                 float64   `json:"prevClosePrice,omitempty"`
-	LimitDownPrice                 float64   `json:"limitDownPrice,omitempty"`
-	LimitUpPrice                   float64   `json:"limitUpPrice,omitempty"`
-	BankruptLimitDownPrice         float64   `json:"bankruptLimitDownPrice,omitempty"`
-	BankruptLimitUpPrice           float64   `json:"bankruptLimitUpPrice,omitempty"`
-	PrevTotalVolume                int       `json:"prevTotalVolume,omitempty"`
-	TotalVolume                    int       `json:"totalVolume,omitempty"`
-	Volume                         int       `json:"volume,omitempty"`
-	Volume24h                      int       `json:"volume24h,omitempty"`
-	PrevTotalTurnover              int       `json:"prevTotalTurnover,omitempty"`
-	TotalTurnover                  int       `json:"totalTurnover,omitempty"`
-	Turnover                       int       `json:"turnover,omitempty"`
-	Turnover24h                    int       `json:"turnover24h,omitempty"`
-	PrevPrice24h                   float64   `json:"prevPrice24h,omitempty"`
-	Vwap                           float64   `json:"vwap,omitempty"`
-	HighPrice                      float64   `json:"highPrice,omitempty"`
-	LowPrice                       float64   `json:"lowPrice,omitempty"`
-	LastPrice                      float64   `json:"lastPrice,omitempty"`
-	LastPriceProtected             float64   `json:"lastPriceProtected,omitempty"`
-	LastTickDirection              string    `json:"lastTickDirection,omitempty"`
-	LastChangePcnt                 float64   `json:"lastChangePcnt,omitempty"`
-	BidPrice                       float64   `json:"bidPrice,omitempty"`
-	MidPrice                       float64   `json:"midPrice,omitempty"`
-	AskPrice                       float64   `json:"askPrice,omitempty"`
-	ImpactBidPrice                 float64   `json:"impactBidPrice,omitempty"`
-	ImpactMidPrice                 float64   `json:"impactMidPrice,omitempty"`
-	ImpactAskPrice                 float64   `json:"impactAskPrice,omitempty"`
-	HasLiquidity                   bool      `json:"hasLiquidity,omitempty"`
-	OpenInterest                   int       `json:"openInterest,omitempty"`
-	OpenValue                      int       `json:"openValue,omitempty"`
-	FairMethod                     string    `json:"fairMethod,omitempty"`
-	FairBasisRate                  float64   `json:"fairBasisRate,omitempty"`
-	FairBasis                      float64   `json:"fairBasis,omitempty"`
-	FairPrice                      float64   `json:"fairPrice,omitempty"`
-	MarkMethod                     string    `json:"markMethod,omitempty"`
-	MarkPrice                      float64   `json:"markPrice,omitempty"`
-	IndicativeTaxRate              float64   `json:"indicativeTaxRate,omitempty"`
-	IndicativeSettlePrice          float64   `json:"indicativeSettlePrice,omitempty"`
-	OptionUnderlyingPrice          float64   `json:"optionUnderlyingPrice,omitempty"`
-	SettledPrice                   float64   `json:"settledPrice,omitempty"`
-	Timestamp                      time.Time `json:"timestamp,omitempty"`
+	Symbol                         string          `json:"symbol"`
+	RootSymbol                     string          `json:"rootSymbol,omitempty"`
+	State                          InstrumentState `json:"state,omitempty"`
+	Typ                            string          `json:"typ,omitempty"`
+	Listing                        time.Time       `json:"listing,omitempty"`
+	Front                          time.Time       `json:"front,omitempty"`
+	Expiry                         time.Time       `json:"expiry,omitempty"`
+	Settle                         time.Time       `json:"settle,omitempty"`
+	RelistInterval                 time.Time       `json:"relistInterval,omitempty"`
+	InverseLeg                     string          `json:"inverseLeg,omitempty"`
+	SellLeg                        string          `json:"sellLeg,omitempty"`
+	BuyLeg                         string          `json:"buyLeg,omitempty"`
+	OptionStrikePcnt               float64         `json:"optionStrikePcnt,omitempty"`
+	OptionStrikeRound              float64         `json:"optionStrikeRound,omitempty"`
+	OptionStrikePrice              Decimal         `json:"optionStrikePrice"`
+	OptionMultiplier               float64         `json:"optionMultiplier,omitempty"`
+	PositionCurrency               string          `json:"positionCurrency,omitempty"`
+	Underlying                     string          `json:"underlying,omitempty"`
+	QuoteCurrency                  string          `json:"quoteCurrency,omitempty"`
+	UnderlyingSymbol               string          `json:"underlyingSymbol,omitempty"`
+	Reference                      string          `json:"reference,omitempty"`
+	ReferenceSymbol                string          `json:"referenceSymbol,omitempty"`
+	CalcInterval                   time.Time       `json:"calcInterval,omitempty"`
+	PublishInterval                time.Time       `json:"publishInterval,omitempty"`
+	PublishTime                    time.Time       `json:"publishTime,omitempty"`
+	MaxOrderQty                    int             `json:"maxOrderQty,omitempty"`
+	MaxPrice                       Decimal         `json:"maxPrice"`
+	LotSize                        int             `json:"lotSize,omitempty"`
+	TickSize                       Decimal         `json:"tickSize"`
+	Multiplier                     int             `json:"multiplier,omitempty"`
+	SettlCurrency                  string          `json:"settlCurrency,omitempty"`
+	UnderlyingToPositionMultiplier int             `json:"underlyingToPositionMultiplier,omitempty"`
+	UnderlyingToSettleMultiplier   int             `json:"underlyingToSettleMultiplier,omitempty"`
+	QuoteToSettleMultiplier        int             `json:"quoteToSettleMultiplier,omitempty"`
+	IsQuanto                       bool            `json:"isQuanto,omitempty"`
+	IsInverse                      bool            `json:"isInverse,omitempty"`
+	InitMargin                     float64         `json:"initMargin,omitempty"`
+	MaintMargin                    float64         `json:"maintMargin,omitempty"`
+	RiskLimit                      int             `json:"riskLimit,omitempty"`
+	RiskStep                       int             `json:"riskStep,omitempty"`
+	Limit                          float64         `json:"limit,omitempty"`
+	Capped                         bool            `json:"capped,omitempty"`
+	Taxed                          bool            `json:"taxed,omitempty"`
+	Deleverage                     bool            `json:"deleverage,omitempty"`
+	MakerFee                       float64         `json:"makerFee,omitempty"`
+	TakerFee                       float64         `json:"takerFee,omitempty"`
+	SettlementFee                  float64         `json:"settlementFee,omitempty"`
+	InsuranceFee                   float64         `json:"insuranceFee,omitempty"`
+	FundingBaseSymbol              string          `json:"fundingBaseSymbol,omitempty"`
+	FundingQuoteSymbol             string          `json:"fundingQuoteSymbol,omitempty"`
+	FundingPremiumSymbol           string          `json:"fundingPremiumSymbol,omitempty"`
+	FundingTimestamp               time.Time       `json:"fundingTimestamp,omitempty"`
+	FundingInterval                time.Time       `json:"fundingInterval,omitempty"`
+	FundingRate                    float64         `json:"fundingRate,omitempty"`
+	IndicativeFundingRate          float64         `json:"indicativeFundingRate,omitempty"`
+	RebalanceTimestamp             time.Time       `json:"rebalanceTimestamp,omitempty"`
+	RebalanceInterval              time.Time       `json:"rebalanceInterval,omitempty"`
+	OpeningTimestamp               time.Time       `json:"openingTimestamp,omitempty"`
+	ClosingTimestamp               time.Time       `json:"closingTimestamp,omitempty"`
+	SessionInterval                time.Time       `json:"sessionInterval,omitempty"`
+	PrevClosePrice                 Decimal         `json:"prevClosePrice"`
+	LimitDownPrice                 Decimal         `json:"limitDownPrice"`
+	LimitUpPrice                   Decimal         `json:"limitUpPrice"`
+	BankruptLimitDownPrice         Decimal         `json:"bankruptLimitDownPrice"`
+	BankruptLimitUpPrice           Decimal         `json:"bankruptLimitUpPrice"`
+	PrevTotalVolume                int             `json:"prevTotalVolume,omitempty"`
+	TotalVolume                    int             `json:"totalVolume,omitempty"`
+	Volume                         int             `json:"volume,omitempty"`
+	Volume24h                      int             `json:"volume24h,omitempty"`
+	PrevTotalTurnover              Amount          `json:"prevTotalTurnover"`
+	TotalTurnover                  Amount          `json:"totalTurnover"`
+	Turnover                       Amount          `json:"turnover"`
+	Turnover24h                    Amount          `json:"turnover24h"`
+	PrevPrice24h                   Decimal         `json:"prevPrice24h"`
+	Vwap                           Decimal         `json:"vwap"`
+	HighPrice                      Decimal         `json:"highPrice"`
+	LowPrice                       Decimal         `json:"lowPrice"`
+	LastPrice                      Decimal         `json:"lastPrice"`
+	LastPriceProtected             Decimal         `json:"lastPriceProtected"`
+	LastTickDirection              TickDirection   `json:"lastTickDirection,omitempty"`
+	LastChangePcnt                 float64         `json:"lastChangePcnt,omitempty"`
+	BidPrice                       Decimal         `json:"bidPrice"`
+	MidPrice                       Decimal         `json:"midPrice"`
+	AskPrice                       Decimal         `json:"askPrice"`
+	ImpactBidPrice                 Decimal         `json:"impactBidPrice"`
+	ImpactMidPrice                 Decimal         `json:"impactMidPrice"`
+	ImpactAskPrice                 Decimal         `json:"impactAskPrice"`
+	HasLiquidity                   bool            `json:"hasLiquidity,omitempty"`
+	OpenInterest                   int             `json:"openInterest,omitempty"`
+	OpenValue                      Amount          `json:"openValue"`
+	FairMethod                     string          `json:"fairMethod,omitempty"`
+	FairBasisRate                  float64         `json:"fairBasisRate,omitempty"`
+	FairBasis                      float64         `json:"fairBasis,omitempty"`
+	FairPrice                      Decimal         `json:"fairPrice"`
+	MarkMethod                     string          `json:"markMethod,omitempty"`
+	MarkPrice                      Decimal         `json:"markPrice"`
+	IndicativeTaxRate              float64         `json:"indicativeTaxRate,omitempty"`
+	IndicativeSettlePrice          Decimal         `json:"indicativeSettlePrice"`
+	OptionUnderlyingPrice          Decimal         `json:"optionUnderlyingPrice"`
+	SettledPrice                   Decimal         `json:"settledPrice"`
+	Timestamp                      time.Time       `json:"timestamp,omitempty"`
+}
+
+// InstrumentPatch is a partial Instrument, as sent by realtime updates.
+type InstrumentPatch = Patch[Instrument]
+
+// Merge sets the fields of o present in p to their values in p.
+func (o *Instrument) Merge(p InstrumentPatch) {
+	p.Apply(o)
+	setCurrency(o.SettlCurrency, &o.PrevTotalTurnover, &o.TotalTurnover, &o.Turnover, &o.Turnover24h, &o.OpenValue)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, giving the amounts of o the currency of o.
+func (o *Instrument) UnmarshalJSON(b []byte) error {
+	type plain Instrument
+	if err := json.Unmarshal(b, (*plain)(o)); err != nil {
+		return err
+	}
+	setCurrency(o.SettlCurrency, &o.PrevTotalTurnover, &o.TotalTurnover, &o.Turnover, &o.Turnover24h, &o.OpenValue)
+	return nil
+}
+
+// InstrumentFilterBuilder builds a Filter on the fields of Instrument.
+type InstrumentFilterBuilder struct {
+	*Filter
+}
+
+// InstrumentFilter starts a Filter on the fields of Instrument.
+func InstrumentFilter() *InstrumentFilterBuilder {
+	return &InstrumentFilterBuilder{NewFilter[Instrument]()}
+}
+
+// Symbol matches symbol against any of values.
+func (f *InstrumentFilterBuilder) Symbol(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "symbol", values)
+	return f
+}
+
+// RootSymbol matches rootSymbol against any of values.
+func (f *InstrumentFilterBuilder) RootSymbol(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "rootSymbol", values)
+	return f
+}
+
+// State matches state against any of values.
+func (f *InstrumentFilterBuilder) State(values ...InstrumentState) *InstrumentFilterBuilder {
+	setField(f.Filter, "state", values)
+	return f
+}
+
+// Typ matches typ against any of values.
+func (f *InstrumentFilterBuilder) Typ(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "typ", values)
+	return f
+}
+
+// ListingAt matches a part of listing, e.g. ListingAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) ListingAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("listing", part, value)
+	return f
+}
+
+// Listing matches listing against any of values.
+func (f *InstrumentFilterBuilder) Listing(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "listing", values)
+	return f
+}
+
+// FrontAt matches a part of front, e.g. FrontAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) FrontAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("front", part, value)
+	return f
+}
+
+// Front matches front against any of values.
+func (f *InstrumentFilterBuilder) Front(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "front", values)
+	return f
+}
+
+// ExpiryAt matches a part of expiry, e.g. ExpiryAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) ExpiryAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("expiry", part, value)
+	return f
+}
+
+// Expiry matches expiry against any of values.
+func (f *InstrumentFilterBuilder) Expiry(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "expiry", values)
+	return f
+}
+
+// SettleAt matches a part of settle, e.g. SettleAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) SettleAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("settle", part, value)
+	return f
+}
+
+// Settle matches settle against any of values.
+func (f *InstrumentFilterBuilder) Settle(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "settle", values)
+	return f
+}
+
+// RelistIntervalAt matches a part of relistInterval, e.g. RelistIntervalAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) RelistIntervalAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("relistInterval", part, value)
+	return f
+}
+
+// RelistInterval matches relistInterval against any of values.
+func (f *InstrumentFilterBuilder) RelistInterval(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "relistInterval", values)
+	return f
+}
+
+// InverseLeg matches inverseLeg against any of values.
+func (f *InstrumentFilterBuilder) InverseLeg(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "inverseLeg", values)
+	return f
+}
+
+// SellLeg matches sellLeg against any of values.
+func (f *InstrumentFilterBuilder) SellLeg(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "sellLeg", values)
+	return f
+}
+
+// BuyLeg matches buyLeg against any of values.
+func (f *InstrumentFilterBuilder) BuyLeg(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "buyLeg", values)
+	return f
+}
+
+// OptionStrikePcnt matches optionStrikePcnt against any of values.
+func (f *InstrumentFilterBuilder) OptionStrikePcnt(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "optionStrikePcnt", values)
+	return f
+}
+
+// OptionStrikeRound matches optionStrikeRound against any of values.
+func (f *InstrumentFilterBuilder) OptionStrikeRound(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "optionStrikeRound", values)
+	return f
+}
+
+// OptionStrikePrice matches optionStrikePrice against any of values.
+func (f *InstrumentFilterBuilder) OptionStrikePrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "optionStrikePrice", values)
+	return f
+}
+
+// OptionMultiplier matches optionMultiplier against any of values.
+func (f *InstrumentFilterBuilder) OptionMultiplier(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "optionMultiplier", values)
+	return f
+}
+
+// PositionCurrency matches positionCurrency against any of values.
+func (f *InstrumentFilterBuilder) PositionCurrency(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "positionCurrency", values)
+	return f
+}
+
+// Underlying matches underlying against any of values.
+func (f *InstrumentFilterBuilder) Underlying(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "underlying", values)
+	return f
+}
+
+// QuoteCurrency matches quoteCurrency against any of values.
+func (f *InstrumentFilterBuilder) QuoteCurrency(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "quoteCurrency", values)
+	return f
+}
+
+// UnderlyingSymbol matches underlyingSymbol against any of values.
+func (f *InstrumentFilterBuilder) UnderlyingSymbol(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "underlyingSymbol", values)
+	return f
+}
+
+// Reference matches reference against any of values.
+func (f *InstrumentFilterBuilder) Reference(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "reference", values)
+	return f
+}
+
+// ReferenceSymbol matches referenceSymbol against any of values.
+func (f *InstrumentFilterBuilder) ReferenceSymbol(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "referenceSymbol", values)
+	return f
+}
+
+// CalcIntervalAt matches a part of calcInterval, e.g. CalcIntervalAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) CalcIntervalAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("calcInterval", part, value)
+	return f
+}
+
+// CalcInterval matches calcInterval against any of values.
+func (f *InstrumentFilterBuilder) CalcInterval(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "calcInterval", values)
+	return f
+}
+
+// PublishIntervalAt matches a part of publishInterval, e.g. PublishIntervalAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) PublishIntervalAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("publishInterval", part, value)
+	return f
+}
+
+// PublishInterval matches publishInterval against any of values.
+func (f *InstrumentFilterBuilder) PublishInterval(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "publishInterval", values)
+	return f
+}
+
+// PublishTimeAt matches a part of publishTime, e.g. PublishTimeAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) PublishTimeAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("publishTime", part, value)
+	return f
+}
+
+// PublishTime matches publishTime against any of values.
+func (f *InstrumentFilterBuilder) PublishTime(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "publishTime", values)
+	return f
+}
+
+// MaxOrderQty matches maxOrderQty against any of values.
+func (f *InstrumentFilterBuilder) MaxOrderQty(values ...int) *InstrumentFilterBuilder {
+	setField(f.Filter, "maxOrderQty", values)
+	return f
+}
+
+// MaxPrice matches maxPrice against any of values.
+func (f *InstrumentFilterBuilder) MaxPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "maxPrice", values)
+	return f
+}
+
+// LotSize matches lotSize against any of values.
+func (f *InstrumentFilterBuilder) LotSize(values ...int) *InstrumentFilterBuilder {
+	setField(f.Filter, "lotSize", values)
+	return f
+}
+
+// TickSize matches tickSize against any of values.
+func (f *InstrumentFilterBuilder) TickSize(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "tickSize", values)
+	return f
+}
+
+// Multiplier matches multiplier against any of values.
+func (f *InstrumentFilterBuilder) Multiplier(values ...int) *InstrumentFilterBuilder {
+	setField(f.Filter, "multiplier", values)
+	return f
+}
+
+// SettlCurrency matches settlCurrency against any of values.
+func (f *InstrumentFilterBuilder) SettlCurrency(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "settlCurrency", values)
+	return f
+}
+
+// UnderlyingToPositionMultiplier matches underlyingToPositionMultiplier against any of values.
+func (f *InstrumentFilterBuilder) UnderlyingToPositionMultiplier(values ...int) *InstrumentFilterBuilder {
+	setField(f.Filter, "underlyingToPositionMultiplier", values)
+	return f
+}
+
+// UnderlyingToSettleMultiplier matches underlyingToSettleMultiplier against any of values.
+func (f *InstrumentFilterBuilder) UnderlyingToSettleMultiplier(values ...int) *InstrumentFilterBuilder {
+	setField(f.Filter, "underlyingToSettleMultiplier", values)
+	return f
+}
+
+// QuoteToSettleMultiplier matches quoteToSettleMultiplier against any of values.
+func (f *InstrumentFilterBuilder) QuoteToSettleMultiplier(values ...int) *InstrumentFilterBuilder {
+	setField(f.Filter, "quoteToSettleMultiplier", values)
+	return f
+}
+
+// IsQuanto matches isQuanto against any of values.
+func (f *InstrumentFilterBuilder) IsQuanto(values ...bool) *InstrumentFilterBuilder {
+	setField(f.Filter, "isQuanto", values)
+	return f
+}
+
+// IsInverse matches isInverse against any of values.
+func (f *InstrumentFilterBuilder) IsInverse(values ...bool) *InstrumentFilterBuilder {
+	setField(f.Filter, "isInverse", values)
+	return f
+}
+
+// InitMargin matches initMargin against any of values.
+func (f *InstrumentFilterBuilder) InitMargin(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "initMargin", values)
+	return f
+}
+
+// MaintMargin matches maintMargin against any of values.
+func (f *InstrumentFilterBuilder) MaintMargin(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "maintMargin", values)
+	return f
+}
+
+// RiskLimit matches riskLimit against any of values.
+func (f *InstrumentFilterBuilder) RiskLimit(values ...int) *InstrumentFilterBuilder {
+	setField(f.Filter, "riskLimit", values)
+	return f
+}
+
+// RiskStep matches riskStep against any of values.
+func (f *InstrumentFilterBuilder) RiskStep(values ...int) *InstrumentFilterBuilder {
+	setField(f.Filter, "riskStep", values)
+	return f
+}
+
+// Limit matches limit against any of values.
+func (f *InstrumentFilterBuilder) Limit(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "limit", values)
+	return f
+}
+
+// Capped matches capped against any of values.
+func (f *InstrumentFilterBuilder) Capped(values ...bool) *InstrumentFilterBuilder {
+	setField(f.Filter, "capped", values)
+	return f
+}
+
+// Taxed matches taxed against any of values.
+func (f *InstrumentFilterBuilder) Taxed(values ...bool) *InstrumentFilterBuilder {
+	setField(f.Filter, "taxed", values)
+	return f
+}
+
+// Deleverage matches deleverage against any of values.
+func (f *InstrumentFilterBuilder) Deleverage(values ...bool) *InstrumentFilterBuilder {
+	setField(f.Filter, "deleverage", values)
+	return f
+}
+
+// MakerFee matches makerFee against any of values.
+func (f *InstrumentFilterBuilder) MakerFee(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "makerFee", values)
+	return f
+}
+
+// TakerFee matches takerFee against any of values.
+func (f *InstrumentFilterBuilder) TakerFee(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "takerFee", values)
+	return f
+}
+
+// SettlementFee matches settlementFee against any of values.
+func (f *InstrumentFilterBuilder) SettlementFee(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "settlementFee", values)
+	return f
+}
+
+// InsuranceFee matches insuranceFee against any of values.
+func (f *InstrumentFilterBuilder) InsuranceFee(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "insuranceFee", values)
+	return f
+}
+
+// FundingBaseSymbol matches fundingBaseSymbol against any of values.
+func (f *InstrumentFilterBuilder) FundingBaseSymbol(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "fundingBaseSymbol", values)
+	return f
+}
+
+// FundingQuoteSymbol matches fundingQuoteSymbol against any of values.
+func (f *InstrumentFilterBuilder) FundingQuoteSymbol(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "fundingQuoteSymbol", values)
+	return f
+}
+
+// FundingPremiumSymbol matches fundingPremiumSymbol against any of values.
+func (f *InstrumentFilterBuilder) FundingPremiumSymbol(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "fundingPremiumSymbol", values)
+	return f
+}
+
+// FundingTimestampAt matches a part of fundingTimestamp, e.g. FundingTimestampAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) FundingTimestampAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("fundingTimestamp", part, value)
+	return f
+}
+
+// FundingTimestamp matches fundingTimestamp against any of values.
+func (f *InstrumentFilterBuilder) FundingTimestamp(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "fundingTimestamp", values)
+	return f
+}
+
+// FundingIntervalAt matches a part of fundingInterval, e.g. FundingIntervalAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) FundingIntervalAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("fundingInterval", part, value)
+	return f
+}
+
+// FundingInterval matches fundingInterval against any of values.
+func (f *InstrumentFilterBuilder) FundingInterval(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "fundingInterval", values)
+	return f
+}
+
+// FundingRate matches fundingRate against any of values.
+func (f *InstrumentFilterBuilder) FundingRate(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "fundingRate", values)
+	return f
+}
+
+// IndicativeFundingRate matches indicativeFundingRate against any of values.
+func (f *InstrumentFilterBuilder) IndicativeFundingRate(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "indicativeFundingRate", values)
+	return f
+}
+
+// RebalanceTimestampAt matches a part of rebalanceTimestamp, e.g. RebalanceTimestampAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) RebalanceTimestampAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("rebalanceTimestamp", part, value)
+	return f
+}
+
+// RebalanceTimestamp matches rebalanceTimestamp against any of values.
+func (f *InstrumentFilterBuilder) RebalanceTimestamp(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "rebalanceTimestamp", values)
+	return f
+}
+
+// RebalanceIntervalAt matches a part of rebalanceInterval, e.g. RebalanceIntervalAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) RebalanceIntervalAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("rebalanceInterval", part, value)
+	return f
+}
+
+// RebalanceInterval matches rebalanceInterval against any of values.
+func (f *InstrumentFilterBuilder) RebalanceInterval(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "rebalanceInterval", values)
+	return f
+}
+
+// OpeningTimestampAt matches a part of openingTimestamp, e.g. OpeningTimestampAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) OpeningTimestampAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("openingTimestamp", part, value)
+	return f
+}
+
+// OpeningTimestamp matches openingTimestamp against any of values.
+func (f *InstrumentFilterBuilder) OpeningTimestamp(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "openingTimestamp", values)
+	return f
+}
+
+// ClosingTimestampAt matches a part of closingTimestamp, e.g. ClosingTimestampAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) ClosingTimestampAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("closingTimestamp", part, value)
+	return f
+}
+
+// ClosingTimestamp matches closingTimestamp against any of values.
+func (f *InstrumentFilterBuilder) ClosingTimestamp(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "closingTimestamp", values)
+	return f
+}
+
+// SessionIntervalAt matches a part of sessionInterval, e.g. SessionIntervalAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) SessionIntervalAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("sessionInterval", part, value)
+	return f
+}
+
+// SessionInterval matches sessionInterval against any of values.
+func (f *InstrumentFilterBuilder) SessionInterval(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "sessionInterval", values)
+	return f
+}
+
+// PrevClosePrice matches prevClosePrice against any of values.
+func (f *InstrumentFilterBuilder) PrevClosePrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "prevClosePrice", values)
+	return f
+}
+
+// LimitDownPrice matches limitDownPrice against any of values.
+func (f *InstrumentFilterBuilder) LimitDownPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "limitDownPrice", values)
+	return f
+}
+
+// LimitUpPrice matches limitUpPrice against any of values.
+func (f *InstrumentFilterBuilder) LimitUpPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "limitUpPrice", values)
+	return f
+}
+
+// BankruptLimitDownPrice matches bankruptLimitDownPrice against any of values.
+func (f *InstrumentFilterBuilder) BankruptLimitDownPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "bankruptLimitDownPrice", values)
+	return f
+}
+
+// BankruptLimitUpPrice matches bankruptLimitUpPrice against any of values.
+func (f *InstrumentFilterBuilder) BankruptLimitUpPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "bankruptLimitUpPrice", values)
+	return f
+}
+
+// PrevTotalVolume matches prevTotalVolume against any of values.
+func (f *InstrumentFilterBuilder) PrevTotalVolume(values ...int) *InstrumentFilterBuilder {
+	setField(f.Filter, "prevTotalVolume", values)
+	return f
+}
+
+// TotalVolume matches totalVolume against any of values.
+func (f *InstrumentFilterBuilder) TotalVolume(values ...int) *InstrumentFilterBuilder {
+	setField(f.Filter, "totalVolume", values)
+	return f
+}
+
+// Volume matches volume against any of values.
+func (f *InstrumentFilterBuilder) Volume(values ...int) *InstrumentFilterBuilder {
+	setField(f.Filter, "volume", values)
+	return f
+}
+
+// Volume24h matches volume24h against any of values.
+func (f *InstrumentFilterBuilder) Volume24h(values ...int) *InstrumentFilterBuilder {
+	setField(f.Filter, "volume24h", values)
+	return f
+}
+
+// PrevPrice24h matches prevPrice24h against any of values.
+func (f *InstrumentFilterBuilder) PrevPrice24h(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "prevPrice24h", values)
+	return f
+}
+
+// Vwap matches vwap against any of values.
+func (f *InstrumentFilterBuilder) Vwap(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "vwap", values)
+	return f
+}
+
+// HighPrice matches highPrice against any of values.
+func (f *InstrumentFilterBuilder) HighPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "highPrice", values)
+	return f
+}
+
+// LowPrice matches lowPrice against any of values.
+func (f *InstrumentFilterBuilder) LowPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "lowPrice", values)
+	return f
+}
+
+// LastPrice matches lastPrice against any of values.
+func (f *InstrumentFilterBuilder) LastPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "lastPrice", values)
+	return f
+}
+
+// LastPriceProtected matches lastPriceProtected against any of values.
+func (f *InstrumentFilterBuilder) LastPriceProtected(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "lastPriceProtected", values)
+	return f
+}
+
+// LastTickDirection matches lastTickDirection against any of values.
+func (f *InstrumentFilterBuilder) LastTickDirection(values ...TickDirection) *InstrumentFilterBuilder {
+	setField(f.Filter, "lastTickDirection", values)
+	return f
+}
+
+// LastChangePcnt matches lastChangePcnt against any of values.
+func (f *InstrumentFilterBuilder) LastChangePcnt(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "lastChangePcnt", values)
+	return f
+}
+
+// BidPrice matches bidPrice against any of values.
+func (f *InstrumentFilterBuilder) BidPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "bidPrice", values)
+	return f
+}
+
+// MidPrice matches midPrice against any of values.
+func (f *InstrumentFilterBuilder) MidPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "midPrice", values)
+	return f
+}
+
+// AskPrice matches askPrice against any of values.
+func (f *InstrumentFilterBuilder) AskPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "askPrice", values)
+	return f
+}
+
+// ImpactBidPrice matches impactBidPrice against any of values.
+func (f *InstrumentFilterBuilder) ImpactBidPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "impactBidPrice", values)
+	return f
+}
+
+// ImpactMidPrice matches impactMidPrice against any of values.
+func (f *InstrumentFilterBuilder) ImpactMidPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "impactMidPrice", values)
+	return f
+}
+
+// ImpactAskPrice matches impactAskPrice against any of values.
+func (f *InstrumentFilterBuilder) ImpactAskPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "impactAskPrice", values)
+	return f
+}
+
+// HasLiquidity matches hasLiquidity against any of values.
+func (f *InstrumentFilterBuilder) HasLiquidity(values ...bool) *InstrumentFilterBuilder {
+	setField(f.Filter, "hasLiquidity", values)
+	return f
+}
+
+// OpenInterest matches openInterest against any of values.
+func (f *InstrumentFilterBuilder) OpenInterest(values ...int) *InstrumentFilterBuilder {
+	setField(f.Filter, "openInterest", values)
+	return f
+}
+
+// FairMethod matches fairMethod against any of values.
+func (f *InstrumentFilterBuilder) FairMethod(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "fairMethod", values)
+	return f
+}
+
+// FairBasisRate matches fairBasisRate against any of values.
+func (f *InstrumentFilterBuilder) FairBasisRate(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "fairBasisRate", values)
+	return f
+}
+
+// FairBasis matches fairBasis against any of values.
+func (f *InstrumentFilterBuilder) FairBasis(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "fairBasis", values)
+	return f
+}
+
+// FairPrice matches fairPrice against any of values.
+func (f *InstrumentFilterBuilder) FairPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "fairPrice", values)
+	return f
+}
+
+// MarkMethod matches markMethod against any of values.
+func (f *InstrumentFilterBuilder) MarkMethod(values ...string) *InstrumentFilterBuilder {
+	setField(f.Filter, "markMethod", values)
+	return f
+}
+
+// MarkPrice matches markPrice against any of values.
+func (f *InstrumentFilterBuilder) MarkPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "markPrice", values)
+	return f
+}
+
+// IndicativeTaxRate matches indicativeTaxRate against any of values.
+func (f *InstrumentFilterBuilder) IndicativeTaxRate(values ...float64) *InstrumentFilterBuilder {
+	setField(f.Filter, "indicativeTaxRate", values)
+	return f
+}
+
+// IndicativeSettlePrice matches indicativeSettlePrice against any of values.
+func (f *InstrumentFilterBuilder) IndicativeSettlePrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "indicativeSettlePrice", values)
+	return f
+}
+
+// OptionUnderlyingPrice matches optionUnderlyingPrice against any of values.
+func (f *InstrumentFilterBuilder) OptionUnderlyingPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "optionUnderlyingPrice", values)
+	return f
+}
+
+// SettledPrice matches settledPrice against any of values.
+func (f *InstrumentFilterBuilder) SettledPrice(values ...Decimal) *InstrumentFilterBuilder {
+	setField(f.Filter, "settledPrice", values)
+	return f
+}
+
+// TimestampAt matches a part of timestamp, e.g. TimestampAt(TimeOfDay, "12:00").
+func (f *InstrumentFilterBuilder) TimestampAt(part TimePart, value interface{}) *InstrumentFilterBuilder {
+	f.Filter.At("timestamp", part, value)
+	return f
+}
+
+// Timestamp matches timestamp against any of values.
+func (f *InstrumentFilterBuilder) Timestamp(values ...time.Time) *InstrumentFilterBuilder {
+	setField(f.Filter, "timestamp", values)
+	return f
 }
@@ -1,6 +1,9 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -8,5 +11,43 @@ import (
 type Insurance struct {
 	Currency      string    `json:"currency"`
 	Timestamp     time.Time `json:"timestamp"`
-	WalletBalance int       `json:"walletBalance,omitempty"`
+	WalletBalance Amount    `json:"walletBalance"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, giving the amounts of o the currency of o.
+func (o *Insurance) UnmarshalJSON(b []byte) error {
+	type plain Insurance
+	if err := json.Unmarshal(b, (*plain)(o)); err != nil {
+		return err
+	}
+	setCurrency(o.Currency, &o.WalletBalance)
+	return nil
+}
+
+// InsuranceFilterBuilder builds a Filter on the fields of Insurance.
+type InsuranceFilterBuilder struct {
+	*Filter
+}
+
+// InsuranceFilter starts a Filter on the fields of Insurance.
+func InsuranceFilter() *InsuranceFilterBuilder {
+	return &InsuranceFilterBuilder{NewFilter[Insurance]()}
+}
+
+// Currency matches currency against any of values.
+func (f *InsuranceFilterBuilder) Currency(values ...string) *InsuranceFilterBuilder {
+	setField(f.Filter, "currency", values)
+	return f
+}
+
+// TimestampAt matches a part of timestamp, e.g. TimestampAt(TimeOfDay, "12:00").
+func (f *InsuranceFilterBuilder) TimestampAt(part TimePart, value interface{}) *InsuranceFilterBuilder {
+	f.Filter.At("timestamp", part, value)
+	return f
+}
+
+// Timestamp matches timestamp against any of values.
+func (f *InsuranceFilterBuilder) Timestamp(values ...time.Time) *InsuranceFilterBuilder {
+	setField(f.Filter, "timestamp", values)
+	return f
 }
@@ -1,10 +1,60 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 // Active Liquidations
 type Liquidation struct {
 	OrderID   string  `json:"orderID"`
 	Symbol    string  `json:"symbol,omitempty"`
-	Side      string  `json:"side,omitempty"`
-	Price     float64 `json:"price,omitempty"`
+	Side      Side    `json:"side,omitempty"`
+	Price     Decimal `json:"price"`
 	LeavesQty int     `json:"leavesQty,omitempty"`
 }
+
+// LiquidationPatch is a partial Liquidation, as sent by realtime updates.
+type LiquidationPatch = Patch[Liquidation]
+
+// Merge sets the fields of o present in p to their values in p.
+func (o *Liquidation) Merge(p LiquidationPatch) {
+	p.Apply(o)
+}
+
+// LiquidationFilterBuilder builds a Filter on the fields of Liquidation.
+type LiquidationFilterBuilder struct {
+	*Filter
+}
+
+// LiquidationFilter starts a Filter on the fields of Liquidation.
+func LiquidationFilter() *LiquidationFilterBuilder {
+	return &LiquidationFilterBuilder{NewFilter[Liquidation]()}
+}
+
+// OrderID matches orderID against any of values.
+func (f *LiquidationFilterBuilder) OrderID(values ...string) *LiquidationFilterBuilder {
+	setField(f.Filter, "orderID", values)
+	return f
+}
+
+// Symbol matches symbol against any of values.
+func (f *LiquidationFilterBuilder) Symbol(values ...string) *LiquidationFilterBuilder {
+	setField(f.Filter, "symbol", values)
+	return f
+}
+
+// Side matches side against any of values.
+func (f *LiquidationFilterBuilder) Side(values ...Side) *LiquidationFilterBuilder {
+	setField(f.Filter, "side", values)
+	return f
+}
+
+// Price matches price against any of values.
+func (f *LiquidationFilterBuilder) Price(values ...Decimal) *LiquidationFilterBuilder {
+	setField(f.Filter, "price", values)
+	return f
+}
+
+// LeavesQty matches leavesQty against any of values.
+func (f *LiquidationFilterBuilder) LeavesQty(values ...int) *LiquidationFilterBuilder {
+	setField(f.Filter, "leavesQty", values)
+	return f
+}
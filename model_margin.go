@@ -1,49 +1,71 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
+	"encoding/json"
 	"time"
 )
 
 type Margin struct {
 	Account            int       `json:"account"`
 	Currency           string    `json:"currency"`
-	RiskLimit          int       `json:"riskLimit,omitempty"`
+	RiskLimit          Amount    `json:"riskLimit"`
 	PrevState          string    `json:"prevState,omitempty"`
 	State              string    `json:"state,omitempty"`
 	Action             string    `json:"action,omitempty"`
-	Amount             int       `json:"amount,omitempty"`
-	PendingCredit      int       `json:"pendingCredit,omitempty"`
-	PendingDebit       int       `json:"pendingDebit,omitempty"`
-	ConfirmedDebit     int       `json:"confirmedDebit,omitempty"`
-	PrevRealisedPnl    int       `json:"prevRealisedPnl,omitempty"`
-	PrevUnrealisedPnl  int       `json:"prevUnrealisedPnl,omitempty"`
-	GrossComm          int       `json:"grossComm,omitempty"`
-	GrossOpenCost      int       `json:"grossOpenCost,omitempty"`
-	GrossOpenPremium   int       `json:"grossOpenPremium,omitempty"`
-	GrossExecCost      int       `json:"grossExecCost,omitempty"`
-	GrossMarkValue     int       `json:"grossMarkValue,omitempty"`
-	RiskValue          int       `json:"riskValue,omitempty"`
-	TaxableMargin      int       `json:"taxableMargin,omitempty"`
-	InitMargin         int       `json:"initMargin,omitempty"`
-	MaintMargin        int       `json:"maintMargin,omitempty"`
-	SessionMargin      int       `json:"sessionMargin,omitempty"`
-	TargetExcessMargin int       `json:"targetExcessMargin,omitempty"`
-	VarMargin          int       `json:"varMargin,omitempty"`
-	RealisedPnl        int       `json:"realisedPnl,omitempty"`
-	UnrealisedPnl      int       `json:"unrealisedPnl,omitempty"`
-	IndicativeTax      int       `json:"indicativeTax,omitempty"`
-	UnrealisedProfit   int       `json:"unrealisedProfit,omitempty"`
-	SyntheticMargin    int       `json:"syntheticMargin,omitempty"`
-	WalletBalance      int       `json:"walletBalance,omitempty"`
-	MarginBalance      int       `json:"marginBalance,omitempty"`
+	Amount             Amount    `json:"amount"`
+	PendingCredit      Amount    `json:"pendingCredit"`
+	PendingDebit       Amount    `json:"pendingDebit"`
+	ConfirmedDebit     Amount    `json:"confirmedDebit"`
+	PrevRealisedPnl    Amount    `json:"prevRealisedPnl"`
+	PrevUnrealisedPnl  Amount    `json:"prevUnrealisedPnl"`
+	GrossComm          Amount    `json:"grossComm"`
+	GrossOpenCost      Amount    `json:"grossOpenCost"`
+	GrossOpenPremium   Amount    `json:"grossOpenPremium"`
+	GrossExecCost      Amount    `json:"grossExecCost"`
+	GrossMarkValue     Amount    `json:"grossMarkValue"`
+	RiskValue          Amount    `json:"riskValue"`
+	TaxableMargin      Amount    `json:"taxableMargin"`
+	InitMargin         Amount    `json:"initMargin"`
+	MaintMargin        Amount    `json:"maintMargin"`
+	SessionMargin      Amount    `json:"sessionMargin"`
+	TargetExcessMargin Amount    `json:"targetExcessMargin"`
+	VarMargin          Amount    `json:"varMargin"`
+	RealisedPnl        Amount    `json:"realisedPnl"`
+	UnrealisedPnl      Amount    `json:"unrealisedPnl"`
+	IndicativeTax      Amount    `json:"indicativeTax"`
+	UnrealisedProfit   Amount    `json:"unrealisedProfit"`
+	SyntheticMargin    Amount    `json:"syntheticMargin"`
+	WalletBalance      Amount    `json:"walletBalance"`
+	MarginBalance      Amount    `json:"marginBalance"`
 	MarginBalancePcnt  float64   `json:"marginBalancePcnt,omitempty"`
 	MarginLeverage     float64   `json:"marginLeverage,omitempty"`
 	MarginUsedPcnt     float64   `json:"marginUsedPcnt,omitempty"`
-	ExcessMargin       int       `json:"excessMargin,omitempty"`
+	ExcessMargin       Amount    `json:"excessMargin"`
 	ExcessMarginPcnt   float64   `json:"excessMarginPcnt,omitempty"`
-	AvailableMargin    int       `json:"availableMargin,omitempty"`
-	WithdrawableMargin int       `json:"withdrawableMargin,omitempty"`
+	AvailableMargin    Amount    `json:"availableMargin"`
+	WithdrawableMargin Amount    `json:"withdrawableMargin"`
 	Timestamp          time.Time `json:"timestamp,omitempty"`
-	GrossLastValue     int       `json:"grossLastValue,omitempty"`
+	GrossLastValue     Amount    `json:"grossLastValue"`
 	Commission         float64   `json:"commission,omitempty"`
 }
+
+// MarginPatch is a partial Margin, as sent by realtime updates.
+type MarginPatch = Patch[Margin]
+
+// Merge sets the fields of o present in p to their values in p.
+func (o *Margin) Merge(p MarginPatch) {
+	p.Apply(o)
+	setCurrency(o.Currency, &o.RiskLimit, &o.Amount, &o.PendingCredit, &o.PendingDebit, &o.ConfirmedDebit, &o.PrevRealisedPnl, &o.PrevUnrealisedPnl, &o.GrossComm, &o.GrossOpenCost, &o.GrossOpenPremium, &o.GrossExecCost, &o.GrossMarkValue, &o.RiskValue, &o.TaxableMargin, &o.InitMargin, &o.MaintMargin, &o.SessionMargin, &o.TargetExcessMargin, &o.VarMargin, &o.RealisedPnl, &o.UnrealisedPnl, &o.IndicativeTax, &o.UnrealisedProfit, &o.SyntheticMargin, &o.WalletBalance, &o.MarginBalance, &o.ExcessMargin, &o.AvailableMargin, &o.WithdrawableMargin, &o.GrossLastValue)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, giving the amounts of o the currency of o.
+func (o *Margin) UnmarshalJSON(b []byte) error {
+	type plain Margin
+	if err := json.Unmarshal(b, (*plain)(o)); err != nil {
+		return err
+	}
+	setCurrency(o.Currency, &o.RiskLimit, &o.Amount, &o.PendingCredit, &o.PendingDebit, &o.ConfirmedDebit, &o.PrevRealisedPnl, &o.PrevUnrealisedPnl, &o.GrossComm, &o.GrossOpenCost, &o.GrossOpenPremium, &o.GrossExecCost, &o.GrossMarkValue, &o.RiskValue, &o.TaxableMargin, &o.InitMargin, &o.MaintMargin, &o.SessionMargin, &o.TargetExcessMargin, &o.VarMargin, &o.RealisedPnl, &o.UnrealisedPnl, &o.IndicativeTax, &o.UnrealisedProfit, &o.SyntheticMargin, &o.WalletBalance, &o.MarginBalance, &o.ExcessMargin, &o.AvailableMargin, &o.WithdrawableMargin, &o.GrossLastValue)
+	return nil
+}
@@ -1,3 +1,5 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
@@ -6,37 +8,265 @@ import (
 
 // Placement, Cancellation, Amending, and History
 type Order struct {
-	OrderID               string    `json:"orderID"`
-	ClOrdID               string    `json:"clOrdID,omitempty"`
-	ClOrdLinkID           string    `json:"clOrdLinkID,omitempty"`
-	Account               int       `json:"account,omitempty"`
-	Symbol                string    `json:"symbol,omitempty"`
-	Side                  string    `json:"side,omitempty"`
-	SimpleOrderQty        float64   `json:"simpleOrderQty,omitempty"`
-	OrderQty              int       `json:"orderQty,omitempty"`
-	Price                 float64   `json:"price,omitempty"`
-	DisplayQty            int       `json:"displayQty,omitempty"`
-	StopPx                float64   `json:"stopPx,omitempty"`
-	PegOffsetValue        float64   `json:"pegOffsetValue,omitempty"`
-	PegPriceType          string    `json:"pegPriceType,omitempty"`
-	Currency              string    `json:"currency,omitempty"`
-	SettlCurrency         string    `json:"settlCurrency,omitempty"`
-	OrdType               string    `json:"ordType,omitempty"`
-	TimeInForce           string    `json:"timeInForce,omitempty"`
-	ExecInst              string    `json:"execInst,omitempty"`
-	ContingencyType       string    `json:"contingencyType,omitempty"`
-	ExDestination         string    `json:"exDestination,omitempty"`
-	OrdStatus             string    `json:"ordStatus,omitempty"`
-	Triggered             string    `json:"triggered,omitempty"`
-	WorkingIndicator      bool      `json:"workingIndicator,omitempty"`
-	OrdRejReason          string    `json:"ordRejReason,omitempty"`
-	SimpleLeavesQty       float64   `json:"simpleLeavesQty,omitempty"`
-	LeavesQty             int       `json:"leavesQty,omitempty"`
-	SimpleCumQty          float64   `json:"simpleCumQty,omitempty"`
-	CumQty                int       `json:"cumQty,omitempty"`
-	AvgPx                 float64   `json:"avgPx,omitempty"`
-	MultiLegReportingType string    `json:"multiLegReportingType,omitempty"`
-	Text                  string    `json:"text,omitempty"`
-	TransactTime          time.Time `json:"transactTime,omitempty"`
-	Timestamp             time.Time `json:"timestamp,omitempty"`
+	OrderID               string          `json:"orderID"`
+	ClOrdID               string          `json:"clOrdID,omitempty"`
+	ClOrdLinkID           string          `json:"clOrdLinkID,omitempty"`
+	Account               int             `json:"account,omitempty"`
+	Symbol                string          `json:"symbol,omitempty"`
+	Side                  Side            `json:"side,omitempty"`
+	SimpleOrderQty        Decimal         `json:"simpleOrderQty"`
+	OrderQty              int             `json:"orderQty,omitempty"`
+	Price                 Decimal         `json:"price"`
+	DisplayQty            int             `json:"displayQty,omitempty"`
+	StopPx                Decimal         `json:"stopPx"`
+	PegOffsetValue        Decimal         `json:"pegOffsetValue"`
+	PegPriceType          PegPriceType    `json:"pegPriceType,omitempty"`
+	Currency              string          `json:"currency,omitempty"`
+	SettlCurrency         string          `json:"settlCurrency,omitempty"`
+	OrdType               OrdType         `json:"ordType,omitempty"`
+	TimeInForce           TimeInForce     `json:"timeInForce,omitempty"`
+	ExecInst              ExecInst        `json:"execInst,omitempty"`
+	ContingencyType       ContingencyType `json:"contingencyType,omitempty"`
+	ExDestination         string          `json:"exDestination,omitempty"`
+	OrdStatus             OrdStatus       `json:"ordStatus,omitempty"`
+	Triggered             string          `json:"triggered,omitempty"`
+	WorkingIndicator      bool            `json:"workingIndicator,omitempty"`
+	OrdRejReason          string          `json:"ordRejReason,omitempty"`
+	SimpleLeavesQty       Decimal         `json:"simpleLeavesQty"`
+	LeavesQty             int             `json:"leavesQty,omitempty"`
+	SimpleCumQty          Decimal         `json:"simpleCumQty"`
+	CumQty                int             `json:"cumQty,omitempty"`
+	AvgPx                 Decimal         `json:"avgPx"`
+	MultiLegReportingType string          `json:"multiLegReportingType,omitempty"`
+	Text                  string          `json:"text,omitempty"`
+	TransactTime          time.Time       `json:"transactTime,omitempty"`
+	Timestamp             time.Time       `json:"timestamp,omitempty"`
+}
+
+// OrderPatch is a partial Order, as sent by realtime updates.
+type OrderPatch = Patch[Order]
+
+// Merge sets the fields of o present in p to their values in p.
+func (o *Order) Merge(p OrderPatch) {
+	p.Apply(o)
+}
+
+// OrderFilterBuilder builds a Filter on the fields of Order.
+type OrderFilterBuilder struct {
+	*Filter
+}
+
+// OrderFilter starts a Filter on the fields of Order.
+func OrderFilter() *OrderFilterBuilder {
+	return &OrderFilterBuilder{NewFilter[Order]()}
+}
+
+// OrderID matches orderID against any of values.
+func (f *OrderFilterBuilder) OrderID(values ...string) *OrderFilterBuilder {
+	setField(f.Filter, "orderID", values)
+	return f
+}
+
+// ClOrdID matches clOrdID against any of values.
+func (f *OrderFilterBuilder) ClOrdID(values ...string) *OrderFilterBuilder {
+	setField(f.Filter, "clOrdID", values)
+	return f
+}
+
+// ClOrdLinkID matches clOrdLinkID against any of values.
+func (f *OrderFilterBuilder) ClOrdLinkID(values ...string) *OrderFilterBuilder {
+	setField(f.Filter, "clOrdLinkID", values)
+	return f
+}
+
+// Account matches account against any of values.
+func (f *OrderFilterBuilder) Account(values ...int) *OrderFilterBuilder {
+	setField(f.Filter, "account", values)
+	return f
+}
+
+// Symbol matches symbol against any of values.
+func (f *OrderFilterBuilder) Symbol(values ...string) *OrderFilterBuilder {
+	setField(f.Filter, "symbol", values)
+	return f
+}
+
+// Side matches side against any of values.
+func (f *OrderFilterBuilder) Side(values ...Side) *OrderFilterBuilder {
+	setField(f.Filter, "side", values)
+	return f
+}
+
+// SimpleOrderQty matches simpleOrderQty against any of values.
+func (f *OrderFilterBuilder) SimpleOrderQty(values ...Decimal) *OrderFilterBuilder {
+	setField(f.Filter, "simpleOrderQty", values)
+	return f
+}
+
+// OrderQty matches orderQty against any of values.
+func (f *OrderFilterBuilder) OrderQty(values ...int) *OrderFilterBuilder {
+	setField(f.Filter, "orderQty", values)
+	return f
+}
+
+// Price matches price against any of values.
+func (f *OrderFilterBuilder) Price(values ...Decimal) *OrderFilterBuilder {
+	setField(f.Filter, "price", values)
+	return f
+}
+
+// DisplayQty matches displayQty against any of values.
+func (f *OrderFilterBuilder) DisplayQty(values ...int) *OrderFilterBuilder {
+	setField(f.Filter, "displayQty", values)
+	return f
+}
+
+// StopPx matches stopPx against any of values.
+func (f *OrderFilterBuilder) StopPx(values ...Decimal) *OrderFilterBuilder {
+	setField(f.Filter, "stopPx", values)
+	return f
+}
+
+// PegOffsetValue matches pegOffsetValue against any of values.
+func (f *OrderFilterBuilder) PegOffsetValue(values ...Decimal) *OrderFilterBuilder {
+	setField(f.Filter, "pegOffsetValue", values)
+	return f
+}
+
+// PegPriceType matches pegPriceType against any of values.
+func (f *OrderFilterBuilder) PegPriceType(values ...PegPriceType) *OrderFilterBuilder {
+	setField(f.Filter, "pegPriceType", values)
+	return f
+}
+
+// Currency matches currency against any of values.
+func (f *OrderFilterBuilder) Currency(values ...string) *OrderFilterBuilder {
+	setField(f.Filter, "currency", values)
+	return f
+}
+
+// SettlCurrency matches settlCurrency against any of values.
+func (f *OrderFilterBuilder) SettlCurrency(values ...string) *OrderFilterBuilder {
+	setField(f.Filter, "settlCurrency", values)
+	return f
+}
+
+// OrdType matches ordType against any of values.
+func (f *OrderFilterBuilder) OrdType(values ...OrdType) *OrderFilterBuilder {
+	setField(f.Filter, "ordType", values)
+	return f
+}
+
+// TimeInForce matches timeInForce against any of values.
+func (f *OrderFilterBuilder) TimeInForce(values ...TimeInForce) *OrderFilterBuilder {
+	setField(f.Filter, "timeInForce", values)
+	return f
+}
+
+// ExecInst matches execInst against any of values.
+func (f *OrderFilterBuilder) ExecInst(values ...ExecInst) *OrderFilterBuilder {
+	setField(f.Filter, "execInst", values)
+	return f
+}
+
+// ContingencyType matches contingencyType against any of values.
+func (f *OrderFilterBuilder) ContingencyType(values ...ContingencyType) *OrderFilterBuilder {
+	setField(f.Filter, "contingencyType", values)
+	return f
+}
+
+// ExDestination matches exDestination against any of values.
+func (f *OrderFilterBuilder) ExDestination(values ...string) *OrderFilterBuilder {
+	setField(f.Filter, "exDestination", values)
+	return f
+}
+
+// OrdStatus matches ordStatus against any of values.
+func (f *OrderFilterBuilder) OrdStatus(values ...OrdStatus) *OrderFilterBuilder {
+	setField(f.Filter, "ordStatus", values)
+	return f
+}
+
+// Triggered matches triggered against any of values.
+func (f *OrderFilterBuilder) Triggered(values ...string) *OrderFilterBuilder {
+	setField(f.Filter, "triggered", values)
+	return f
+}
+
+// WorkingIndicator matches workingIndicator against any of values.
+func (f *OrderFilterBuilder) WorkingIndicator(values ...bool) *OrderFilterBuilder {
+	setField(f.Filter, "workingIndicator", values)
+	return f
+}
+
+// OrdRejReason matches ordRejReason against any of values.
+func (f *OrderFilterBuilder) OrdRejReason(values ...string) *OrderFilterBuilder {
+	setField(f.Filter, "ordRejReason", values)
+	return f
+}
+
+// SimpleLeavesQty matches simpleLeavesQty against any of values.
+func (f *OrderFilterBuilder) SimpleLeavesQty(values ...Decimal) *OrderFilterBuilder {
+	setField(f.Filter, "simpleLeavesQty", values)
+	return f
+}
+
+// LeavesQty matches leavesQty against any of values.
+func (f *OrderFilterBuilder) LeavesQty(values ...int) *OrderFilterBuilder {
+	setField(f.Filter, "leavesQty", values)
+	return f
+}
+
+// SimpleCumQty matches simpleCumQty against any of values.
+func (f *OrderFilterBuilder) SimpleCumQty(values ...Decimal) *OrderFilterBuilder {
+	setField(f.Filter, "simpleCumQty", values)
+	return f
+}
+
+// CumQty matches cumQty against any of values.
+func (f *OrderFilterBuilder) CumQty(values ...int) *OrderFilterBuilder {
+	setField(f.Filter, "cumQty", values)
+	return f
+}
+
+// AvgPx matches avgPx against any of values.
+func (f *OrderFilterBuilder) AvgPx(values ...Decimal) *OrderFilterBuilder {
+	setField(f.Filter, "avgPx", values)
+	return f
+}
+
+// MultiLegReportingType matches multiLegReportingType against any of values.
+func (f *OrderFilterBuilder) MultiLegReportingType(values ...string) *OrderFilterBuilder {
+	setField(f.Filter, "multiLegReportingType", values)
+	return f
+}
+
+// Text matches text against any of values.
+func (f *OrderFilterBuilder) Text(values ...string) *OrderFilterBuilder {
+	setField(f.Filter, "text", values)
+	return f
+}
+
+// TransactTimeAt matches a part of transactTime, e.g. TransactTimeAt(TimeOfDay, "12:00").
+func (f *OrderFilterBuilder) TransactTimeAt(part TimePart, value interface{}) *OrderFilterBuilder {
+	f.Filter.At("transactTime", part, value)
+	return f
+}
+
+// TransactTime matches transactTime against any of values.
+func (f *OrderFilterBuilder) TransactTime(values ...time.Time) *OrderFilterBuilder {
+	setField(f.Filter, "transactTime", values)
+	return f
+}
+
+// TimestampAt matches a part of timestamp, e.g. TimestampAt(TimeOfDay, "12:00").
+func (f *OrderFilterBuilder) TimestampAt(part TimePart, value interface{}) *OrderFilterBuilder {
+	f.Filter.At("timestamp", part, value)
+	return f
+}
+
+// Timestamp matches timestamp against any of values.
+func (f *OrderFilterBuilder) Timestamp(values ...time.Time) *OrderFilterBuilder {
+	setField(f.Filter, "timestamp", values)
+	return f
 }
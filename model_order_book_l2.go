@@ -1,9 +1,19 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 type OrderBookL2 struct {
 	Symbol string  `json:"symbol"`
 	Id     int     `json:"id"`
-	Side   string  `json:"side"`
+	Side   Side    `json:"side"`
 	Size   int     `json:"size,omitempty"`
-	Price  float64 `json:"price,omitempty"`
+	Price  Decimal `json:"price"`
+}
+
+// OrderBookL2Patch is a partial OrderBookL2, as sent by realtime updates.
+type OrderBookL2Patch = Patch[OrderBookL2]
+
+// Merge sets the fields of o present in p to their values in p.
+func (o *OrderBookL2) Merge(p OrderBookL2Patch) {
+	p.Apply(o)
 }
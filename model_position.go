@@ -1,6 +1,9 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -14,87 +17,386 @@ type Position struct {
 	Commission           float64   `json:"commission,omitempty"`
 	InitMarginReq        float64   `json:"initMarginReq,omitempty"`
 	MaintMarginReq       float64   `json:"maintMarginReq,omitempty"`
-	RiskLimit            int       `json:"riskLimit,omitempty"`
+	RiskLimit            Amount    `json:"riskLimit"`
 	Leverage             float64   `json:"leverage,omitempty"`
 	CrossMargin          bool      `json:"crossMargin,omitempty"`
 	DeleveragePercentile float64   `json:"deleveragePercentile,omitempty"`
-	RebalancedPnl        int       `json:"rebalancedPnl,omitempty"`
-	PrevRealisedPnl      int       `json:"prevRealisedPnl,omitempty"`
-	PrevUnrealisedPnl    int       `json:"prevUnrealisedPnl,omitempty"`
-	PrevClosePrice       float64   `json:"prevClosePrice,omitempty"`
+	RebalancedPnl        Amount    `json:"rebalancedPnl"`
+	PrevRealisedPnl      Amount    `json:"prevRealisedPnl"`
+	PrevUnrealisedPnl    Amount    `json:"prevUnrealisedPnl"`
+	PrevClosePrice       Decimal   `json:"prevClosePrice"`
 	OpeningTimestamp     time.Time `json:"openingTimestamp,omitempty"`
 	OpeningQty           int       `json:"openingQty,omitempty"`
-	OpeningCost          int       `json:"openingCost,omitempty"`
-	OpeningComm          int       `json:"openingComm,omitempty"`
+	OpeningCost          Amount    `json:"openingCost"`
+	OpeningComm          Amount    `json:"openingComm"`
 	OpenOrderBuyQty      int       `json:"openOrderBuyQty,omitempty"`
-	OpenOrderBuyCost     int       `json:"openOrderBuyCost,omitempty"`
-	OpenOrderBuyPremium  int       `json:"openOrderBuyPremium,omitempty"`
+	OpenOrderBuyCost     Amount    `json:"openOrderBuyCost"`
+	OpenOrderBuyPremium  Amount    `json:"openOrderBuyPremium"`
 	OpenOrderSellQty     int       `json:"openOrderSellQty,omitempty"`
-	OpenOrderSellCost    int       `json:"openOrderSellCost,omitempty"`
-	OpenOrderSellPremium int       `json:"openOrderSellPremium,omitempty"`
+	OpenOrderSellCost    Amount    `json:"openOrderSellCost"`
+	OpenOrderSellPremium Amount    `json:"openOrderSellPremium"`
 	ExecBuyQty           int       `json:"execBuyQty,omitempty"`
-	ExecBuyCost          int       `json:"execBuyCost,omitempty"`
+	ExecBuyCost          Amount    `json:"execBuyCost"`
 	ExecSellQty          int       `json:"execSellQty,omitempty"`
-	ExecSellCost         int       `json:"execSellCost,omitempty"`
+	ExecSellCost         Amount    `json:"execSellCost"`
 	ExecQty              int       `json:"execQty,omitempty"`
-	ExecCost             int       `json:"execCost,omitempty"`
-	ExecComm             int       `json:"execComm,omitempty"`
+	ExecCost             Amount    `json:"execCost"`
+	ExecComm             Amount    `json:"execComm"`
 	CurrentTimestamp     time.Time `json:"currentTimestamp,omitempty"`
 	CurrentQty           int       `json:"currentQty,omitempty"`
-	CurrentCost          int       `json:"currentCost,omitempty"`
-	CurrentComm          int       `json:"currentComm,omitempty"`
-	RealisedCost         int       `json:"realisedCost,omitempty"`
-	UnrealisedCost       int       `json:"unrealisedCost,omitempty"`
-	GrossOpenCost        int       `json:"grossOpenCost,omitempty"`
-	GrossOpenPremium     int       `json:"grossOpenPremium,omitempty"`
-	GrossExecCost        int       `json:"grossExecCost,omitempty"`
+	CurrentCost          Amount    `json:"currentCost"`
+	CurrentComm          Amount    `json:"currentComm"`
+	RealisedCost         Amount    `json:"realisedCost"`
+	UnrealisedCost       Amount    `json:"unrealisedCost"`
+	GrossOpenCost        Amount    `json:"grossOpenCost"`
+	GrossOpenPremium     Amount    `json:"grossOpenPremium"`
+	GrossExecCost        Amount    `json:"grossExecCost"`
 	IsOpen               bool      `json:"isOpen,omitempty"`
-	MarkPrice            float64   `json:"markPrice,omitempty"`
-	MarkValue            int       `json:"markValue,omitempty"`
-	RiskValue            int       `json:"riskValue,omitempty"`
-	HomeNotional         float64   `json:"homeNotional,omitempty"`
-	ForeignNotional      float64   `json:"foreignNotional,omitempty"`
+	MarkPrice            Decimal   `json:"markPrice"`
+	MarkValue            Amount    `json:"markValue"`
+	RiskValue            Amount    `json:"riskValue"`
+	HomeNotional         Decimal   `json:"homeNotional"`
+	ForeignNotional      Decimal   `json:"foreignNotional"`
 	PosState             string    `json:"posState,omitempty"`
-	PosCost              int       `json:"posCost,omitempty"`
-	PosCost2             int       `json:"posCost2,omitempty"`
-	PosCross             int       `json:"posCross,omitempty"`
-	PosInit              int       `json:"posInit,omitempty"`
-	PosComm              int       `json:"posComm,omitempty"`
-	PosLoss              int       `json:"posLoss,omitempty"`
-	PosMargin            int       `json:"posMargin,omitempty"`
-	PosMaint             int       `json:"posMaint,omitempty"`
-	PosAllowance         int       `json:"posAllowance,omitempty"`
-	TaxableMargin        int       `json:"taxableMargin,omitempty"`
-	InitMargin           int       `json:"initMargin,omitempty"`
-	MaintMargin          int       `json:"maintMargin,omitempty"`
-	SessionMargin        int       `json:"sessionMargin,omitempty"`
-	TargetExcessMargin   int       `json:"targetExcessMargin,omitempty"`
-	VarMargin            int       `json:"varMargin,omitempty"`
-	RealisedGrossPnl     int       `json:"realisedGrossPnl,omitempty"`
-	RealisedTax          int       `json:"realisedTax,omitempty"`
-	RealisedPnl          int       `json:"realisedPnl,omitempty"`
-	UnrealisedGrossPnl   int       `json:"unrealisedGrossPnl,omitempty"`
-	LongBankrupt         int       `json:"longBankrupt,omitempty"`
-	ShortBankrupt        int       `json:"shortBankrupt,omitempty"`
-	TaxBase              int       `json:"taxBase,omitempty"`
+	PosCost              Amount    `json:"posCost"`
+	PosCost2             Amount    `json:"posCost2"`
+	PosCross             Amount    `json:"posCross"`
+	PosInit              Amount    `json:"posInit"`
+	PosComm              Amount    `json:"posComm"`
+	PosLoss              Amount    `json:"posLoss"`
+	PosMargin            Amount    `json:"posMargin"`
+	PosMaint             Amount    `json:"posMaint"`
+	PosAllowance         Amount    `json:"posAllowance"`
+	TaxableMargin        Amount    `json:"taxableMargin"`
+	InitMargin           Amount    `json:"initMargin"`
+	MaintMargin          Amount    `json:"maintMargin"`
+	SessionMargin        Amount    `json:"sessionMargin"`
+	TargetExcessMargin   Amount    `json:"targetExcessMargin"`
+	VarMargin            Amount    `json:"varMargin"`
+	RealisedGrossPnl     Amount    `json:"realisedGrossPnl"`
+	RealisedTax          Amount    `json:"realisedTax"`
+	RealisedPnl          Amount    `json:"realisedPnl"`
+	UnrealisedGrossPnl   Amount    `json:"unrealisedGrossPnl"`
+	LongBankrupt         Amount    `json:"longBankrupt"`
+	ShortBankrupt        Amount    `json:"shortBankrupt"`
+	TaxBase              Amount    `json:"taxBase"`
 	IndicativeTaxRate    float64   `json:"indicativeTaxRate,omitempty"`
-	IndicativeTax        int       `json:"indicativeTax,omitempty"`
-	UnrealisedTax        int       `json:"unrealisedTax,omitempty"`
-	UnrealisedPnl        int       `json:"unrealisedPnl,omitempty"`
+	IndicativeTax        Amount    `json:"indicativeTax"`
+	UnrealisedTax        Amount    `json:"unrealisedTax"`
+	UnrealisedPnl        Amount    `json:"unrealisedPnl"`
 	UnrealisedPnlPcnt    float64   `json:"unrealisedPnlPcnt,omitempty"`
 	UnrealisedRoePcnt    float64   `json:"unrealisedRoePcnt,omitempty"`
-	SimpleQty            float64   `json:"simpleQty,omitempty"`
+	SimpleQty            Decimal   `json:"simpleQty"`
 	SimpleCost           float64   `json:"simpleCost,omitempty"`
 	SimpleValue          float64   `json:"simpleValue,omitempty"`
 	SimplePnl            float64   `json:"simplePnl,omitempty"`
 	SimplePnlPcnt        float64   `json:"simplePnlPcnt,omitempty"`
-	AvgCostPrice         float64   `json:"avgCostPrice,omitempty"`
-	AvgEntryPrice        float64   `json:"avgEntryPrice,omitempty"`
-	BreakEvenPrice       float64   `json:"breakEvenPrice,omitempty"`
-	MarginCallPrice      float64   `json:"marginCallPrice,omitempty"`
-	LiquidationPrice     float64   `json:"liquidationPrice,omitempty"`
-	BankruptPrice        float64   `json:"bankruptPrice,omitempty"`
+	AvgCostPrice         Decimal   `json:"avgCostPrice"`
+	AvgEntryPrice        Decimal   `json:"avgEntryPrice"`
+	BreakEvenPrice       Decimal   `json:"breakEvenPrice"`
+	MarginCallPrice      Decimal   `json:"marginCallPrice"`
+	LiquidationPrice     Decimal   `json:"liquidationPrice"`
+	BankruptPrice        Decimal   `json:"bankruptPrice"`
 	Timestamp            time.Time `json:"timestamp,omitempty"`
-	LastPrice            float64   `json:"lastPrice,omitempty"`
-	LastValue            int       `json:"lastValue,omitempty"`
+	LastPrice            Decimal   `json:"lastPrice"`
+	LastValue            Amount    `json:"lastValue"`
+}
+
+// PositionPatch is a partial Position, as sent by realtime updates.
+type PositionPatch = Patch[Position]
+
+// Merge sets the fields of o present in p to their values in p.
+func (o *Position) Merge(p PositionPatch) {
+	p.Apply(o)
+	setCurrency(o.Currency, &o.RiskLimit, &o.RebalancedPnl, &o.PrevRealisedPnl, &o.PrevUnrealisedPnl, &o.OpeningCost, &o.OpeningComm, &o.OpenOrderBuyCost, &o.OpenOrderBuyPremium, &o.OpenOrderSellCost, &o.OpenOrderSellPremium, &o.ExecBuyCost, &o.ExecSellCost, &o.ExecCost, &o.ExecComm, &o.CurrentCost, &o.CurrentComm, &o.RealisedCost, &o.UnrealisedCost, &o.GrossOpenCost, &o.GrossOpenPremium, &o.GrossExecCost, &o.MarkValue, &o.RiskValue, &o.PosCost, &o.PosCost2, &o.PosCross, &o.PosInit, &o.PosComm, &o.PosLoss, &o.PosMargin, &o.PosMaint, &o.PosAllowance, &o.TaxableMargin, &o.InitMargin, &o.MaintMargin, &o.SessionMargin, &o.TargetExcessMargin, &o.VarMargin, &o.RealisedGrossPnl, &o.RealisedTax, &o.RealisedPnl, &o.UnrealisedGrossPnl, &o.LongBankrupt, &o.ShortBankrupt, &o.TaxBase, &o.IndicativeTax, &o.UnrealisedTax, &o.UnrealisedPnl, &o.LastValue)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, giving the amounts of o the currency of o.
+func (o *Position) UnmarshalJSON(b []byte) error {
+	type plain Position
+	if err := json.Unmarshal(b, (*plain)(o)); err != nil {
+		return err
+	}
+	setCurrency(o.Currency, &o.RiskLimit, &o.RebalancedPnl, &o.PrevRealisedPnl, &o.PrevUnrealisedPnl, &o.OpeningCost, &o.OpeningComm, &o.OpenOrderBuyCost, &o.OpenOrderBuyPremium, &o.OpenOrderSellCost, &o.OpenOrderSellPremium, &o.ExecBuyCost, &o.ExecSellCost, &o.ExecCost, &o.ExecComm, &o.CurrentCost, &o.CurrentComm, &o.RealisedCost, &o.UnrealisedCost, &o.GrossOpenCost, &o.GrossOpenPremium, &o.GrossExecCost, &o.MarkValue, &o.RiskValue, &o.PosCost, &o.PosCost2, &o.PosCross, &o.PosInit, &o.PosComm, &o.PosLoss, &o.PosMargin, &o.PosMaint, &o.PosAllowance, &o.TaxableMargin, &o.InitMargin, &o.MaintMargin, &o.SessionMargin, &o.TargetExcessMargin, &o.VarMargin, &o.RealisedGrossPnl, &o.RealisedTax, &o.RealisedPnl, &o.UnrealisedGrossPnl, &o.LongBankrupt, &o.ShortBankrupt, &o.TaxBase, &o.IndicativeTax, &o.UnrealisedTax, &o.UnrealisedPnl, &o.LastValue)
+	return nil
+}
+
+// PositionFilterBuilder builds a Filter on the fields of Position.
+type PositionFilterBuilder struct {
+	*Filter
+}
+
+// PositionFilter starts a Filter on the fields of Position.
+func PositionFilter() *PositionFilterBuilder {
+	return &PositionFilterBuilder{NewFilter[Position]()}
+}
+
+// Account matches account against any of values.
+func (f *PositionFilterBuilder) Account(values ...int) *PositionFilterBuilder {
+	setField(f.Filter, "account", values)
+	return f
+}
+
+// Symbol matches symbol against any of values.
+func (f *PositionFilterBuilder) Symbol(values ...string) *PositionFilterBuilder {
+	setField(f.Filter, "symbol", values)
+	return f
+}
+
+// Currency matches currency against any of values.
+func (f *PositionFilterBuilder) Currency(values ...string) *PositionFilterBuilder {
+	setField(f.Filter, "currency", values)
+	return f
+}
+
+// Underlying matches underlying against any of values.
+func (f *PositionFilterBuilder) Underlying(values ...string) *PositionFilterBuilder {
+	setField(f.Filter, "underlying", values)
+	return f
+}
+
+// QuoteCurrency matches quoteCurrency against any of values.
+func (f *PositionFilterBuilder) QuoteCurrency(values ...string) *PositionFilterBuilder {
+	setField(f.Filter, "quoteCurrency", values)
+	return f
+}
+
+// Commission matches commission against any of values.
+func (f *PositionFilterBuilder) Commission(values ...float64) *PositionFilterBuilder {
+	setField(f.Filter, "commission", values)
+	return f
+}
+
+// InitMarginReq matches initMarginReq against any of values.
+func (f *PositionFilterBuilder) InitMarginReq(values ...float64) *PositionFilterBuilder {
+	setField(f.Filter, "initMarginReq", values)
+	return f
+}
+
+// MaintMarginReq matches maintMarginReq against any of values.
+func (f *PositionFilterBuilder) MaintMarginReq(values ...float64) *PositionFilterBuilder {
+	setField(f.Filter, "maintMarginReq", values)
+	return f
+}
+
+// Leverage matches leverage against any of values.
+func (f *PositionFilterBuilder) Leverage(values ...float64) *PositionFilterBuilder {
+	setField(f.Filter, "leverage", values)
+	return f
+}
+
+// CrossMargin matches crossMargin against any of values.
+func (f *PositionFilterBuilder) CrossMargin(values ...bool) *PositionFilterBuilder {
+	setField(f.Filter, "crossMargin", values)
+	return f
+}
+
+// DeleveragePercentile matches deleveragePercentile against any of values.
+func (f *PositionFilterBuilder) DeleveragePercentile(values ...float64) *PositionFilterBuilder {
+	setField(f.Filter, "deleveragePercentile", values)
+	return f
+}
+
+// PrevClosePrice matches prevClosePrice against any of values.
+func (f *PositionFilterBuilder) PrevClosePrice(values ...Decimal) *PositionFilterBuilder {
+	setField(f.Filter, "prevClosePrice", values)
+	return f
+}
+
+// OpeningTimestampAt matches a part of openingTimestamp, e.g. OpeningTimestampAt(TimeOfDay, "12:00").
+func (f *PositionFilterBuilder) OpeningTimestampAt(part TimePart, value interface{}) *PositionFilterBuilder {
+	f.Filter.At("openingTimestamp", part, value)
+	return f
+}
+
+// OpeningTimestamp matches openingTimestamp against any of values.
+func (f *PositionFilterBuilder) OpeningTimestamp(values ...time.Time) *PositionFilterBuilder {
+	setField(f.Filter, "openingTimestamp", values)
+	return f
+}
+
+// OpeningQty matches openingQty against any of values.
+func (f *PositionFilterBuilder) OpeningQty(values ...int) *PositionFilterBuilder {
+	setField(f.Filter, "openingQty", values)
+	return f
+}
+
+// OpenOrderBuyQty matches openOrderBuyQty against any of values.
+func (f *PositionFilterBuilder) OpenOrderBuyQty(values ...int) *PositionFilterBuilder {
+	setField(f.Filter, "openOrderBuyQty", values)
+	return f
+}
+
+// OpenOrderSellQty matches openOrderSellQty against any of values.
+func (f *PositionFilterBuilder) OpenOrderSellQty(values ...int) *PositionFilterBuilder {
+	setField(f.Filter, "openOrderSellQty", values)
+	return f
+}
+
+// ExecBuyQty matches execBuyQty against any of values.
+func (f *PositionFilterBuilder) ExecBuyQty(values ...int) *PositionFilterBuilder {
+	setField(f.Filter, "execBuyQty", values)
+	return f
+}
+
+// ExecSellQty matches execSellQty against any of values.
+func (f *PositionFilterBuilder) ExecSellQty(values ...int) *PositionFilterBuilder {
+	setField(f.Filter, "execSellQty", values)
+	return f
+}
+
+// ExecQty matches execQty against any of values.
+func (f *PositionFilterBuilder) ExecQty(values ...int) *PositionFilterBuilder {
+	setField(f.Filter, "execQty", values)
+	return f
+}
+
+// CurrentTimestampAt matches a part of currentTimestamp, e.g. CurrentTimestampAt(TimeOfDay, "12:00").
+func (f *PositionFilterBuilder) CurrentTimestampAt(part TimePart, value interface{}) *PositionFilterBuilder {
+	f.Filter.At("currentTimestamp", part, value)
+	return f
+}
+
+// CurrentTimestamp matches currentTimestamp against any of values.
+func (f *PositionFilterBuilder) CurrentTimestamp(values ...time.Time) *PositionFilterBuilder {
+	setField(f.Filter, "currentTimestamp", values)
+	return f
+}
+
+// CurrentQty matches currentQty against any of values.
+func (f *PositionFilterBuilder) CurrentQty(values ...int) *PositionFilterBuilder {
+	setField(f.Filter, "currentQty", values)
+	return f
+}
+
+// IsOpen matches isOpen against any of values.
+func (f *PositionFilterBuilder) IsOpen(values ...bool) *PositionFilterBuilder {
+	setField(f.Filter, "isOpen", values)
+	return f
+}
+
+// MarkPrice matches markPrice against any of values.
+func (f *PositionFilterBuilder) MarkPrice(values ...Decimal) *PositionFilterBuilder {
+	setField(f.Filter, "markPrice", values)
+	return f
+}
+
+// HomeNotional matches homeNotional against any of values.
+func (f *PositionFilterBuilder) HomeNotional(values ...Decimal) *PositionFilterBuilder {
+	setField(f.Filter, "homeNotional", values)
+	return f
+}
+
+// ForeignNotional matches foreignNotional against any of values.
+func (f *PositionFilterBuilder) ForeignNotional(values ...Decimal) *PositionFilterBuilder {
+	setField(f.Filter, "foreignNotional", values)
+	return f
+}
+
+// PosState matches posState against any of values.
+func (f *PositionFilterBuilder) PosState(values ...string) *PositionFilterBuilder {
+	setField(f.Filter, "posState", values)
+	return f
+}
+
+// IndicativeTaxRate matches indicativeTaxRate against any of values.
+func (f *PositionFilterBuilder) IndicativeTaxRate(values ...float64) *PositionFilterBuilder {
+	setField(f.Filter, "indicativeTaxRate", values)
+	return f
+}
+
+// UnrealisedPnlPcnt matches unrealisedPnlPcnt against any of values.
+func (f *PositionFilterBuilder) UnrealisedPnlPcnt(values ...float64) *PositionFilterBuilder {
+	setField(f.Filter, "unrealisedPnlPcnt", values)
+	return f
+}
+
+// UnrealisedRoePcnt matches unrealisedRoePcnt against any of values.
+func (f *PositionFilterBuilder) UnrealisedRoePcnt(values ...float64) *PositionFilterBuilder {
+	setField(f.Filter, "unrealisedRoePcnt", values)
+	return f
+}
+
+// SimpleQty matches simpleQty against any of values.
+func (f *PositionFilterBuilder) SimpleQty(values ...Decimal) *PositionFilterBuilder {
+	setField(f.Filter, "simpleQty", values)
+	return f
+}
+
+// SimpleCost matches simpleCost against any of values.
+func (f *PositionFilterBuilder) SimpleCost(values ...float64) *PositionFilterBuilder {
+	setField(f.Filter, "simpleCost", values)
+	return f
+}
+
+// SimpleValue matches simpleValue against any of values.
+func (f *PositionFilterBuilder) SimpleValue(values ...float64) *PositionFilterBuilder {
+	setField(f.Filter, "simpleValue", values)
+	return f
+}
+
+// SimplePnl matches simplePnl against any of values.
+func (f *PositionFilterBuilder) SimplePnl(values ...float64) *PositionFilterBuilder {
+	setField(f.Filter, "simplePnl", values)
+	return f
+}
+
+// SimplePnlPcnt matches simplePnlPcnt against any of values.
+func (f *PositionFilterBuilder) SimplePnlPcnt(values ...float64) *PositionFilterBuilder {
+	setField(f.Filter, "simplePnlPcnt", values)
+	return f
+}
+
+// AvgCostPrice matches avgCostPrice against any of values.
+func (f *PositionFilterBuilder) AvgCostPrice(values ...Decimal) *PositionFilterBuilder {
+	setField(f.Filter, "avgCostPrice", values)
+	return f
+}
+
+// AvgEntryPrice matches avgEntryPrice against any of values.
+func (f *PositionFilterBuilder) AvgEntryPrice(values ...Decimal) *PositionFilterBuilder {
+	setField(f.Filter, "avgEntryPrice", values)
+	return f
+}
+
+// BreakEvenPrice matches breakEvenPrice against any of values.
+func (f *PositionFilterBuilder) BreakEvenPrice(values ...Decimal) *PositionFilterBuilder {
+	setField(f.Filter, "breakEvenPrice", values)
+	return f
+}
+
+// MarginCallPrice matches marginCallPrice against any of values.
+func (f *PositionFilterBuilder) MarginCallPrice(values ...Decimal) *PositionFilterBuilder {
+	setField(f.Filter, "marginCallPrice", values)
+	return f
+}
+
+// LiquidationPrice matches liquidationPrice against any of values.
+func (f *PositionFilterBuilder) LiquidationPrice(values ...Decimal) *PositionFilterBuilder {
+	setField(f.Filter, "liquidationPrice", values)
+	return f
+}
+
+// BankruptPrice matches bankruptPrice against any of values.
+func (f *PositionFilterBuilder) BankruptPrice(values ...Decimal) *PositionFilterBuilder {
+	setField(f.Filter, "bankruptPrice", values)
+	return f
+}
+
+// TimestampAt matches a part of timestamp, e.g. TimestampAt(TimeOfDay, "12:00").
+func (f *PositionFilterBuilder) TimestampAt(part TimePart, value interface{}) *PositionFilterBuilder {
+	f.Filter.At("timestamp", part, value)
+	return f
+}
+
+// Timestamp matches timestamp against any of values.
+func (f *PositionFilterBuilder) Timestamp(values ...time.Time) *PositionFilterBuilder {
+	setField(f.Filter, "timestamp", values)
+	return f
+}
+
+// LastPrice matches lastPrice against any of values.
+func (f *PositionFilterBuilder) LastPrice(values ...Decimal) *PositionFilterBuilder {
+	setField(f.Filter, "lastPrice", values)
+	return f
 }
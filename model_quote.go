@@ -1,3 +1,5 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
@@ -9,7 +11,59 @@ type Quote struct {
 	Timestamp time.Time `json:"timestamp"`
 	Symbol    string    `json:"symbol"`
 	BidSize   int       `json:"bidSize,omitempty"`
-	BidPrice  float64   `json:"bidPrice,omitempty"`
-	AskPrice  float64   `json:"askPrice,omitempty"`
+	BidPrice  Decimal   `json:"bidPrice"`
+	AskPrice  Decimal   `json:"askPrice"`
 	AskSize   int       `json:"askSize,omitempty"`
 }
+
+// QuoteFilterBuilder builds a Filter on the fields of Quote.
+type QuoteFilterBuilder struct {
+	*Filter
+}
+
+// QuoteFilter starts a Filter on the fields of Quote.
+func QuoteFilter() *QuoteFilterBuilder {
+	return &QuoteFilterBuilder{NewFilter[Quote]()}
+}
+
+// TimestampAt matches a part of timestamp, e.g. TimestampAt(TimeOfDay, "12:00").
+func (f *QuoteFilterBuilder) TimestampAt(part TimePart, value interface{}) *QuoteFilterBuilder {
+	f.Filter.At("timestamp", part, value)
+	return f
+}
+
+// Timestamp matches timestamp against any of values.
+func (f *QuoteFilterBuilder) Timestamp(values ...time.Time) *QuoteFilterBuilder {
+	setField(f.Filter, "timestamp", values)
+	return f
+}
+
+// Symbol matches symbol against any of values.
+func (f *QuoteFilterBuilder) Symbol(values ...string) *QuoteFilterBuilder {
+	setField(f.Filter, "symbol", values)
+	return f
+}
+
+// BidSize matches bidSize against any of values.
+func (f *QuoteFilterBuilder) BidSize(values ...int) *QuoteFilterBuilder {
+	setField(f.Filter, "bidSize", values)
+	return f
+}
+
+// BidPrice matches bidPrice against any of values.
+func (f *QuoteFilterBuilder) BidPrice(values ...Decimal) *QuoteFilterBuilder {
+	setField(f.Filter, "bidPrice", values)
+	return f
+}
+
+// AskPrice matches askPrice against any of values.
+func (f *QuoteFilterBuilder) AskPrice(values ...Decimal) *QuoteFilterBuilder {
+	setField(f.Filter, "askPrice", values)
+	return f
+}
+
+// AskSize matches askSize against any of values.
+func (f *QuoteFilterBuilder) AskSize(values ...int) *QuoteFilterBuilder {
+	setField(f.Filter, "askSize", values)
+	return f
+}
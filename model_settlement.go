@@ -1,3 +1,5 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
@@ -9,10 +11,80 @@ type Settlement struct {
 	Timestamp             time.Time `json:"timestamp"`
 	Symbol                string    `json:"symbol"`
 	SettlementType        string    `json:"settlementType,omitempty"`
-	SettledPrice          float64   `json:"settledPrice,omitempty"`
-	OptionStrikePrice     float64   `json:"optionStrikePrice,omitempty"`
-	OptionUnderlyingPrice float64   `json:"optionUnderlyingPrice,omitempty"`
+	SettledPrice          Decimal   `json:"settledPrice"`
+	OptionStrikePrice     Decimal   `json:"optionStrikePrice"`
+	OptionUnderlyingPrice Decimal   `json:"optionUnderlyingPrice"`
 	Bankrupt              int       `json:"bankrupt,omitempty"`
 	TaxBase               int       `json:"taxBase,omitempty"`
 	TaxRate               float64   `json:"taxRate,omitempty"`
 }
+
+// SettlementFilterBuilder builds a Filter on the fields of Settlement.
+type SettlementFilterBuilder struct {
+	*Filter
+}
+
+// SettlementFilter starts a Filter on the fields of Settlement.
+func SettlementFilter() *SettlementFilterBuilder {
+	return &SettlementFilterBuilder{NewFilter[Settlement]()}
+}
+
+// TimestampAt matches a part of timestamp, e.g. TimestampAt(TimeOfDay, "12:00").
+func (f *SettlementFilterBuilder) TimestampAt(part TimePart, value interface{}) *SettlementFilterBuilder {
+	f.Filter.At("timestamp", part, value)
+	return f
+}
+
+// Timestamp matches timestamp against any of values.
+func (f *SettlementFilterBuilder) Timestamp(values ...time.Time) *SettlementFilterBuilder {
+	setField(f.Filter, "timestamp", values)
+	return f
+}
+
+// Symbol matches symbol against any of values.
+func (f *SettlementFilterBuilder) Symbol(values ...string) *SettlementFilterBuilder {
+	setField(f.Filter, "symbol", values)
+	return f
+}
+
+// SettlementType matches settlementType against any of values.
+func (f *SettlementFilterBuilder) SettlementType(values ...string) *SettlementFilterBuilder {
+	setField(f.Filter, "settlementType", values)
+	return f
+}
+
+// SettledPrice matches settledPrice against any of values.
+func (f *SettlementFilterBuilder) SettledPrice(values ...Decimal) *SettlementFilterBuilder {
+	setField(f.Filter, "settledPrice", values)
+	return f
+}
+
+// OptionStrikePrice matches optionStrikePrice against any of values.
+func (f *SettlementFilterBuilder) OptionStrikePrice(values ...Decimal) *SettlementFilterBuilder {
+	setField(f.Filter, "optionStrikePrice", values)
+	return f
+}
+
+// OptionUnderlyingPrice matches optionUnderlyingPrice against any of values.
+func (f *SettlementFilterBuilder) OptionUnderlyingPrice(values ...Decimal) *SettlementFilterBuilder {
+	setField(f.Filter, "optionUnderlyingPrice", values)
+	return f
+}
+
+// Bankrupt matches bankrupt against any of values.
+func (f *SettlementFilterBuilder) Bankrupt(values ...int) *SettlementFilterBuilder {
+	setField(f.Filter, "bankrupt", values)
+	return f
+}
+
+// TaxBase matches taxBase against any of values.
+func (f *SettlementFilterBuilder) TaxBase(values ...int) *SettlementFilterBuilder {
+	setField(f.Filter, "taxBase", values)
+	return f
+}
+
+// TaxRate matches taxRate against any of values.
+func (f *SettlementFilterBuilder) TaxRate(values ...float64) *SettlementFilterBuilder {
+	setField(f.Filter, "taxRate", values)
+	return f
+}
@@ -1,11 +1,27 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
+import (
+	"encoding/json"
+)
+
 // Exchange Statistics
 type Stats struct {
 	RootSymbol   string `json:"rootSymbol"`
 	Currency     string `json:"currency,omitempty"`
 	Volume24h    int    `json:"volume24h,omitempty"`
-	Turnover24h  int    `json:"turnover24h,omitempty"`
+	Turnover24h  Amount `json:"turnover24h"`
 	OpenInterest int    `json:"openInterest,omitempty"`
-	OpenValue    int    `json:"openValue,omitempty"`
+	OpenValue    Amount `json:"openValue"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, giving the amounts of o the currency of o.
+func (o *Stats) UnmarshalJSON(b []byte) error {
+	type plain Stats
+	if err := json.Unmarshal(b, (*plain)(o)); err != nil {
+		return err
+	}
+	setCurrency(o.Currency, &o.Turnover24h, &o.OpenValue)
+	return nil
 }
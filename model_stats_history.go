@@ -1,6 +1,9 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -9,5 +12,15 @@ type StatsHistory struct {
 	RootSymbol string    `json:"rootSymbol"`
 	Currency   string    `json:"currency,omitempty"`
 	Volume     int       `json:"volume,omitempty"`
-	Turnover   int       `json:"turnover,omitempty"`
+	Turnover   Amount    `json:"turnover"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, giving the amounts of o the currency of o.
+func (o *StatsHistory) UnmarshalJSON(b []byte) error {
+	type plain StatsHistory
+	if err := json.Unmarshal(b, (*plain)(o)); err != nil {
+		return err
+	}
+	setCurrency(o.Currency, &o.Turnover)
+	return nil
 }
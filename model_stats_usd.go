@@ -1,10 +1,26 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
+import (
+	"encoding/json"
+)
+
 type StatsUsd struct {
 	RootSymbol   string `json:"rootSymbol"`
 	Currency     string `json:"currency,omitempty"`
-	Turnover24h  int    `json:"turnover24h,omitempty"`
-	Turnover30d  int    `json:"turnover30d,omitempty"`
-	Turnover365d int    `json:"turnover365d,omitempty"`
-	Turnover     int    `json:"turnover,omitempty"`
+	Turnover24h  Amount `json:"turnover24h"`
+	Turnover30d  Amount `json:"turnover30d"`
+	Turnover365d Amount `json:"turnover365d"`
+	Turnover     Amount `json:"turnover"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, giving the amounts of o the currency of o.
+func (o *StatsUsd) UnmarshalJSON(b []byte) error {
+	type plain StatsUsd
+	if err := json.Unmarshal(b, (*plain)(o)); err != nil {
+		return err
+	}
+	setCurrency(o.Currency, &o.Turnover24h, &o.Turnover30d, &o.Turnover365d, &o.Turnover)
+	return nil
 }
@@ -1,3 +1,5 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
@@ -6,14 +8,84 @@ import (
 
 // Individual & Bucketed Trades
 type Trade struct {
-	Timestamp       time.Time `json:"timestamp"`
-	Symbol          string    `json:"symbol"`
-	Side            string    `json:"side,omitempty"`
-	Size            int       `json:"size,omitempty"`
-	Price           float64   `json:"price,omitempty"`
-	TickDirection   string    `json:"tickDirection,omitempty"`
-	TrdMatchID      string    `json:"trdMatchID,omitempty"`
-	GrossValue      int       `json:"grossValue,omitempty"`
-	HomeNotional    float64   `json:"homeNotional,omitempty"`
-	ForeignNotional float64   `json:"foreignNotional,omitempty"`
+	Timestamp       time.Time     `json:"timestamp"`
+	Symbol          string        `json:"symbol"`
+	Side            Side          `json:"side,omitempty"`
+	Size            int           `json:"size,omitempty"`
+	Price           Decimal       `json:"price"`
+	TickDirection   TickDirection `json:"tickDirection,omitempty"`
+	TrdMatchID      string        `json:"trdMatchID,omitempty"`
+	GrossValue      Amount        `json:"grossValue"`
+	HomeNotional    Decimal       `json:"homeNotional"`
+	ForeignNotional Decimal       `json:"foreignNotional"`
+}
+
+// TradeFilterBuilder builds a Filter on the fields of Trade.
+type TradeFilterBuilder struct {
+	*Filter
+}
+
+// TradeFilter starts a Filter on the fields of Trade.
+func TradeFilter() *TradeFilterBuilder {
+	return &TradeFilterBuilder{NewFilter[Trade]()}
+}
+
+// TimestampAt matches a part of timestamp, e.g. TimestampAt(TimeOfDay, "12:00").
+func (f *TradeFilterBuilder) TimestampAt(part TimePart, value interface{}) *TradeFilterBuilder {
+	f.Filter.At("timestamp", part, value)
+	return f
+}
+
+// Timestamp matches timestamp against any of values.
+func (f *TradeFilterBuilder) Timestamp(values ...time.Time) *TradeFilterBuilder {
+	setField(f.Filter, "timestamp", values)
+	return f
+}
+
+// Symbol matches symbol against any of values.
+func (f *TradeFilterBuilder) Symbol(values ...string) *TradeFilterBuilder {
+	setField(f.Filter, "symbol", values)
+	return f
+}
+
+// Side matches side against any of values.
+func (f *TradeFilterBuilder) Side(values ...Side) *TradeFilterBuilder {
+	setField(f.Filter, "side", values)
+	return f
+}
+
+// Size matches size against any of values.
+func (f *TradeFilterBuilder) Size(values ...int) *TradeFilterBuilder {
+	setField(f.Filter, "size", values)
+	return f
+}
+
+// Price matches price against any of values.
+func (f *TradeFilterBuilder) Price(values ...Decimal) *TradeFilterBuilder {
+	setField(f.Filter, "price", values)
+	return f
+}
+
+// TickDirection matches tickDirection against any of values.
+func (f *TradeFilterBuilder) TickDirection(values ...TickDirection) *TradeFilterBuilder {
+	setField(f.Filter, "tickDirection", values)
+	return f
+}
+
+// TrdMatchID matches trdMatchID against any of values.
+func (f *TradeFilterBuilder) TrdMatchID(values ...string) *TradeFilterBuilder {
+	setField(f.Filter, "trdMatchID", values)
+	return f
+}
+
+// HomeNotional matches homeNotional against any of values.
+func (f *TradeFilterBuilder) HomeNotional(values ...Decimal) *TradeFilterBuilder {
+	setField(f.Filter, "homeNotional", values)
+	return f
+}
+
+// ForeignNotional matches foreignNotional against any of values.
+func (f *TradeFilterBuilder) ForeignNotional(values ...Decimal) *TradeFilterBuilder {
+	setField(f.Filter, "foreignNotional", values)
+	return f
 }
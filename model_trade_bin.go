@@ -1,3 +1,5 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
@@ -7,15 +9,103 @@ import (
 type TradeBin struct {
 	Timestamp       time.Time `json:"timestamp"`
 	Symbol          string    `json:"symbol"`
-	Open            float64   `json:"open,omitempty"`
-	High            float64   `json:"high,omitempty"`
-	Low             float64   `json:"low,omitempty"`
-	Close           float64   `json:"close,omitempty"`
+	Open            Decimal   `json:"open"`
+	High            Decimal   `json:"high"`
+	Low             Decimal   `json:"low"`
+	Close           Decimal   `json:"close"`
 	Trades          int       `json:"trades,omitempty"`
 	Volume          int       `json:"volume,omitempty"`
-	Vwap            float64   `json:"vwap,omitempty"`
+	Vwap            Decimal   `json:"vwap"`
 	LastSize        int       `json:"lastSize,omitempty"`
-	Turnover        int       `json:"turnover,omitempty"`
-	HomeNotional    float64   `json:"homeNotional,omitempty"`
-	ForeignNotional float64   `json:"foreignNotional,omitempty"`
+	Turnover        Amount    `json:"turnover"`
+	HomeNotional    Decimal   `json:"homeNotional"`
+	ForeignNotional Decimal   `json:"foreignNotional"`
+}
+
+// TradeBinFilterBuilder builds a Filter on the fields of TradeBin.
+type TradeBinFilterBuilder struct {
+	*Filter
+}
+
+// TradeBinFilter starts a Filter on the fields of TradeBin.
+func TradeBinFilter() *TradeBinFilterBuilder {
+	return &TradeBinFilterBuilder{NewFilter[TradeBin]()}
+}
+
+// TimestampAt matches a part of timestamp, e.g. TimestampAt(TimeOfDay, "12:00").
+func (f *TradeBinFilterBuilder) TimestampAt(part TimePart, value interface{}) *TradeBinFilterBuilder {
+	f.Filter.At("timestamp", part, value)
+	return f
+}
+
+// Timestamp matches timestamp against any of values.
+func (f *TradeBinFilterBuilder) Timestamp(values ...time.Time) *TradeBinFilterBuilder {
+	setField(f.Filter, "timestamp", values)
+	return f
+}
+
+// Symbol matches symbol against any of values.
+func (f *TradeBinFilterBuilder) Symbol(values ...string) *TradeBinFilterBuilder {
+	setField(f.Filter, "symbol", values)
+	return f
+}
+
+// Open matches open against any of values.
+func (f *TradeBinFilterBuilder) Open(values ...Decimal) *TradeBinFilterBuilder {
+	setField(f.Filter, "open", values)
+	return f
+}
+
+// High matches high against any of values.
+func (f *TradeBinFilterBuilder) High(values ...Decimal) *TradeBinFilterBuilder {
+	setField(f.Filter, "high", values)
+	return f
+}
+
+// Low matches low against any of values.
+func (f *TradeBinFilterBuilder) Low(values ...Decimal) *TradeBinFilterBuilder {
+	setField(f.Filter, "low", values)
+	return f
+}
+
+// Close matches close against any of values.
+func (f *TradeBinFilterBuilder) Close(values ...Decimal) *TradeBinFilterBuilder {
+	setField(f.Filter, "close", values)
+	return f
+}
+
+// Trades matches trades against any of values.
+func (f *TradeBinFilterBuilder) Trades(values ...int) *TradeBinFilterBuilder {
+	setField(f.Filter, "trades", values)
+	return f
+}
+
+// Volume matches volume against any of values.
+func (f *TradeBinFilterBuilder) Volume(values ...int) *TradeBinFilterBuilder {
+	setField(f.Filter, "volume", values)
+	return f
+}
+
+// Vwap matches vwap against any of values.
+func (f *TradeBinFilterBuilder) Vwap(values ...Decimal) *TradeBinFilterBuilder {
+	setField(f.Filter, "vwap", values)
+	return f
+}
+
+// LastSize matches lastSize against any of values.
+func (f *TradeBinFilterBuilder) LastSize(values ...int) *TradeBinFilterBuilder {
+	setField(f.Filter, "lastSize", values)
+	return f
+}
+
+// HomeNotional matches homeNotional against any of values.
+func (f *TradeBinFilterBuilder) HomeNotional(values ...Decimal) *TradeBinFilterBuilder {
+	setField(f.Filter, "homeNotional", values)
+	return f
+}
+
+// ForeignNotional matches foreignNotional against any of values.
+func (f *TradeBinFilterBuilder) ForeignNotional(values ...Decimal) *TradeBinFilterBuilder {
+	setField(f.Filter, "foreignNotional", values)
+	return f
 }
@@ -1,6 +1,9 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -9,8 +12,8 @@ type Transaction struct {
 	Account        int       `json:"account,omitempty"`
 	Currency       string    `json:"currency,omitempty"`
 	TransactType   string    `json:"transactType,omitempty"`
-	Amount         int       `json:"amount,omitempty"`
-	Fee            int       `json:"fee,omitempty"`
+	Amount         Amount    `json:"amount"`
+	Fee            Amount    `json:"fee"`
 	TransactStatus string    `json:"transactStatus,omitempty"`
 	Address        string    `json:"address,omitempty"`
 	Tx             string    `json:"tx,omitempty"`
@@ -18,3 +21,13 @@ type Transaction struct {
 	TransactTime   time.Time `json:"transactTime,omitempty"`
 	Timestamp      time.Time `json:"timestamp,omitempty"`
 }
+
+// UnmarshalJSON implements json.Unmarshaler, giving the amounts of o the currency of o.
+func (o *Transaction) UnmarshalJSON(b []byte) error {
+	type plain Transaction
+	if err := json.Unmarshal(b, (*plain)(o)); err != nil {
+		return err
+	}
+	setCurrency(o.Currency, &o.Amount, &o.Fee)
+	return nil
+}
@@ -1,33 +1,55 @@
+// Code generated by bitmex-gen from api/swagger.yaml. DO NOT EDIT.
+
 package bitmex
 
 import (
+	"encoding/json"
 	"time"
 )
 
 type Wallet struct {
 	Account          int       `json:"account"`
 	Currency         string    `json:"currency"`
-	PrevDeposited    int       `json:"prevDeposited,omitempty"`
-	PrevWithdrawn    int       `json:"prevWithdrawn,omitempty"`
-	PrevTransferIn   int       `json:"prevTransferIn,omitempty"`
-	PrevTransferOut  int       `json:"prevTransferOut,omitempty"`
-	PrevAmount       int       `json:"prevAmount,omitempty"`
+	PrevDeposited    Amount    `json:"prevDeposited"`
+	PrevWithdrawn    Amount    `json:"prevWithdrawn"`
+	PrevTransferIn   Amount    `json:"prevTransferIn"`
+	PrevTransferOut  Amount    `json:"prevTransferOut"`
+	PrevAmount       Amount    `json:"prevAmount"`
 	PrevTimestamp    time.Time `json:"prevTimestamp,omitempty"`
-	DeltaDeposited   int       `json:"deltaDeposited,omitempty"`
-	DeltaWithdrawn   int       `json:"deltaWithdrawn,omitempty"`
-	DeltaTransferIn  int       `json:"deltaTransferIn,omitempty"`
-	DeltaTransferOut int       `json:"deltaTransferOut,omitempty"`
-	DeltaAmount      int       `json:"deltaAmount,omitempty"`
-	Deposited        int       `json:"deposited,omitempty"`
-	Withdrawn        int       `json:"withdrawn,omitempty"`
-	TransferIn       int       `json:"transferIn,omitempty"`
-	TransferOut      int       `json:"transferOut,omitempty"`
-	Amount           int       `json:"amount,omitempty"`
-	PendingCredit    int       `json:"pendingCredit,omitempty"`
-	PendingDebit     int       `json:"pendingDebit,omitempty"`
-	ConfirmedDebit   int       `json:"confirmedDebit,omitempty"`
+	DeltaDeposited   Amount    `json:"deltaDeposited"`
+	DeltaWithdrawn   Amount    `json:"deltaWithdrawn"`
+	DeltaTransferIn  Amount    `json:"deltaTransferIn"`
+	DeltaTransferOut Amount    `json:"deltaTransferOut"`
+	DeltaAmount      Amount    `json:"deltaAmount"`
+	Deposited        Amount    `json:"deposited"`
+	Withdrawn        Amount    `json:"withdrawn"`
+	TransferIn       Amount    `json:"transferIn"`
+	TransferOut      Amount    `json:"transferOut"`
+	Amount           Amount    `json:"amount"`
+	PendingCredit    Amount    `json:"pendingCredit"`
+	PendingDebit     Amount    `json:"pendingDebit"`
+	ConfirmedDebit   Amount    `json:"confirmedDebit"`
 	Timestamp        time.Time `json:"timestamp,omitempty"`
 	Addr             string    `json:"addr,omitempty"`
 	Script           string    `json:"script,omitempty"`
 	WithdrawalLock   []string  `json:"withdrawalLock,omitempty"`
 }
+
+// WalletPatch is a partial Wallet, as sent by realtime updates.
+type WalletPatch = Patch[Wallet]
+
+// Merge sets the fields of o present in p to their values in p.
+func (o *Wallet) Merge(p WalletPatch) {
+	p.Apply(o)
+	setCurrency(o.Currency, &o.PrevDeposited, &o.PrevWithdrawn, &o.PrevTransferIn, &o.PrevTransferOut, &o.PrevAmount, &o.DeltaDeposited, &o.DeltaWithdrawn, &o.DeltaTransferIn, &o.DeltaTransferOut, &o.DeltaAmount, &o.Deposited, &o.Withdrawn, &o.TransferIn, &o.TransferOut, &o.Amount, &o.PendingCredit, &o.PendingDebit, &o.ConfirmedDebit)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, giving the amounts of o the currency of o.
+func (o *Wallet) UnmarshalJSON(b []byte) error {
+	type plain Wallet
+	if err := json.Unmarshal(b, (*plain)(o)); err != nil {
+		return err
+	}
+	setCurrency(o.Currency, &o.PrevDeposited, &o.PrevWithdrawn, &o.PrevTransferIn, &o.PrevTransferOut, &o.PrevAmount, &o.DeltaDeposited, &o.DeltaWithdrawn, &o.DeltaTransferIn, &o.DeltaTransferOut, &o.DeltaAmount, &o.Deposited, &o.Withdrawn, &o.TransferIn, &o.TransferOut, &o.Amount, &o.PendingCredit, &o.PendingDebit, &o.ConfirmedDebit)
+	return nil
+}
@@ -0,0 +1,23 @@
+//go:build !bitmex_float
+
+package optional
+
+import "github.com/go-numb/go-bitmex/decimal"
+
+type Decimal struct {
+	set   bool
+	value decimal.Decimal
+}
+
+func (o *Decimal) IsSet() bool {
+	return o.set
+}
+
+func (o *Decimal) Value() decimal.Decimal {
+	return o.value
+}
+
+func (o *Decimal) Set(v decimal.Decimal) {
+	o.set = true
+	o.value = v
+}
@@ -0,0 +1,6 @@
+//go:build bitmex_float
+
+package optional
+
+// Decimal is Float64 in builds with the bitmex_float tag.
+type Decimal = Float64
@@ -0,0 +1,19 @@
+package optional
+
+type Enum[T ~string] struct {
+	set   bool
+	value T
+}
+
+func (o *Enum[T]) IsSet() bool {
+	return o.set
+}
+
+func (o *Enum[T]) Value() T {
+	return o.value
+}
+
+func (o *Enum[T]) Set(v T) {
+	o.set = true
+	o.value = v
+}
@@ -0,0 +1,340 @@
+package bitmex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-numb/go-bitmex/optional"
+)
+
+// ErrInvalidOrder is returned by OrderBuilder for an order BitMEX would refuse.
+var ErrInvalidOrder = errors.New("bitmex: invalid order")
+
+// ordTypes holds which of price and stopPx each ordType requires. The other
+// is forbidden.
+var ordTypes = map[OrdType]struct{ price, stopPx bool }{
+	OrdTypeLimit:           {price: true},
+	OrdTypeMarket:          {},
+	OrdTypeStop:            {stopPx: true},
+	OrdTypeStopLimit:       {price: true, stopPx: true},
+	OrdTypeMarketIfTouched: {stopPx: true},
+	OrdTypeLimitIfTouched:  {price: true, stopPx: true},
+}
+
+// OrderBuilder builds an order of one of the ordTypes of BitMEX, checking the
+// fields the type requires and forbids before anything is sent:
+//
+//	opts, err := bitmex.StopLimitOrder("XBTUSD", bitmex.SideSell, 100, stopPx, price).
+//		Trigger(bitmex.ExecInstMarkPrice).ReduceOnly().NewOpts()
+//	order, _, err := client.OrderApi.OrderNew(ctx, "XBTUSD", opts)
+//
+// The methods set a field and return the builder; an invalid combination is
+// reported by NewOpts, AmendOpts and MarshalJSON.
+type OrderBuilder struct {
+	symbol   string
+	opts     OrderNewOpts
+	execInst ExecInst
+}
+
+func newOrderBuilder(symbol string, side Side, qty int, ordType OrdType) *OrderBuilder {
+	b := &OrderBuilder{symbol: symbol}
+	b.opts.Side.Set(side)
+	b.opts.OrderQty.Set(qty)
+	b.opts.OrdType.Set(ordType)
+	return b
+}
+
+// LimitOrder starts a Limit order of qty contracts at price.
+func LimitOrder(symbol string, side Side, qty int, price Decimal) *OrderBuilder {
+	b := newOrderBuilder(symbol, side, qty, OrdTypeLimit)
+	b.opts.Price.Set(price)
+	return b
+}
+
+// PostOnlyOrder starts a Limit order canceled rather than taking liquidity.
+func PostOnlyOrder(symbol string, side Side, qty int, price Decimal) *OrderBuilder {
+	return LimitOrder(symbol, side, qty, price).addExecInst(ExecInstParticipateDoNotInitiate)
+}
+
+// MarketOrder starts a Market order of qty contracts.
+func MarketOrder(symbol string, side Side, qty int) *OrderBuilder {
+	return newOrderBuilder(symbol, side, qty, OrdTypeMarket)
+}
+
+// StopMarketOrder starts a Stop order, sent to the book as a Market order
+// once the price reaches stopPx.
+func StopMarketOrder(symbol string, side Side, qty int, stopPx Decimal) *OrderBuilder {
+	b := newOrderBuilder(symbol, side, qty, OrdTypeStop)
+	b.opts.StopPx.Set(stopPx)
+	return b
+}
+
+// StopLimitOrder starts a StopLimit order, sent to the book as a Limit order
+// at price once the price reaches stopPx.
+func StopLimitOrder(symbol string, side Side, qty int, stopPx, price Decimal) *OrderBuilder {
+	b := newOrderBuilder(symbol, side, qty, OrdTypeStopLimit)
+	b.opts.StopPx.Set(stopPx)
+	b.opts.Price.Set(price)
+	return b
+}
+
+// TrailingStopOrder starts a Stop order whose stopPx trails the price by
+// offset: negative for a sell, below the price, and positive for a buy.
+func TrailingStopOrder(symbol string, side Side, qty int, offset Decimal) *OrderBuilder {
+	return newOrderBuilder(symbol, side, qty, OrdTypeStop).Trail(offset)
+}
+
+// MarketIfTouchedOrder starts a MarketIfTouched order, sent to the book as a
+// Market order once the price reaches stopPx, from the other side than a stop.
+func MarketIfTouchedOrder(symbol string, side Side, qty int, stopPx Decimal) *OrderBuilder {
+	b := newOrderBuilder(symbol, side, qty, OrdTypeMarketIfTouched)
+	b.opts.StopPx.Set(stopPx)
+	return b
+}
+
+// LimitIfTouchedOrder starts a LimitIfTouched order, sent to the book as a
+// Limit order at price once the price reaches stopPx.
+func LimitIfTouchedOrder(symbol string, side Side, qty int, stopPx, price Decimal) *OrderBuilder {
+	b := newOrderBuilder(symbol, side, qty, OrdTypeLimitIfTouched)
+	b.opts.StopPx.Set(stopPx)
+	b.opts.Price.Set(price)
+	return b
+}
+
+// Trail makes the stopPx of a stop or if-touched order trail the price by
+// offset, with a TrailingStopPeg, rather than be fixed. The offset of a stop
+// is negative for a sell and positive for a buy; that of an if-touched order
+// the other way round.
+func (b *OrderBuilder) Trail(offset Decimal) *OrderBuilder {
+	b.opts.StopPx = optional.Decimal{}
+	b.opts.PegPriceType.Set(PegPriceTypeTrailingStopPeg)
+	b.opts.PegOffsetValue.Set(offset)
+	return b
+}
+
+// ClOrdID sets the client order ID.
+func (b *OrderBuilder) ClOrdID(id string) *OrderBuilder {
+	b.opts.ClOrdID.Set(id)
+	return b
+}
+
+// ClOrdLinkID sets the client order link ID of a contingent order.
+func (b *OrderBuilder) ClOrdLinkID(id string) *OrderBuilder {
+	b.opts.ClOrdLinkID.Set(id)
+	return b
+}
+
+// Text sets the annotation of the order.
+func (b *OrderBuilder) Text(text string) *OrderBuilder {
+	b.opts.Text.Set(text)
+	return b
+}
+
+// TimeInForce sets how long the order stays in the book.
+func (b *OrderBuilder) TimeInForce(tif TimeInForce) *OrderBuilder {
+	b.opts.TimeInForce.Set(tif)
+	return b
+}
+
+// DisplayQty shows qty contracts of a priced order in the book, none for a
+// hidden order.
+func (b *OrderBuilder) DisplayQty(qty int) *OrderBuilder {
+	b.opts.DisplayQty.Set(qty)
+	return b
+}
+
+// ReduceOnly keeps the order from increasing the position.
+func (b *OrderBuilder) ReduceOnly() *OrderBuilder {
+	return b.addExecInst(ExecInstReduceOnly)
+}
+
+// Close makes the order close the position, which it may not increase. A
+// quantity of 0 closes all of it.
+func (b *OrderBuilder) Close() *OrderBuilder {
+	return b.addExecInst(ExecInstClose)
+}
+
+// ParticipateDoNotInitiate cancels the order rather than taking liquidity.
+func (b *OrderBuilder) ParticipateDoNotInitiate() *OrderBuilder {
+	return b.addExecInst(ExecInstParticipateDoNotInitiate)
+}
+
+// AllOrNone fills the order at once or not at all.
+func (b *OrderBuilder) AllOrNone() *OrderBuilder {
+	return b.addExecInst(ExecInstAllOrNone)
+}
+
+// Trigger sets the price a stop order is triggered by, ExecInstMarkPrice,
+// ExecInstLastPrice or ExecInstIndexPrice.
+func (b *OrderBuilder) Trigger(price ExecInst) *OrderBuilder {
+	return b.addExecInst(price)
+}
+
+func (b *OrderBuilder) addExecInst(inst ExecInst) *OrderBuilder {
+	b.execInst = b.execInst.With(inst)
+	return b
+}
+
+// Symbol returns the symbol of the order.
+func (b *OrderBuilder) Symbol() string {
+	return b.symbol
+}
+
+// NewOpts returns the order as the options of OrderNew, or ErrInvalidOrder.
+func (b *OrderBuilder) NewOpts() (*OrderNewOpts, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+	opts := b.opts
+	if opts.OrderQty.Value() == 0 {
+		opts.OrderQty = optional.Int{}
+	}
+	if b.execInst != "" {
+		opts.ExecInst.Set(b.execInst)
+	}
+	return &opts, nil
+}
+
+// AmendOpts returns the options of OrderAmend changing the order orderID, or
+// the order of the ClOrdID of b when orderID is empty, to the quantity and
+// prices of b. The ordType, side and execInst of an order can't be amended.
+func (b *OrderBuilder) AmendOpts(orderID string) (*OrderAmendOpts, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+	var opts OrderAmendOpts
+	switch {
+	case orderID != "":
+		opts.OrderID.Set(orderID)
+	case b.opts.ClOrdID.IsSet():
+		opts.OrigClOrdID.Set(b.opts.ClOrdID.Value())
+	default:
+		return nil, fmt.Errorf("%w: amend needs an orderID or a clOrdID", ErrInvalidOrder)
+	}
+	if b.opts.OrderQty.Value() != 0 {
+		opts.OrderQty = b.opts.OrderQty
+	}
+	opts.Price, opts.StopPx, opts.PegOffsetValue = b.opts.Price, b.opts.StopPx, b.opts.PegOffsetValue
+	opts.Text = b.opts.Text
+	return &opts, nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the order as an element of
+// the orders of OrderNewBulk.
+func (b *OrderBuilder) MarshalJSON() ([]byte, error) {
+	opts, err := b.NewOpts()
+	if err != nil {
+		return nil, err
+	}
+	order := optionalValues(opts)
+	order["symbol"] = b.symbol
+	return json.Marshal(order)
+}
+
+// BulkOrders returns the orders parameter of OrderNewBulk placing orders.
+func BulkOrders(orders ...*OrderBuilder) (optional.String, error) {
+	var o optional.String
+	b, err := json.Marshal(orders)
+	if err != nil {
+		var jerr *json.MarshalerError
+		if errors.As(err, &jerr) {
+			err = jerr.Err
+		}
+		return o, err
+	}
+	o.Set(string(b))
+	return o, nil
+}
+
+// validate checks the fields of the order against its ordType and execInst.
+func (b *OrderBuilder) validate() error {
+	ordType, side, qty := b.opts.OrdType.Value(), b.opts.Side.Value(), b.opts.OrderQty.Value()
+	invalid := func(format string, a ...interface{}) error {
+		return fmt.Errorf("%w: %s %s: %s", ErrInvalidOrder, b.symbol, ordType, fmt.Sprintf(format, a...))
+	}
+	rule, ok := ordTypes[ordType]
+	trailing := b.opts.PegPriceType.Value() == PegPriceTypeTrailingStopPeg
+	postOnly := b.execInst.Has(ExecInstParticipateDoNotInitiate)
+	switch {
+	case b.symbol == "":
+		return invalid("symbol required")
+	case !ok:
+		return invalid("unknown ordType")
+	case !side.IsValid():
+		return invalid("side %q is neither %s nor %s", side, SideBuy, SideSell)
+	case qty < 0:
+		return invalid("negative orderQty %d, use the side", qty)
+	case qty == 0 && !b.execInst.Has(ExecInstClose):
+		return invalid("orderQty required")
+	}
+
+	price, stopPx := ToDecimal(b.opts.Price.Value()), ToDecimal(b.opts.StopPx.Value())
+	switch {
+	case rule.price && !b.opts.Price.IsSet():
+		return invalid("price required")
+	case !rule.price && b.opts.Price.IsSet():
+		return invalid("price not allowed")
+	case b.opts.Price.IsSet() && price.Sign() <= 0:
+		return invalid("price %v not positive", price)
+	case rule.stopPx && !b.opts.StopPx.IsSet() && !trailing:
+		return invalid("stopPx required")
+	case !rule.stopPx && b.opts.StopPx.IsSet():
+		return invalid("stopPx not allowed")
+	case b.opts.StopPx.IsSet() && stopPx.Sign() <= 0:
+		return invalid("stopPx %v not positive", stopPx)
+	}
+
+	if b.opts.PegPriceType.IsSet() {
+		// A stop triggers past the price against the side, an if-touched
+		// order past it in favour of the side.
+		below := side == SideSell
+		if ordType == OrdTypeMarketIfTouched || ordType == OrdTypeLimitIfTouched {
+			below = !below
+		}
+		offset := ToDecimal(b.opts.PegOffsetValue.Value())
+		switch {
+		case !trailing:
+			return invalid("pegPriceType %q not supported", b.opts.PegPriceType.Value())
+		case !rule.stopPx:
+			return invalid("trailing stops are stop or if-touched orders")
+		case below && offset.Sign() >= 0:
+			return invalid("trailing %s %s needs a negative pegOffsetValue", side, ordType)
+		case !below && offset.Sign() <= 0:
+			return invalid("trailing %s %s needs a positive pegOffsetValue", side, ordType)
+		}
+	} else if b.opts.PegOffsetValue.IsSet() {
+		return invalid("pegOffsetValue without pegPriceType")
+	}
+
+	tif := b.opts.TimeInForce.Value()
+	switch {
+	case b.opts.TimeInForce.IsSet() && !tif.IsValid():
+		return invalid("unknown timeInForce %q", tif)
+	case postOnly && !rule.price:
+		return invalid("%s needs a priced order", ExecInstParticipateDoNotInitiate)
+	case postOnly && (tif == TimeInForceImmediateOrCancel || tif == TimeInForceFillOrKill):
+		return invalid("%s can't be %s", ExecInstParticipateDoNotInitiate, tif)
+	case b.opts.DisplayQty.IsSet() && !rule.price:
+		return invalid("displayQty needs a priced order")
+	case b.opts.DisplayQty.IsSet() && (b.opts.DisplayQty.Value() < 0 || b.opts.DisplayQty.Value() > qty):
+		return invalid("displayQty %d not within 0 and orderQty", b.opts.DisplayQty.Value())
+	}
+
+	triggers := 0
+	for _, inst := range b.execInst.Split() {
+		switch inst {
+		case ExecInstReduceOnly, ExecInstClose, ExecInstParticipateDoNotInitiate, ExecInstAllOrNone:
+		case ExecInstMarkPrice, ExecInstLastPrice, ExecInstIndexPrice:
+			if !rule.stopPx {
+				return invalid("trigger %s needs a stop or if-touched order", inst)
+			}
+			if triggers++; triggers > 1 {
+				return invalid("more than one trigger price")
+			}
+		default:
+			return invalid("unknown execInst %q", inst)
+		}
+	}
+	return nil
+}
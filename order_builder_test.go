@@ -0,0 +1,150 @@
+package bitmex_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-numb/go-bitmex"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var px = bitmex.FromFloat
+
+func TestOrderBuilderValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		order *bitmex.OrderBuilder
+		err   string // "" when valid
+	}{
+		{"limit", bitmex.LimitOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)), ""},
+		{"limit without price", bitmex.LimitOrder("XBTUSD", bitmex.SideBuy, 100, px(0)), "price 0 not positive"},
+		{"market", bitmex.MarketOrder("XBTUSD", bitmex.SideSell, 100), ""},
+		{"market without qty", bitmex.MarketOrder("XBTUSD", bitmex.SideSell, 0), "orderQty required"},
+		{"market with negative qty", bitmex.MarketOrder("XBTUSD", bitmex.SideSell, -100), "negative orderQty"},
+		{"market closing", bitmex.MarketOrder("XBTUSD", bitmex.SideSell, 0).Close(), ""},
+		{"market with a side typo", bitmex.MarketOrder("XBTUSD", "Short", 100), `side "Short"`},
+		{"no symbol", bitmex.MarketOrder("", bitmex.SideSell, 100), "symbol required"},
+		{"stop", bitmex.StopMarketOrder("XBTUSD", bitmex.SideSell, 100, px(6500)), ""},
+		{"stop without stopPx", bitmex.StopMarketOrder("XBTUSD", bitmex.SideSell, 100, px(-1)), "stopPx -1 not positive"},
+		{"stop limit", bitmex.StopLimitOrder("XBTUSD", bitmex.SideSell, 100, px(6500), px(6490)), ""},
+		{"market if touched", bitmex.MarketIfTouchedOrder("XBTUSD", bitmex.SideSell, 100, px(7500)), ""},
+		{"limit if touched", bitmex.LimitIfTouchedOrder("XBTUSD", bitmex.SideSell, 100, px(7500), px(7490)), ""},
+
+		{"trailing sell stop", bitmex.TrailingStopOrder("XBTUSD", bitmex.SideSell, 100, px(-50)), ""},
+		{"trailing sell stop above", bitmex.TrailingStopOrder("XBTUSD", bitmex.SideSell, 100, px(50)), "needs a negative pegOffsetValue"},
+		{"trailing buy stop", bitmex.TrailingStopOrder("XBTUSD", bitmex.SideBuy, 100, px(50)), ""},
+		{"trailing buy stop below", bitmex.TrailingStopOrder("XBTUSD", bitmex.SideBuy, 100, px(-50)), "needs a positive pegOffsetValue"},
+		{"trailing stop limit", bitmex.StopLimitOrder("XBTUSD", bitmex.SideSell, 100, px(6500), px(6490)).Trail(px(-50)), ""},
+		{"trailing sell if touched", bitmex.MarketIfTouchedOrder("XBTUSD", bitmex.SideSell, 100, px(7500)).Trail(px(50)), ""},
+		{"trailing sell if touched below", bitmex.MarketIfTouchedOrder("XBTUSD", bitmex.SideSell, 100, px(7500)).Trail(px(-50)), "needs a positive pegOffsetValue"},
+		{"trailing buy limit if touched", bitmex.LimitIfTouchedOrder("XBTUSD", bitmex.SideBuy, 100, px(6500), px(6510)).Trail(px(-50)), ""},
+		{"trailing buy limit if touched above", bitmex.LimitIfTouchedOrder("XBTUSD", bitmex.SideBuy, 100, px(6500), px(6510)).Trail(px(50)), "needs a negative pegOffsetValue"},
+		{"trailing limit", bitmex.LimitOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)).Trail(px(50)), "stop or if-touched orders"},
+
+		{"post only", bitmex.PostOnlyOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)), ""},
+		{"post only good till cancel", bitmex.PostOnlyOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)).TimeInForce(bitmex.TimeInForceGoodTillCancel), ""},
+		{"post only immediate or cancel", bitmex.PostOnlyOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)).TimeInForce(bitmex.TimeInForceImmediateOrCancel), "can't be ImmediateOrCancel"},
+		{"post only fill or kill", bitmex.PostOnlyOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)).TimeInForce(bitmex.TimeInForceFillOrKill), "can't be FillOrKill"},
+		{"post only market", bitmex.MarketOrder("XBTUSD", bitmex.SideBuy, 100).ParticipateDoNotInitiate(), "needs a priced order"},
+		{"unknown time in force", bitmex.MarketOrder("XBTUSD", bitmex.SideBuy, 100).TimeInForce("GTC"), `unknown timeInForce "GTC"`},
+
+		{"hidden", bitmex.LimitOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)).DisplayQty(0), ""},
+		{"iceberg", bitmex.LimitOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)).DisplayQty(100), ""},
+		{"display above qty", bitmex.LimitOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)).DisplayQty(101), "displayQty 101 not within"},
+		{"negative display", bitmex.LimitOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)).DisplayQty(-1), "displayQty -1 not within"},
+		{"display of a market order", bitmex.MarketOrder("XBTUSD", bitmex.SideBuy, 100).DisplayQty(0), "displayQty needs a priced order"},
+
+		{"all or none", bitmex.LimitOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)).DisplayQty(0).AllOrNone(), ""},
+		{"mark price trigger", bitmex.StopMarketOrder("XBTUSD", bitmex.SideSell, 100, px(6500)).Trigger(bitmex.ExecInstMarkPrice).ReduceOnly(), ""},
+		{"trigger of a limit", bitmex.LimitOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)).Trigger(bitmex.ExecInstMarkPrice), "needs a stop or if-touched order"},
+		{"two triggers", bitmex.StopMarketOrder("XBTUSD", bitmex.SideSell, 100, px(6500)).Trigger(bitmex.ExecInstMarkPrice).Trigger(bitmex.ExecInstLastPrice), "more than one trigger price"},
+		{"unknown execInst", bitmex.MarketOrder("XBTUSD", bitmex.SideBuy, 100).Trigger("Fast"), `unknown execInst "Fast"`},
+	} {
+		_, err := tt.order.NewOpts()
+		if tt.err == "" {
+			assert.NoError(t, err, tt.name)
+			continue
+		}
+		if assert.Error(t, err, tt.name) {
+			assert.True(t, errors.Is(err, bitmex.ErrInvalidOrder), tt.name)
+			assert.Contains(t, err.Error(), tt.err, tt.name)
+		}
+	}
+}
+
+func TestOrderBuilderOpts(t *testing.T) {
+	var form url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = r.PostForm
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"orderID":"o1"}`))
+	}))
+	defer srv.Close()
+	cfg := bitmex.NewConfiguration()
+	cfg.BasePath = srv.URL
+	orders := bitmex.NewAPIClient(cfg).OrderApi
+	ctx := bitmex.NewAPIKeyContext("key", "secret")
+
+	for _, tt := range []struct {
+		name  string
+		order *bitmex.OrderBuilder
+		new   url.Values
+		amend url.Values // of the order of clOrdID "c1"
+	}{
+		{"limit", bitmex.LimitOrder("XBTUSD", bitmex.SideBuy, 100, px(7000.5)).ClOrdID("c1"),
+			url.Values{"symbol": {"XBTUSD"}, "side": {"Buy"}, "orderQty": {"100"}, "ordType": {"Limit"}, "price": {"7000.5"}, "clOrdID": {"c1"}},
+			url.Values{"origClOrdID": {"c1"}, "orderQty": {"100"}, "price": {"7000.5"}}},
+		{"closing stop", bitmex.StopMarketOrder("XBTUSD", bitmex.SideSell, 0, px(6500)).Close().Trigger(bitmex.ExecInstMarkPrice).ClOrdID("c1"),
+			url.Values{"symbol": {"XBTUSD"}, "side": {"Sell"}, "ordType": {"Stop"}, "stopPx": {"6500"}, "execInst": {"Close,MarkPrice"}, "clOrdID": {"c1"}},
+			url.Values{"origClOrdID": {"c1"}, "stopPx": {"6500"}}},
+		{"trailing stop", bitmex.TrailingStopOrder("XBTUSD", bitmex.SideSell, 100, px(-50)).Text("trail").ClOrdID("c1"),
+			url.Values{"symbol": {"XBTUSD"}, "side": {"Sell"}, "orderQty": {"100"}, "ordType": {"Stop"}, "pegPriceType": {"TrailingStopPeg"}, "pegOffsetValue": {"-50"}, "text": {"trail"}, "clOrdID": {"c1"}},
+			url.Values{"origClOrdID": {"c1"}, "orderQty": {"100"}, "pegOffsetValue": {"-50"}, "text": {"trail"}}},
+	} {
+		opts, err := tt.order.NewOpts()
+		if !assert.NoError(t, err, tt.name) {
+			continue
+		}
+		_, _, err = orders.OrderNew(ctx, tt.order.Symbol(), opts)
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.new, form, tt.name)
+
+		amend, err := tt.order.AmendOpts("")
+		if !assert.NoError(t, err, tt.name) {
+			continue
+		}
+		_, _, err = orders.OrderAmend(ctx, amend)
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.amend, form, tt.name)
+	}
+
+	amend, err := bitmex.LimitOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)).AmendOpts("o1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "o1", amend.OrderID.Value())
+		assert.False(t, amend.OrigClOrdID.IsSet())
+	}
+	_, err = bitmex.LimitOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)).AmendOpts("")
+	assert.ErrorIs(t, err, bitmex.ErrInvalidOrder)
+}
+
+func TestBulkOrders(t *testing.T) {
+	o, err := bitmex.BulkOrders(
+		bitmex.PostOnlyOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)).ClOrdID("b1"),
+		bitmex.StopMarketOrder("ETHUSD", bitmex.SideSell, 0, px(150.05)).Close(),
+	)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `[
+			{"symbol":"XBTUSD","side":"Buy","orderQty":100,"ordType":"Limit","price":7000,"execInst":"ParticipateDoNotInitiate","clOrdID":"b1"},
+			{"symbol":"ETHUSD","side":"Sell","ordType":"Stop","stopPx":150.05,"execInst":"Close"}
+		]`, o.Value())
+	}
+
+	o, err = bitmex.BulkOrders(bitmex.LimitOrder("XBTUSD", bitmex.SideBuy, 100, px(7000)), bitmex.MarketOrder("XBTUSD", bitmex.SideBuy, 0))
+	assert.ErrorIs(t, err, bitmex.ErrInvalidOrder)
+	assert.False(t, o.IsSet())
+}
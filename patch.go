@@ -0,0 +1,100 @@
+package bitmex
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Patch is a partial row of the model T, such as the data of a realtime
+// update or a REST row fetched with a columns parameter: the values of the
+// fields present in it, and which fields those were. A field present with a
+// null or zero value is applied as such, where the model alone can't tell it
+// from an absent one. The models receiving updates have a Merge method
+// applying their XxxPatch, e.g.
+//
+//	var patches []bitmex.OrderPatch
+//	err := json.Unmarshal(data, &patches)
+//	orders[patches[0].Value.OrderID].Merge(patches[0])
+type Patch[T any] struct {
+	Value  T
+	fields map[string]bool
+}
+
+// NewPatch returns the patch of the fields, their JSON names, of v, e.g. the
+// columns of a REST request. An unknown name is an error.
+func NewPatch[T any](v T, fields ...string) (Patch[T], error) {
+	p := Patch[T]{Value: v, fields: map[string]bool{}}
+	model := jsonFields(reflect.TypeOf(v))
+	for _, name := range fields {
+		if _, ok := model[name]; !ok {
+			return Patch[T]{}, fmt.Errorf("bitmex: %T has no field %q", v, name)
+		}
+		p.fields[name] = true
+	}
+	return p, nil
+}
+
+// Has reports whether the field name, its JSON name, is present in p.
+func (p Patch[T]) Has(name string) bool {
+	return p.fields[name]
+}
+
+// Fields returns the JSON names of the fields present in p, sorted.
+func (p Patch[T]) Fields() []string {
+	fields := make([]string, 0, len(p.fields))
+	for name := range p.fields {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Apply sets the fields of dst present in p to their values in p.
+func (p Patch[T]) Apply(dst *T) {
+	src, out := reflect.ValueOf(p.Value), reflect.ValueOf(dst).Elem()
+	for name, f := range jsonFields(src.Type()) {
+		if p.fields[name] {
+			out.FieldByIndex(f.Index).Set(src.FieldByIndex(f.Index))
+		}
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler, recording the fields of T present
+// in b. Other keys are ignored.
+func (p *Patch[T]) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	var v T
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	p.Value, p.fields = v, map[string]bool{}
+	for name := range jsonFields(reflect.TypeOf(v)) {
+		if _, ok := raw[name]; ok {
+			p.fields[name] = true
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the fields present in p,
+// zero values included.
+func (p Patch[T]) MarshalJSON() ([]byte, error) {
+	v := reflect.ValueOf(p.Value)
+	out := map[string]json.RawMessage{}
+	for name, f := range jsonFields(v.Type()) {
+		if !p.fields[name] {
+			continue
+		}
+		b, err := json.Marshal(v.FieldByIndex(f.Index).Interface())
+		if err != nil {
+			return nil, err
+		}
+		out[name] = b
+	}
+	return json.Marshal(out)
+}
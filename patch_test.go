@@ -0,0 +1,87 @@
+package bitmex_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-numb/go-bitmex"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatch(t *testing.T) {
+	cached := func() bitmex.Order {
+		return bitmex.Order{
+			OrderID:   "o1",
+			ClOrdID:   "mine",
+			Side:      bitmex.SideBuy,
+			OrderQty:  100,
+			Price:     bitmex.FromFloat(7000),
+			OrdStatus: bitmex.OrdStatusNew,
+			LeavesQty: 100,
+			Text:      "Submitted via API.",
+		}
+	}
+	for _, tt := range []struct {
+		name   string
+		json   string
+		fields []string
+		want   func(o *bitmex.Order)
+	}{
+		{"only the key", `{"orderID":"o1"}`, []string{"orderID"},
+			func(o *bitmex.Order) {}},
+		{"zero values", `{"orderID":"o1","leavesQty":0,"ordStatus":"Canceled"}`, []string{"leavesQty", "ordStatus", "orderID"},
+			func(o *bitmex.Order) { o.LeavesQty, o.OrdStatus = 0, bitmex.OrdStatusCanceled }},
+		{"explicit null", `{"orderID":"o1","price":null,"text":null}`, []string{"orderID", "price", "text"},
+			func(o *bitmex.Order) { o.Price, o.Text = bitmex.FromFloat(0), "" }},
+		{"absent fields kept", `{"orderID":"o1","price":7001.5}`, []string{"orderID", "price"},
+			func(o *bitmex.Order) { o.Price = bitmex.FromFloat(7001.5) }},
+		{"unknown keys ignored", `{"orderID":"o1","foo":1}`, []string{"orderID"},
+			func(o *bitmex.Order) {}},
+	} {
+		var p bitmex.OrderPatch
+		if !assert.NoError(t, json.Unmarshal([]byte(tt.json), &p), tt.name) {
+			continue
+		}
+		assert.Equal(t, tt.fields, p.Fields(), tt.name)
+		assert.True(t, p.Has("orderID"), tt.name)
+		assert.False(t, p.Has("clOrdID"), tt.name)
+
+		got, want := cached(), cached()
+		got.Merge(p)
+		tt.want(&want)
+		assert.Equal(t, want, got, tt.name)
+
+		// a patch encodes the fields present in it, zero values included
+		b, err := json.Marshal(p)
+		assert.NoError(t, err, tt.name)
+		var back bitmex.OrderPatch
+		assert.NoError(t, json.Unmarshal(b, &back), tt.name)
+		assert.Equal(t, tt.fields, back.Fields(), tt.name)
+	}
+}
+
+func TestNewPatch(t *testing.T) {
+	row := bitmex.Order{OrderID: "o1", LeavesQty: 0, OrdStatus: bitmex.OrdStatusFilled}
+	p, err := bitmex.NewPatch(row, "orderID", "leavesQty", "ordStatus")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"leavesQty", "ordStatus", "orderID"}, p.Fields())
+		o := bitmex.Order{OrderID: "o1", LeavesQty: 100, OrdStatus: bitmex.OrdStatusNew, Symbol: "XBTUSD"}
+		o.Merge(p)
+		assert.Equal(t, bitmex.Order{OrderID: "o1", OrdStatus: bitmex.OrdStatusFilled, Symbol: "XBTUSD"}, o)
+	}
+
+	_, err = bitmex.NewPatch(row, "orderID", "size")
+	assert.EqualError(t, err, `bitmex: bitmex.Order has no field "size"`)
+}
+
+func TestPatchAmounts(t *testing.T) {
+	// amounts merged into a margin keep the currency of the margin
+	m := bitmex.Margin{Currency: "XBt", WalletBalance: bitmex.NewAmount(100, bitmex.XBt)}
+	var p bitmex.MarginPatch
+	if assert.NoError(t, json.Unmarshal([]byte(`{"account":1,"availableMargin":40}`), &p)) {
+		m.Merge(p)
+	}
+	assert.Equal(t, bitmex.NewAmount(40, bitmex.XBt), m.AvailableMargin)
+	assert.Equal(t, bitmex.NewAmount(100, bitmex.XBt), m.WalletBalance)
+}
@@ -0,0 +1,86 @@
+package realtimetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Script drives the frames a Server sends. It is usually loaded from a
+// fixture file with LoadScript:
+//
+//	{"connections": [
+//	  [
+//	    {"expect": "subscribe"},
+//	    {"send": {"table": "trade", "action": "partial", "data": [...]}},
+//	    {"sleep": "100ms"},
+//	    {"send": {"status": 400, "error": "Rate limit exceeded", "meta": {}}},
+//	    {"disconnect": true}
+//	  ],
+//	  [...]
+//	]}
+type Script struct {
+	// Connections holds the steps run on each connection in turn. The
+	// connections beyond the last one run the last steps again.
+	Connections [][]Step `json:"connections"`
+}
+
+// Step is one action of a Script, only one field being set.
+type Step struct {
+	// Expect waits for the client to send a request with this op, e.g.
+	// "authKeyExpires", "subscribe" or "ping", after the server has answered it.
+	Expect string `json:"expect,omitempty"`
+	// Send writes a frame as is: a table frame, an error frame or any other message.
+	Send json.RawMessage `json:"send,omitempty"`
+	// Sleep pauses the script.
+	Sleep Duration `json:"sleep,omitempty"`
+	// Disconnect drops the connection without a close frame.
+	Disconnect bool `json:"disconnect,omitempty"`
+}
+
+// Duration is a time.Duration written as a string in fixtures, e.g. "250ms".
+type Duration time.Duration
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// LoadScript reads a Script from a JSON fixture file.
+func LoadScript(name string) (*Script, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var script Script
+	if err := json.Unmarshal(b, &script); err != nil {
+		return nil, fmt.Errorf("realtimetest: %s: %v", name, err)
+	}
+	return &script, nil
+}
+
+// steps returns the steps of the n-th connection.
+func (s *Script) steps(n int) []Step {
+	if s == nil || len(s.Connections) == 0 {
+		return nil
+	}
+	if n >= len(s.Connections) {
+		n = len(s.Connections) - 1
+	}
+	return s.Connections[n]
+}
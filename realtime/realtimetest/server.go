@@ -0,0 +1,405 @@
+// Package realtimetest runs a fake BitMEX realtime API in process, so that
+// realtime.Connect can be tested offline:
+//
+//	script, _ := realtimetest.LoadScript("testdata/trade.json")
+//	srv := realtimetest.NewServer(script)
+//	defer srv.Close()
+//	ctx := realtime.WithEndpoint(context.Background(), srv.URL)
+//	err := realtime.Connect(ctx, ch, []string{"trade"}, []string{"XBTUSD"}, nil)
+//
+// The server answers authKeyExpires, subscribe, unsubscribe and ping by
+// itself; the table frames, error frames and disconnects come from the Script
+// or from Publish and Disconnect.
+package realtimetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+
+	"github.com/gorilla/websocket"
+)
+
+// Server is a BitMEX realtime API listening on a local address.
+type Server struct {
+	// URL is the endpoint to connect to, see realtime.WithEndpoint.
+	URL string
+	// Now is the server time, time.Now when nil.
+	Now func() time.Time
+
+	srv      *httptest.Server
+	script   *Script
+	upgrader websocket.Upgrader
+
+	mu       sync.Mutex
+	accounts map[string]string // secret by key
+	conns    map[*conn]struct{}
+	accepted int
+}
+
+// Request is a message sent by the client.
+type Request struct {
+	Op   string            `json:"op"`
+	Args []json.RawMessage `json:"args"`
+	ID   int               `json:"id,omitempty"`
+}
+
+// conn is a client connection.
+type conn struct {
+	ws *websocket.Conn
+
+	wmu sync.Mutex // serializes writes
+
+	mu     sync.Mutex
+	key    string          // authenticated API key
+	topics map[string]bool // subscriptions, e.g. "trade:XBTUSD"
+
+	ops  chan string // ops of the requests answered, for Step.Expect
+	done chan struct{}
+}
+
+// Tables of the realtime API.
+var (
+	publicTables = []string{
+		"announcement", "chat", "connected", "funding", "instrument", "insurance", "liquidation",
+		"orderBookL2_25", "orderBookL2", "orderBook10", "publicNotifications",
+		"quote", "quoteBin1m", "quoteBin5m", "quoteBin1h", "quoteBin1d",
+		"settlement", "trade", "tradeBin1m", "tradeBin5m", "tradeBin1h", "tradeBin1d",
+	}
+	privateTables = []string{
+		"affiliate", "execution", "order", "margin", "position", "privateNotifications", "transact", "wallet",
+	}
+)
+
+// NewServer starts a Server running script on every connection. script may be nil.
+func NewServer(script *Script) *Server {
+	s := &Server{
+		script:   script,
+		accounts: map[string]string{},
+		conns:    map[*conn]struct{}{},
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serve))
+	s.URL = "ws" + strings.TrimPrefix(s.srv.URL, "http") + "/realtime"
+	return s
+}
+
+// Close drops the connections and shuts the server down.
+func (s *Server) Close() {
+	s.Disconnect()
+	s.srv.Close()
+}
+
+// AddAccount registers an API key accepted by authKeyExpires.
+func (s *Server) AddAccount(key, secret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accounts[key] = secret
+}
+
+// Accepted returns the number of connections accepted so far.
+func (s *Server) Accepted() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accepted
+}
+
+// Publish sends a table frame to the connections subscribed to topic, either a
+// table such as "order" or a table and symbol such as "trade:XBTUSD". A frame
+// for a table reaches the subscribers of every symbol of it.
+func (s *Server) Publish(topic, action string, data interface{}) error {
+	table := topic
+	if i := strings.Index(topic, ":"); i >= 0 {
+		table = topic[:i]
+	}
+	frame, err := json.Marshal(map[string]interface{}{
+		"table":  table,
+		"action": action,
+		"data":   data,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, c := range s.connections() {
+		if c.subscribed(topic, table) {
+			c.send(frame)
+		}
+	}
+	return nil
+}
+
+// Disconnect drops every connection without a close frame, as a network
+// failure would.
+func (s *Server) Disconnect() {
+	for _, c := range s.connections() {
+		c.ws.Close()
+	}
+}
+
+func (s *Server) connections() []*conn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conns := make([]*conn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	return conns
+}
+
+func (s *Server) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/realtime" {
+		http.NotFound(w, r)
+		return
+	}
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	c := &conn{
+		ws:     ws,
+		topics: map[string]bool{},
+		ops:    make(chan string, 64),
+		done:   make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	n := s.accepted
+	s.accepted++
+	s.conns[c] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		ws.Close()
+	}()
+
+	c.write(map[string]interface{}{
+		"info":      "Welcome to the BitMEX Realtime API.",
+		"version":   "2.0.0",
+		"timestamp": s.now().UTC().Format("2006-01-02T15:04:05.000Z"),
+		"docs":      "https://www.bitmex.com/app/wsAPI",
+		"limit":     map[string]int{"remaining": 40},
+	})
+
+	go s.run(c, s.script.steps(n))
+	defer close(c.done)
+
+	for {
+		_, msg, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		if string(msg) == "ping" {
+			c.send([]byte("pong"))
+			c.expected("ping")
+			continue
+		}
+
+		var req Request
+		if err := json.Unmarshal(msg, &req); err != nil {
+			c.write(errorFrame(400, "Unable to parse request.", nil))
+			continue
+		}
+		s.handle(c, &req)
+		c.expected(req.Op)
+	}
+}
+
+// handle answers a request as BitMEX does.
+func (s *Server) handle(c *conn, req *Request) {
+	switch req.Op {
+	case "authKeyExpires":
+		if err := s.authenticate(c, req); err != "" {
+			c.write(errorFrame(401, err, req))
+			return
+		}
+		c.write(map[string]interface{}{"success": true, "request": req})
+
+	case "subscribe", "unsubscribe":
+		for _, arg := range req.Args {
+			var topic string
+			if err := json.Unmarshal(arg, &topic); err != nil {
+				c.write(errorFrame(400, "Invalid subscription argument.", req))
+				continue
+			}
+			table := topic
+			if i := strings.Index(topic, ":"); i >= 0 {
+				table = topic[:i]
+			}
+			switch {
+			case contains(privateTables, table):
+				if req.Op == "subscribe" && !c.authenticated() {
+					c.write(errorFrame(401, "User requested an account-locked subscription but no authorization was provided.", req))
+					continue
+				}
+			case !contains(publicTables, table):
+				c.write(errorFrame(400, "Unknown table: "+table, req))
+				continue
+			}
+
+			c.mu.Lock()
+			if req.Op == "subscribe" {
+				c.topics[topic] = true
+			} else {
+				delete(c.topics, topic)
+			}
+			c.mu.Unlock()
+			c.write(map[string]interface{}{"success": true, req.Op: topic, "request": req})
+		}
+
+	default:
+		c.write(errorFrame(400, fmt.Sprintf("Unknown or unsupported command %q.", req.Op), req))
+	}
+}
+
+// authenticate checks the authKeyExpires arguments, returning the error
+// message to send when they are refused.
+func (s *Server) authenticate(c *conn, req *Request) string {
+	var key, signature string
+	var expires int64
+	if len(req.Args) != 3 ||
+		json.Unmarshal(req.Args[0], &key) != nil ||
+		json.Unmarshal(req.Args[1], &expires) != nil ||
+		json.Unmarshal(req.Args[2], &signature) != nil {
+		return "Invalid arguments: expected [key, expires, signature]."
+	}
+
+	s.mu.Lock()
+	secret, ok := s.accounts[key]
+	s.mu.Unlock()
+	if !ok {
+		return "Invalid API Key."
+	}
+	if expires <= s.now().Unix() {
+		return "Authorization has expired."
+	}
+	want, err := bitmex.NewHMACSigner(key, secret).Sign("GET", "/realtime", expires, nil)
+	if err != nil || want != signature {
+		return "Signature not valid."
+	}
+
+	c.mu.Lock()
+	c.key = key
+	c.mu.Unlock()
+	return ""
+}
+
+// run plays steps on c.
+func (s *Server) run(c *conn, steps []Step) {
+	for _, step := range steps {
+		switch {
+		case step.Expect != "":
+			if !c.expect(step.Expect) {
+				return
+			}
+		case len(step.Send) > 0:
+			c.send(step.Send)
+		case step.Sleep > 0:
+			select {
+			case <-time.After(time.Duration(step.Sleep)):
+			case <-c.done:
+				return
+			}
+		case step.Disconnect:
+			c.ws.Close()
+			return
+		}
+	}
+}
+
+func (c *conn) send(msg []byte) {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	c.ws.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	c.ws.WriteMessage(websocket.TextMessage, msg)
+}
+
+func (c *conn) write(v interface{}) {
+	msg, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	c.send(msg)
+}
+
+// expected hands op over to the script, dropping it when the script lags far behind.
+func (c *conn) expected(op string) {
+	select {
+	case c.ops <- op:
+	default:
+	}
+}
+
+// expect waits for a request with op, returning false when the connection is lost first.
+func (c *conn) expect(op string) bool {
+	for {
+		select {
+		case got := <-c.ops:
+			if got == op {
+				return true
+			}
+		case <-c.done:
+			return false
+		}
+	}
+}
+
+func (c *conn) authenticated() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.key != ""
+}
+
+func (c *conn) subscribed(topic, table string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.topics[topic] {
+		return true
+	}
+	if topic != table {
+		return c.topics[table]
+	}
+	for t := range c.topics {
+		if strings.HasPrefix(t, table+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// errorFrame is the message BitMEX sends for a refused request.
+func errorFrame(status int, message string, req *Request) map[string]interface{} {
+	frame := map[string]interface{}{
+		"status": status,
+		"error":  message,
+		"meta":   map[string]interface{}{},
+	}
+	if req != nil {
+		frame["request"] = req
+	}
+	return frame
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
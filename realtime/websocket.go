@@ -2,9 +2,6 @@ package realtime
 
 import (
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
 	"log"
 	"os"
@@ -25,7 +22,9 @@ const (
 	ENDPOINTTESTNET               = "wss://testnet.bitmex.com/realtime"
 	READDEADLINE    time.Duration = 300 * time.Second
 
-	AUTHKEY = "auth"
+	AUTHKEY     = "auth"
+	ENDPOINTKEY = "endpoint"
+	CLOCKKEY    = "clock"
 )
 
 type Types int
@@ -122,13 +121,32 @@ type Client struct {
 	conn *websocket.Conn
 	Auth *Auth
 
-	log *log.Logger
+	// clock is fed with the server time of the messages received.
+	clock *bitmex.Clock
+	log   *log.Logger
 }
 
 type Auth struct {
 	IsTestnet bool
 	Key       string
 	Secret    string
+	// Signer, when set, signs the authentication in place of Key and Secret.
+	Signer bitmex.Signer
+	// ExpiryWindow is how long the authentication stays valid, 24 hours when zero.
+	ExpiryWindow time.Duration
+	// Clock, when set, gives the server time the expiry is computed from and
+	// is fed with the timestamps of the messages received, like the Clock of
+	// WithClock. Share it with the REST client through
+	// bitmex.Configuration.Clock.
+	Clock *bitmex.Clock
+}
+
+// signer returns the Signer authenticating the connection.
+func (p *Auth) signer() bitmex.Signer {
+	if p.Signer != nil {
+		return p.Signer
+	}
+	return bitmex.NewHMACSigner(p.Key, p.Secret)
 }
 
 func New(ctx context.Context, l *log.Logger) *Client {
@@ -141,20 +159,44 @@ func New(ctx context.Context, l *log.Logger) *Client {
 		auth = nil
 	}
 
-	endpoint := ENDPOINT
-	if auth.IsTestnet {
-		endpoint = ENDPOINTTESTNET
-	}
-
-	conn, _, err := websocket.DefaultDialer.Dial(endpoint, nil)
+	conn, _, err := websocket.DefaultDialer.Dial(endpoint(ctx), nil)
 	if err != nil {
+		l.Printf("dial %v", err)
 		return nil
 	}
+	clock, _ := ctx.Value(CLOCKKEY).(*bitmex.Clock)
+	if auth != nil && auth.Clock != nil {
+		clock = auth.Clock
+	}
 	return &Client{
-		conn: conn,
-		Auth: auth,
-		log:  l,
+		conn:  conn,
+		Auth:  auth,
+		clock: clock,
+		log:   l,
+	}
+}
+
+// WithEndpoint returns a copy of ctx connecting to endpoint in place of
+// ENDPOINT or ENDPOINTTESTNET, e.g. the URL of a realtimetest.Server.
+func WithEndpoint(ctx context.Context, endpoint string) context.Context {
+	return context.WithValue(ctx, ENDPOINTKEY, endpoint)
+}
+
+// WithClock returns a copy of ctx feeding clock with the server time of the
+// messages received, on public connections as on authenticated ones. Share it
+// with the REST client through bitmex.Configuration.Clock.
+func WithClock(ctx context.Context, clock *bitmex.Clock) context.Context {
+	return context.WithValue(ctx, CLOCKKEY, clock)
+}
+
+func endpoint(ctx context.Context) string {
+	if endpoint, ok := ctx.Value(ENDPOINTKEY).(string); ok && endpoint != "" {
+		return endpoint
+	}
+	if auth, ok := ctx.Value(AUTHKEY).(*Auth); ok && auth.IsTestnet {
+		return ENDPOINTTESTNET
 	}
+	return ENDPOINT
 }
 
 func NewAuth(isTestnet bool, key, secret string) context.Context {
@@ -165,6 +207,14 @@ func NewAuth(isTestnet bool, key, secret string) context.Context {
 	})
 }
 
+// NewAuthWithSigner is NewAuth for an API key whose secret is held by signer.
+func NewAuthWithSigner(isTestnet bool, signer bitmex.Signer) context.Context {
+	return context.WithValue(context.TODO(), AUTHKEY, &Auth{
+		IsTestnet: isTestnet,
+		Signer:    signer,
+	})
+}
+
 func (p *Client) Close() error {
 	if p.conn == nil {
 		return fmt.Errorf("connection no longer exists")
@@ -195,6 +245,7 @@ type Response struct {
 	Funding       []bitmex.Funding
 	Instrument    []bitmex.Instrument
 	Insurance     []bitmex.Insurance
+	Liquidation   []bitmex.Liquidation
 	Settlement    []bitmex.Settlement
 	Notifications []bitmex.Notification
 
@@ -214,11 +265,32 @@ type Response struct {
 	Transact                []bitmex.Transaction
 	Wallet                  []bitmex.Wallet
 
+	// The rows of update actions, only with the fields they carry, to Merge
+	// into the rows of earlier partial and insert actions.
+	InstrumentPatch  []bitmex.InstrumentPatch
+	LiquidationPatch []bitmex.LiquidationPatch
+	OrderbookLPatch  []bitmex.OrderBookL2Patch
+	OrderPatch       []bitmex.OrderPatch
+	MarginPatch      []bitmex.MarginPatch
+	PositionPatch    []bitmex.PositionPatch
+	WalletPatch      []bitmex.WalletPatch
+
 	Results error
 }
 
+// patches decodes the rows of an update action into patches.
+func patches[T any](action string, data []byte, patches *[]bitmex.Patch[T]) error {
+	if action != "update" {
+		return nil
+	}
+	return json.Unmarshal(data, patches)
+}
+
 func Connect(ctx context.Context, ch chan Response, channels, symbols []string, l *log.Logger) error {
 	p := New(ctx, l)
+	if p == nil {
+		return fmt.Errorf("can't connect to %s", endpoint(ctx))
+	}
 	defer p.Close()
 
 	// subscribe private
@@ -251,6 +323,24 @@ func Connect(ctx context.Context, ch chan Response, channels, symbols []string,
 
 			var r Response
 
+			// the welcome message carries the server time
+			if p.clock != nil {
+				if ts, err := jsonparser.GetString(msg, "timestamp"); err == nil {
+					if server, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+						p.clock.Observe(server, time.Now())
+					}
+				}
+			}
+
+			// error frames answer a request, e.g. a bad signature or an unknown table
+			if message, err := jsonparser.GetString(msg, "error"); err == nil {
+				status, _ := jsonparser.GetInt(msg, "status")
+				r.Types = Error
+				r.Results = fmt.Errorf("%d %s", status, message)
+				ch <- r
+				continue
+			}
+
 			name, err := jsonparser.GetString(msg, "table")
 			if err != nil {
 				continue
@@ -273,6 +363,9 @@ func Connect(ctx context.Context, ch chan Response, channels, symbols []string,
 				if err := json.Unmarshal(data, &r.OrderbookL); err != nil {
 					continue
 				}
+				if err := patches(r.Action, data, &r.OrderbookLPatch); err != nil {
+					continue
+				}
 
 			case strings.HasPrefix(name, "orderBook"):
 				r.Types = Orderbook
@@ -321,6 +414,9 @@ func Connect(ctx context.Context, ch chan Response, channels, symbols []string,
 				if err := json.Unmarshal(data, &r.Instrument); err != nil {
 					continue
 				}
+				if err := patches(r.Action, data, &r.InstrumentPatch); err != nil {
+					continue
+				}
 
 			case strings.HasPrefix(name, "insurance"):
 				r.Types = Insurance
@@ -328,6 +424,15 @@ func Connect(ctx context.Context, ch chan Response, channels, symbols []string,
 					continue
 				}
 
+			case strings.HasPrefix(name, "liquidation"):
+				r.Types = Liquidation
+				if err := json.Unmarshal(data, &r.Liquidation); err != nil {
+					continue
+				}
+				if err := patches(r.Action, data, &r.LiquidationPatch); err != nil {
+					continue
+				}
+
 			case strings.HasPrefix(name, "settlement"):
 				r.Types = Settlement
 				if err := json.Unmarshal(data, &r.Settlement); err != nil {
@@ -351,16 +456,25 @@ func Connect(ctx context.Context, ch chan Response, channels, symbols []string,
 				if err := json.Unmarshal(data, &r.Order); err != nil {
 					continue
 				}
+				if err := patches(r.Action, data, &r.OrderPatch); err != nil {
+					continue
+				}
 			case strings.HasPrefix(name, "margin"):
 				r.Types = Margin
 				if err := json.Unmarshal(data, &r.Margin); err != nil {
 					continue
 				}
+				if err := patches(r.Action, data, &r.MarginPatch); err != nil {
+					continue
+				}
 			case strings.HasPrefix(name, "position"):
 				r.Types = Position
 				if err := json.Unmarshal(data, &r.Position); err != nil {
 					continue
 				}
+				if err := patches(r.Action, data, &r.PositionPatch); err != nil {
+					continue
+				}
 			case strings.HasPrefix(name, "transact"):
 				r.Types = Transact
 				if err := json.Unmarshal(data, &r.Transact); err != nil {
@@ -371,6 +485,9 @@ func Connect(ctx context.Context, ch chan Response, channels, symbols []string,
 				if err := json.Unmarshal(data, &r.Wallet); err != nil {
 					continue
 				}
+				if err := patches(r.Action, data, &r.WalletPatch); err != nil {
+					continue
+				}
 			case strings.HasPrefix(name, "privateNotifications"):
 				r.Types = NotificationsForPrivate
 				if err := json.Unmarshal(data, &r.NotificationsForPrivate); err != nil {
@@ -425,6 +542,7 @@ func Connect(ctx context.Context, ch chan Response, channels, symbols []string,
 			// defer close()/unsubscribe()
 			return fmt.Errorf("context stop outside %v", err)
 		}
+		return fmt.Errorf("disconnect %v", err)
 	}
 
 	return nil
@@ -495,18 +613,23 @@ func (p *Client) ping(ctx context.Context) {
 }
 
 func (p *Client) signture() error {
-	// 24時間
-	expire := time.Now().UTC().Add(24 * 60 * time.Minute).Unix()
-	h := hmac.New(sha256.New, []byte(p.Auth.Secret))
-	payload := fmt.Sprintf("GET/realtime%d", expire)
-	h.Write([]byte(payload))
-	sign := hex.EncodeToString(h.Sum(nil))
+	window := p.Auth.ExpiryWindow
+	if window <= 0 {
+		// 24時間
+		window = 24 * time.Hour
+	}
+	expire := p.clock.Now().UTC().Add(window).Unix()
+	signer := p.Auth.signer()
+	sign, err := signer.Sign("GET", "/realtime", expire, nil)
+	if err != nil {
+		return err
+	}
 
 	var req = &Request{
 		Op: "authKeyExpires",
 		ID: 1,
 	}
-	req.Args = append(req.Args, p.Auth.Key)
+	req.Args = append(req.Args, signer.KeyID())
 	req.Args = append(req.Args, expire)
 	req.Args = append(req.Args, sign)
 
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-numb/go-bitmex"
 	"github.com/go-numb/go-bitmex/realtime"
+	"github.com/go-numb/go-bitmex/realtime/realtimetest"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -50,85 +52,150 @@ func TestParse(t *testing.T) {
 }
 
 func TestConnect(t *testing.T) {
-	// Only public
-	// ctx := context.Background()
-	// Both
-	ctx := realtime.NewAuth(os.Getenv("MEXKEY"), os.Getenv("MEXSECRET"))
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-	ch := make(chan realtime.Response, 10)
-	public := []string{
-		"trade",
-	}
-	private := []string{
-		"order",
-	}
-	symbols := []string{
-		"XBTUSD",
-		"ETHUSD",
+	script, err := realtimetest.LoadScript("testdata/trade.json")
+	if err != nil {
+		t.Fatal(err)
 	}
+	srv := realtimetest.NewServer(script)
+	defer srv.Close()
 
-	go realtime.Connect(ctx, ch, public, symbols, nil)
-	go realtime.Connect(ctx, ch, private, nil, nil)
-
-	for {
-		select {
-		case v := <-ch:
-			switch v.Types {
-			case realtime.OrderbookL:
-				fmt.Printf("%+v\n", v.OrderbookL)
-
-			case realtime.Orderbook:
-				fmt.Printf("%+v\n", v.Orderbook)
+	ctx := realtime.WithEndpoint(context.Background(), srv.URL)
+	ch := make(chan realtime.Response, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- realtime.Connect(ctx, ch, []string{"trade"}, []string{"XBTUSD"}, nil)
+	}()
+
+	partial := receive(t, ch)
+	assert.Equal(t, realtime.Trade, partial.Types)
+	assert.Equal(t, "partial", partial.Action)
+	if assert.Len(t, partial.Trade, 1) {
+		assert.Equal(t, 7024.5, bitmex.ToFloat(partial.Trade[0].Price))
+		assert.Equal(t, bitmex.SideBuy, partial.Trade[0].Side)
+	}
 
-			case realtime.Quote:
-				fmt.Printf("%+v\n", v.Quote)
+	insert := receive(t, ch)
+	assert.Equal(t, "insert", insert.Action)
+	if assert.Len(t, insert.Trade, 1) {
+		assert.Equal(t, 50, insert.Trade[0].Size)
+	}
 
-			case realtime.TradeBin:
-				fmt.Printf("%+v\n", v.TradeBin)
+	limited := receive(t, ch)
+	assert.Equal(t, realtime.Error, limited.Types)
+	assert.Contains(t, limited.Results.Error(), "429")
 
-			case realtime.Trade:
-				fmt.Printf("%+v\n", v.Trade)
+	select {
+	case err := <-done:
+		assert.Error(t, err, "forced disconnect")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Connect did not return on disconnect")
+	}
+}
 
-			case realtime.Settlement:
-				fmt.Printf("%+v\n", v.Settlement)
+func TestConnectClock(t *testing.T) {
+	script, err := realtimetest.LoadScript("testdata/trade.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := realtimetest.NewServer(script)
+	defer srv.Close()
+	srv.Now = func() time.Time { return time.Now().Add(10 * time.Second) }
 
-			case realtime.Funding:
-				fmt.Printf("%+v\n", v.Funding)
+	// a public connection feeds the clock with the time of the welcome message
+	clock := bitmex.NewClock()
+	ctx := realtime.WithClock(realtime.WithEndpoint(context.Background(), srv.URL), clock)
+	ch := make(chan realtime.Response, 10)
+	go realtime.Connect(ctx, ch, []string{"trade"}, []string{"XBTUSD"}, nil)
 
-			case realtime.Chat:
-				fmt.Printf("%+v\n", v.Chat)
+	receive(t, ch)
+	skew, samples, _ := clock.Estimate()
+	assert.Equal(t, 1, samples)
+	assert.InDelta(t, 10*time.Second, skew, float64(time.Second))
+}
 
-			case realtime.Announcement:
-				fmt.Printf("%+v\n", v.Announcement)
+func TestConnectAuth(t *testing.T) {
+	script, err := realtimetest.LoadScript("testdata/order.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := realtimetest.NewServer(script)
+	defer srv.Close()
+	srv.AddAccount("key", "secret")
 
-			case realtime.Connected:
-				fmt.Printf("%+v\n", v.Connected)
+	ctx := realtime.WithEndpoint(realtime.NewAuth(false, "key", "secret"), srv.URL)
+	ch := make(chan realtime.Response, 10)
+	go realtime.Connect(ctx, ch, []string{"order"}, nil, nil)
 
-			case realtime.Instrument:
-				fmt.Printf("%+v\n", v.Instrument)
+	for _, action := range []string{"partial", "insert", "update"} {
+		r := receive(t, ch)
+		assert.Equal(t, realtime.Order, r.Types)
+		assert.Equal(t, action, r.Action)
+	}
 
-			case realtime.Insurance:
-				fmt.Printf("%+v\n", v.Insurance)
+	// a wrong secret is refused, and so is the private subscription
+	ctx = realtime.WithEndpoint(realtime.NewAuth(false, "key", "wrong"), srv.URL)
+	go realtime.Connect(ctx, ch, []string{"order"}, nil, nil)
+	for _, message := range []string{"Signature not valid.", "no authorization was provided"} {
+		r := receive(t, ch)
+		if assert.Equal(t, realtime.Error, r.Types) {
+			assert.Contains(t, r.Results.Error(), message)
+		}
+	}
+}
 
-				/*
-					# Private
-				*/
-			case realtime.Wallet:
-				fmt.Printf("%+v\n", v.Wallet)
-			case realtime.Margin:
-				fmt.Printf("%+v\n", v.Margin)
-			case realtime.Execution:
-				fmt.Printf("%+v\n", v.Execution)
-			case realtime.Order:
-				fmt.Printf("%+v\n", v.Order)
-			case realtime.Position:
-				fmt.Printf("%+v\n", v.Position)
+func TestConnectLiquidation(t *testing.T) {
+	script, err := realtimetest.LoadScript("testdata/liquidation.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := realtimetest.NewServer(script)
+	defer srv.Close()
 
+	ctx := realtime.WithEndpoint(context.Background(), srv.URL)
+	ch := make(chan realtime.Response, 10)
+	go realtime.Connect(ctx, ch, []string{"liquidation"}, []string{"XBTUSD"}, nil)
+
+	var book bitmex.Liquidation
+	for _, tt := range []struct {
+		action    string
+		rows      int
+		patches   int
+		leavesQty int
+	}{
+		{"partial", 0, 0, 0},
+		{"insert", 1, 0, 2000},
+		{"update", 1, 1, 500},
+		{"delete", 1, 0, 500},
+	} {
+		r := receive(t, ch)
+		assert.Equal(t, realtime.Liquidation, r.Types, tt.action)
+		assert.Equal(t, tt.action, r.Action)
+		assert.Len(t, r.Liquidation, tt.rows, tt.action)
+		assert.Len(t, r.LiquidationPatch, tt.patches, tt.action)
+		switch tt.action {
+		case "insert":
+			book = r.Liquidation[0]
+		case "update":
+			if len(r.LiquidationPatch) == 1 {
+				p := r.LiquidationPatch[0]
+				assert.Equal(t, []string{"leavesQty", "orderID", "symbol"}, p.Fields())
+				book.Merge(p)
 			}
-
-		case <-ctx.Done():
-			break
 		}
+		assert.Equal(t, tt.leavesQty, book.LeavesQty, tt.action)
+	}
+	// the update left the fields it didn't send alone
+	assert.Equal(t, bitmex.SideSell, book.Side)
+	assert.Equal(t, 6950.5, bitmex.ToFloat(book.Price))
+}
+
+func receive(t *testing.T, ch chan realtime.Response) realtime.Response {
+	t.Helper()
+	select {
+	case r := <-ch:
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
 	}
+	return realtime.Response{}
 }
@@ -0,0 +1,159 @@
+package bitmex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// RemoteSigner is a Signer delegating to a signing service on a local socket,
+// see ServeSigner. The service holds the API secret; only the requests to sign
+// and their signatures cross the socket. Restrict access to the socket with
+// file permissions.
+type RemoteSigner struct {
+	network string
+	address string
+	// Timeout bounds every exchange with the service.
+	Timeout time.Duration
+
+	mu   sync.Mutex
+	key  string
+	conn net.Conn
+	enc  *json.Encoder
+	dec  *json.Decoder
+}
+
+// signRequest is a line sent to the signing service. A request without a
+// method only asks for the key ID.
+type signRequest struct {
+	Method  string `json:"method,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Expires int64  `json:"expires,omitempty"`
+	Body    []byte `json:"body,omitempty"`
+}
+
+// signResponse is the line answering a signRequest.
+type signResponse struct {
+	KeyID     string `json:"keyId"`
+	Signature string `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// DialSigner connects to the signing service at address, e.g.
+// DialSigner("unix", "/run/bitmex/signer.sock"), and fetches the ID of the key it signs for.
+func DialSigner(network, address string) (*RemoteSigner, error) {
+	s := &RemoteSigner{network: network, address: address, Timeout: 5 * time.Second}
+	res, err := s.call(signRequest{})
+	if err != nil {
+		return nil, err
+	}
+	s.key = res.KeyID
+	return s, nil
+}
+
+// KeyID implements Signer.
+func (s *RemoteSigner) KeyID() string {
+	return s.key
+}
+
+// Sign implements Signer.
+func (s *RemoteSigner) Sign(method, path string, expires int64, body []byte) (string, error) {
+	res, err := s.call(signRequest{Method: method, Path: path, Expires: expires, Body: body})
+	if err != nil {
+		return "", err
+	}
+	return res.Signature, nil
+}
+
+// Close closes the connection to the signing service.
+func (s *RemoteSigner) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// call sends req to the service and reads its response, dialing again once
+// when the connection has been lost.
+func (s *RemoteSigner) call(req signRequest) (signResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res signResponse
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if s.conn == nil {
+			if s.conn, err = net.DialTimeout(s.network, s.address, s.Timeout); err != nil {
+				break
+			}
+			s.enc, s.dec = json.NewEncoder(s.conn), json.NewDecoder(s.conn)
+		}
+		if res, err = s.exchange(req); err == nil {
+			break
+		}
+		s.conn.Close()
+		s.conn = nil
+	}
+	if err != nil {
+		return res, fmt.Errorf("bitmex: signer %s: %w", s.address, err)
+	}
+	if res.Error != "" {
+		return res, fmt.Errorf("bitmex: signer %s: %s", s.address, res.Error)
+	}
+	return res, nil
+}
+
+func (s *RemoteSigner) exchange(req signRequest) (res signResponse, err error) {
+	if s.Timeout > 0 {
+		s.conn.SetDeadline(time.Now().Add(s.Timeout))
+	}
+	if err = s.enc.Encode(req); err != nil {
+		return res, err
+	}
+	err = s.dec.Decode(&res)
+	return res, err
+}
+
+// ServeSigner answers the RemoteSigners connecting to l with signer, typically
+// an HMACSigner living in a process of its own. It returns nil once l is closed.
+func ServeSigner(l net.Listener, signer Signer) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go serveSigner(conn, signer)
+	}
+}
+
+func serveSigner(conn net.Conn, signer Signer) {
+	defer conn.Close()
+	dec, enc := json.NewDecoder(conn), json.NewEncoder(conn)
+	for {
+		var req signRequest
+		if err := dec.Decode(&req); err != nil {
+			return
+		}
+		res := signResponse{KeyID: signer.KeyID()}
+		if req.Method != "" {
+			sig, err := signer.Sign(req.Method, req.Path, req.Expires, req.Body)
+			if err != nil {
+				res.Error = err.Error()
+			}
+			res.Signature = sig
+		}
+		if err := enc.Encode(res); err != nil {
+			return
+		}
+	}
+}
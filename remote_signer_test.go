@@ -0,0 +1,151 @@
+package bitmex_test
+
+import (
+	"bufio"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// signerListener is a unix socket listener keeping the connections it
+// accepted, so that a test can drop them.
+type signerListener struct {
+	net.Listener
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func listenSigner(t *testing.T, path string, signer bitmex.Signer) *signerListener {
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sl := &signerListener{Listener: l}
+	go bitmex.ServeSigner(sl, signer)
+	t.Cleanup(func() { sl.Close() })
+	return sl
+}
+
+func (l *signerListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.mu.Lock()
+		l.conns = append(l.conns, conn)
+		l.mu.Unlock()
+	}
+	return conn, err
+}
+
+// drop closes the connections accepted so far.
+func (l *signerListener) drop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, conn := range l.conns {
+		conn.Close()
+	}
+	l.conns = nil
+}
+
+func TestRemoteSigner(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signer.sock")
+	local := bitmex.NewHMACSigner("key", "secret")
+	l := listenSigner(t, path, local)
+
+	remote, err := bitmex.DialSigner("unix", path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer remote.Close()
+	assert.Equal(t, "key", remote.KeyID())
+
+	sign := func(name string) {
+		want, _ := local.Sign("POST", "/api/v1/order", 1518064238, []byte(`{"symbol":"XBTUSD"}`))
+		got, err := remote.Sign("POST", "/api/v1/order", 1518064238, []byte(`{"symbol":"XBTUSD"}`))
+		assert.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+	sign("round trip")
+
+	// The signer dials again once the service dropped the connection.
+	l.drop()
+	sign("after a drop")
+
+	// It fails while the service is down, and recovers once it is back.
+	l.Close()
+	l.drop()
+	_, err = remote.Sign("GET", "/api/v1/user", 1518064238, nil)
+	assert.ErrorContains(t, err, "bitmex: signer "+path)
+	l = listenSigner(t, path, local)
+	sign("after a restart")
+
+	// Sign can be called from several goroutines.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sign("concurrent")
+		}()
+	}
+	wg.Wait()
+
+	assert.NoError(t, remote.Close())
+	sign("after Close")
+}
+
+func TestRemoteSignerErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	// The error of the service signer is returned, and the connection kept.
+	path := filepath.Join(dir, "failing.sock")
+	listenSigner(t, path, failingSigner{})
+	remote, err := bitmex.DialSigner("unix", path)
+	if assert.NoError(t, err) {
+		defer remote.Close()
+		assert.Equal(t, "key", remote.KeyID())
+		for i := 0; i < 2; i++ {
+			_, err = remote.Sign("GET", "/api/v1/user", 1518064238, nil)
+			assert.ErrorContains(t, err, errRefused.Error())
+		}
+	}
+
+	// A service going silent after telling its key ID times out.
+	path = filepath.Join(dir, "silent.sock")
+	l, err := net.Listen("unix", path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer l.Close()
+	go func() {
+		for first := true; ; first = false {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			if first {
+				bufio.NewReader(conn).ReadString('\n')
+				conn.Write([]byte(`{"keyId":"key"}` + "\n"))
+			}
+		}
+	}()
+	remote, err = bitmex.DialSigner("unix", path)
+	if assert.NoError(t, err) {
+		defer remote.Close()
+		remote.Timeout = 50 * time.Millisecond
+		start := time.Now()
+		_, err = remote.Sign("GET", "/api/v1/user", 1518064238, nil)
+		assert.ErrorContains(t, err, "timeout")
+		assert.Less(t, time.Since(start), time.Second)
+	}
+
+	_, err = bitmex.DialSigner("unix", filepath.Join(dir, "missing.sock"))
+	assert.Error(t, err)
+}
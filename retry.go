@@ -0,0 +1,118 @@
+package bitmex
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// RetryPolicy controls how calls failing with an overload, a rate limit or a
+// transport error are retried.
+//
+// GET operations are always safe to retry. Calls that change state are only
+// retried when every order they carry has a ClOrdID, so that a request which
+// did reach the exchange is rejected as a duplicate instead of being executed twice.
+// Errors of an Interceptor or of the Signer are never retried.
+type RetryPolicy struct {
+	MaxAttempts int           // attempts including the first one; 1 or less disables retries
+	MinBackoff  time.Duration // delay before the first retry, doubled for every further one
+	MaxBackoff  time.Duration // upper bound of the delay
+	Jitter      float64       // fraction of the delay that is randomised, between 0 and 1
+}
+
+// NewRetryPolicy returns a RetryPolicy making up to three attempts.
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// backoff returns the delay before the given retry (1 for the first one).
+// A Retry-After received from the server takes precedence when it is longer.
+func (p *RetryPolicy) backoff(retry int, err error) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		d -= time.Duration(p.Jitter * rand.Float64() * float64(d))
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > d {
+		d = apiErr.RetryAfter
+	}
+	return d
+}
+
+// shouldRetry reports whether err is worth another attempt of call.
+func shouldRetry(call *Call, err error) bool {
+	var apiErr *APIError
+	switch {
+	case errors.As(err, new(abort)):
+		// refused by an interceptor or the signer, before or after sending
+		return false
+	case errors.As(err, &apiErr):
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		default:
+			return false
+		}
+	case errors.Is(err, ErrRateLimited):
+		// held back by the client side limiter, configured to fail fast
+		return false
+	case call.Request.Context().Err() != nil:
+		return false
+	}
+	return call.Request.Method == http.MethodGet || hasClOrdID(call.Options)
+}
+
+// hasClOrdID reports whether the *XxxOpts struct opts identifies its orders by
+// ClOrdID: either through a set ClOrdID field, or through an Orders JSON array
+// whose elements all carry a clOrdID.
+func hasClOrdID(opts interface{}) bool {
+	v := reflect.ValueOf(opts)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	v = v.Elem()
+
+	if f := v.FieldByName("ClOrdID"); f.IsValid() {
+		if s, ok := f.Addr().Interface().(interface {
+			IsSet() bool
+			Value() string
+		}); ok {
+			return s.IsSet() && s.Value() != ""
+		}
+	}
+
+	if f := v.FieldByName("Orders"); f.IsValid() {
+		if s, ok := f.Addr().Interface().(interface {
+			IsSet() bool
+			Value() string
+		}); ok && s.IsSet() {
+			var orders []struct {
+				ClOrdID string `json:"clOrdID"`
+			}
+			if err := json.Unmarshal([]byte(s.Value()), &orders); err != nil || len(orders) == 0 {
+				return false
+			}
+			for _, o := range orders {
+				if o.ClOrdID == "" {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package bitmex_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errRefused = errors.New("refused")
+
+type failingSigner struct{}
+
+func (failingSigner) KeyID() string { return "key" }
+func (failingSigner) Sign(method, path string, expires int64, body []byte) (string, error) {
+	return "", errRefused
+}
+
+func TestRetry(t *testing.T) {
+	var sent int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&sent, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":{"message":"The system is currently overloaded. Please try again later.","name":"HTTPError"}}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	refuse := func(*bitmex.Call) error { return errRefused }
+	for _, tt := range []struct {
+		name         string
+		ctx          context.Context
+		interceptor  bitmex.Interceptor
+		err          error
+		sent, called int32
+	}{
+		{"overloaded", context.Background(), bitmex.Interceptor{}, nil, 3, 3},
+		{"interceptor before sign", context.Background(), bitmex.Interceptor{BeforeSign: refuse}, errRefused, 0, 1},
+		{"interceptor after sign", context.Background(), bitmex.Interceptor{AfterSign: refuse}, errRefused, 0, 1},
+		{"interceptor after response", context.Background(), bitmex.Interceptor{AfterResponse: refuse}, errRefused, 1, 1},
+		{"signer", bitmex.WithSigner(context.Background(), failingSigner{}), bitmex.Interceptor{}, errRefused, 0, 1},
+	} {
+		atomic.StoreInt32(&sent, 0)
+		var called int32
+		count := func(*bitmex.Call) error {
+			atomic.AddInt32(&called, 1)
+			return nil
+		}
+
+		cfg := bitmex.NewConfiguration()
+		cfg.BasePath = srv.URL
+		cfg.Retry = &bitmex.RetryPolicy{MaxAttempts: 5, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+		cfg.Interceptors = []bitmex.Interceptor{{BeforeSign: count}, tt.interceptor}
+		_, _, err := bitmex.NewAPIClient(cfg).TradeApi.TradeGet(tt.ctx, nil)
+
+		if tt.err == nil {
+			assert.NoError(t, err, tt.name)
+		} else {
+			assert.Equal(t, tt.err, err, tt.name)
+		}
+		assert.Equal(t, tt.sent, atomic.LoadInt32(&sent), tt.name+": requests sent")
+		assert.Equal(t, tt.called, atomic.LoadInt32(&called), tt.name+": attempts")
+	}
+}
@@ -0,0 +1,411 @@
+package bitmex
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrOrderRejected is returned by the safe submit methods for orders the matching engine rejected.
+	ErrOrderRejected = errors.New("bitmex: order rejected")
+	// ErrUnknownOutcome is returned by the safe submit methods when it could not be
+	// determined whether a submission reached the book.
+	ErrUnknownOutcome = errors.New("bitmex: order outcome unknown")
+)
+
+// SubmitStatus is the outcome of a safe submission.
+type SubmitStatus int
+
+const (
+	// SubmitUnknown means that the outcome could not be determined.
+	SubmitUnknown SubmitStatus = iota
+	// SubmitAccepted means that BitMEX knows the order; SubmitResult.Order holds it.
+	SubmitAccepted
+	// SubmitRejected means that the order was refused or never reached BitMEX.
+	SubmitRejected
+)
+
+func (s SubmitStatus) String() string {
+	switch s {
+	case SubmitAccepted:
+		return "accepted"
+	case SubmitRejected:
+		return "rejected"
+	}
+	return "unknown"
+}
+
+// SubmitResult is the definitive result of a safe submission.
+type SubmitResult struct {
+	Status  SubmitStatus
+	ClOrdID string
+	// Order is the order as BitMEX reported it, set when Status is SubmitAccepted
+	// or when the matching engine rejected it.
+	Order Order
+	// Err is nil for accepted orders and explains the outcome otherwise.
+	Err error
+}
+
+// SafeSubmitOpts tunes how the safe submit methods resolve submissions whose
+// outcome is unknown. The zero value, like a nil pointer, uses the defaults.
+type SafeSubmitOpts struct {
+	Probes        int           // OrderGetOrders lookups before giving up, 3 by default
+	ProbeInterval time.Duration // delay before every lookup, 1s by default
+	ProbeTimeout  time.Duration // timeout of a single lookup, 10s by default
+	ClOrdIDPrefix string        // prefix of the generated ClOrdIDs, at most 20 characters
+}
+
+func (o *SafeSubmitOpts) withDefaults() SafeSubmitOpts {
+	var safe SafeSubmitOpts
+	if o != nil {
+		safe = *o
+	}
+	if safe.Probes <= 0 {
+		safe.Probes = 3
+	}
+	if safe.ProbeInterval <= 0 {
+		safe.ProbeInterval = time.Second
+	}
+	if safe.ProbeTimeout <= 0 {
+		safe.ProbeTimeout = 10 * time.Second
+	}
+	return safe
+}
+
+/*
+SafeOrderNew creates a new order like OrderNew, assigning it a ClOrdID when none is given.
+When the outcome of the request is unknown, because of a transport failure, a timeout or
+a gateway error, the order is looked up by its ClOrdID to return a definitive result.
+
+An order that cannot be found is only reported as rejected once the api-expires of the
+last request sending it has passed, as BitMEX refuses expired requests; before that it
+stays unknown. The lookups stop when ctx is done, after a single one when it already is.
+The returned error is nil for accepted orders and equals SubmitResult.Err otherwise.
+*/
+func (a *OrderApiService) SafeOrderNew(ctx context.Context, symbol string, localVarOptionals *OrderNewOpts, safe *SafeSubmitOpts) (SubmitResult, error) {
+	s := safe.withDefaults()
+
+	var opts OrderNewOpts
+	if localVarOptionals != nil {
+		opts = *localVarOptionals
+	}
+	generated := !opts.ClOrdID.IsSet() || opts.ClOrdID.Value() == ""
+	if generated {
+		id, err := newClOrdID(s.ClOrdIDPrefix)
+		if err != nil {
+			return SubmitResult{Err: err}, err
+		}
+		opts.ClOrdID.Set(id)
+	}
+	clOrdID := opts.ClOrdID.Value()
+
+	sctx, sub := a.withSubmission(ctx)
+	order, _, err := a.OrderNew(sctx, symbol, &opts)
+
+	results := a.settle(ctx, []string{clOrdID}, sub, generated, []Order{order}, err, s)
+	return results[0], results[0].Err
+}
+
+/*
+SafeOrderAmend amends an order like OrderAmend and resolves an unknown outcome by
+looking the order up. When OrigClOrdID is given and ClOrdID is not, a new ClOrdID is
+assigned and the amendment is confirmed by finding it; otherwise the order found by
+OrderID must carry every amended value.
+*/
+func (a *OrderApiService) SafeOrderAmend(ctx context.Context, localVarOptionals *OrderAmendOpts, safe *SafeSubmitOpts) (SubmitResult, error) {
+	s := safe.withDefaults()
+
+	var opts OrderAmendOpts
+	if localVarOptionals != nil {
+		opts = *localVarOptionals
+	}
+	generated := opts.OrigClOrdID.IsSet() && (!opts.ClOrdID.IsSet() || opts.ClOrdID.Value() == "")
+	if generated {
+		id, err := newClOrdID(s.ClOrdIDPrefix)
+		if err != nil {
+			return SubmitResult{Err: err}, err
+		}
+		opts.ClOrdID.Set(id)
+	}
+
+	sctx, sub := a.withSubmission(ctx)
+	order, _, err := a.OrderAmend(sctx, &opts)
+
+	if opts.ClOrdID.IsSet() && opts.ClOrdID.Value() != "" {
+		results := a.settle(ctx, []string{opts.ClOrdID.Value()}, sub, generated, []Order{order}, err, s)
+		return results[0], results[0].Err
+	}
+
+	r := SubmitResult{}
+	if !outcomeUnknown(err, sub.resent()) {
+		r = result(r, order, err)
+		return r, r.Err
+	}
+
+	// Without a ClOrdID the amendment shows only in the values of the order.
+	orders, definitive := a.probe(ctx, map[string]interface{}{"orderID": opts.OrderID.Value()}, sub.expires, s, func(found []Order) bool {
+		return len(found) > 0 && amended(found[0], &opts)
+	})
+	switch {
+	case len(orders) > 0 && amended(orders[0], &opts):
+		r = result(r, orders[0], nil)
+	case definitive:
+		r.Status, r.Err = SubmitRejected, err
+	default:
+		r.Status, r.Err = SubmitUnknown, fmt.Errorf("%w: %v", ErrUnknownOutcome, err)
+	}
+	return r, r.Err
+}
+
+/*
+SafeOrderNewBulk creates multiple orders like OrderNewBulk, assigning a ClOrdID to
+every order that has none, and resolves an unknown outcome like SafeOrderNew.
+It returns one SubmitResult per order, in the order of localVarOptionals.Orders;
+the error is only set when the orders cannot be prepared.
+*/
+func (a *OrderApiService) SafeOrderNewBulk(ctx context.Context, localVarOptionals *OrderNewBulkOpts, safe *SafeSubmitOpts) ([]SubmitResult, error) {
+	s := safe.withDefaults()
+
+	var orders []map[string]interface{}
+	if localVarOptionals != nil && localVarOptionals.Orders.IsSet() {
+		if err := json.Unmarshal([]byte(localVarOptionals.Orders.Value()), &orders); err != nil {
+			return nil, err
+		}
+	}
+	if len(orders) == 0 {
+		return nil, reportError("orders is required and must be a JSON array of orders")
+	}
+
+	clOrdIDs := make([]string, len(orders))
+	generated := true
+	for i, o := range orders {
+		id, _ := o["clOrdID"].(string)
+		if id == "" {
+			var err error
+			if id, err = newClOrdID(s.ClOrdIDPrefix); err != nil {
+				return nil, err
+			}
+			o["clOrdID"] = id
+		} else {
+			generated = false
+		}
+		clOrdIDs[i] = id
+	}
+	b, err := json.Marshal(orders)
+	if err != nil {
+		return nil, err
+	}
+	var opts OrderNewBulkOpts
+	opts.Orders.Set(string(b))
+
+	sctx, sub := a.withSubmission(ctx)
+	placed, _, err := a.OrderNewBulk(sctx, &opts)
+
+	return a.settle(ctx, clOrdIDs, sub, generated, placed, err, s), nil
+}
+
+// contextSubmission carries the *submission in which sign records the requests
+// it signs.
+var contextSubmission = contextKey("submission")
+
+// submission records the requests sending an order: their number, retries
+// included, and the api-expires of the last one.
+type submission struct {
+	expires time.Time
+	sent    int
+}
+
+// resent reports whether the order was sent more than once, so that BitMEX
+// may refuse it as a duplicate of itself.
+func (s *submission) resent() bool {
+	return s.sent > 1
+}
+
+// withSubmission returns a context recording the requests signed with it. Until
+// one is, the api-expires is that of a request signed now.
+func (a *OrderApiService) withSubmission(ctx context.Context) (context.Context, *submission) {
+	sub := &submission{expires: a.client.cfg.Clock.Now().Add(a.client.expiryWindow())}
+	return context.WithValue(ctx, contextSubmission, sub), sub
+}
+
+// settle turns the outcome of submitting the orders identified by clOrdIDs into
+// one SubmitResult per order, probing for them when the outcome is unknown. A
+// duplicate clOrdID leaves the outcome unknown when the clOrdIDs were generated
+// for the submission or sent more than once; otherwise it is a rejection.
+func (a *OrderApiService) settle(ctx context.Context, clOrdIDs []string, sub *submission, generated bool, placed []Order, err error, safe SafeSubmitOpts) []SubmitResult {
+	results := make([]SubmitResult, len(clOrdIDs))
+	for i, id := range clOrdIDs {
+		results[i].ClOrdID = id
+	}
+
+	if !outcomeUnknown(err, generated || sub.resent()) {
+		byID := ordersByClOrdID(placed)
+		for i := range results {
+			o, ok := byID[results[i].ClOrdID]
+			if !ok && len(placed) == 1 && len(results) == 1 {
+				// Single order endpoints return the order without a doubt.
+				o, ok = placed[0], true
+			}
+			if err == nil && !ok {
+				results[i].Status, results[i].Err = SubmitUnknown, fmt.Errorf("%w: order missing from the response", ErrUnknownOutcome)
+				continue
+			}
+			results[i] = result(results[i], o, err)
+		}
+		return results
+	}
+
+	var filter interface{} = clOrdIDs
+	if len(clOrdIDs) == 1 {
+		filter = clOrdIDs[0]
+	}
+	found, definitive := a.probe(ctx, map[string]interface{}{"clOrdID": filter}, sub.expires, safe, func(found []Order) bool {
+		return len(ordersByClOrdID(found)) == len(clOrdIDs)
+	})
+
+	byID := ordersByClOrdID(found)
+	for i := range results {
+		if o, ok := byID[results[i].ClOrdID]; ok {
+			results[i] = result(results[i], o, nil)
+		} else if definitive {
+			results[i].Status, results[i].Err = SubmitRejected, err
+		} else {
+			results[i].Status, results[i].Err = SubmitUnknown, fmt.Errorf("%w: %v", ErrUnknownOutcome, err)
+		}
+	}
+	return results
+}
+
+// probe looks up the orders matching filter until done reports that all of them
+// were found, safe.Probes lookups were made or ctx is done. Not finding an order
+// is definitive when a lookup succeeded after expires, the api-expires of the
+// submission.
+func (a *OrderApiService) probe(ctx context.Context, filter map[string]interface{}, expires time.Time, safe SafeSubmitOpts, done func([]Order) bool) (orders []Order, definitive bool) {
+	b, err := json.Marshal(filter)
+	if err != nil {
+		return nil, false
+	}
+	var opts OrderGetOrdersOpts
+	opts.Filter.Set(string(b))
+	opts.Reverse.Set(true)
+	opts.Count.Set(500)
+
+	// The submission may have failed because ctx is done; one lookup still has
+	// to run then, with the credentials and metadata of ctx.
+	base := context.WithoutCancel(ctx)
+	for i := 0; i < safe.Probes; i++ {
+		if i > 0 || ctx.Err() == nil {
+			timer := time.NewTimer(safe.ProbeInterval)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return orders, definitive
+			}
+		}
+
+		pctx, cancel := context.WithTimeout(base, safe.ProbeTimeout)
+		found, _, err := a.OrderGetOrders(pctx, &opts)
+		cancel()
+		if err != nil {
+			continue
+		}
+		orders = found
+		if done(found) {
+			return orders, true
+		}
+		definitive = a.client.cfg.Clock.Now().After(expires)
+		if definitive {
+			return orders, true
+		}
+	}
+	return orders, definitive
+}
+
+// outcomeUnknown reports whether err leaves open whether a submission reached
+// the book. A duplicate clOrdID does only when the clOrdID may be that of the
+// submission itself, having gone through on an earlier attempt.
+func outcomeUnknown(err error, ownClOrdID bool) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadGateway, http.StatusGatewayTimeout:
+			return true
+		}
+		// A retried submission that already went through is refused as a duplicate.
+		return ownClOrdID && errors.Is(apiErr, ErrDuplicateClOrdID)
+	}
+	// Requests held back by the client side limiter were never sent.
+	return !errors.Is(err, ErrRateLimited)
+}
+
+// result completes r from the order BitMEX reported and the error of the request.
+func result(r SubmitResult, o Order, err error) SubmitResult {
+	switch {
+	case err != nil:
+		r.Status, r.Err = SubmitRejected, err
+	case o.OrdStatus == OrdStatusRejected:
+		r.Status, r.Order = SubmitRejected, o
+		r.Err = fmt.Errorf("%w: %s", ErrOrderRejected, o.OrdRejReason)
+	default:
+		r.Status, r.Order = SubmitAccepted, o
+	}
+	if r.ClOrdID == "" {
+		r.ClOrdID = o.ClOrdID
+	}
+	return r
+}
+
+// amended reports whether o carries every value set in opts.
+func amended(o Order, opts *OrderAmendOpts) bool {
+	switch {
+	case opts.Price.IsSet() && o.Price != opts.Price.Value():
+	case opts.StopPx.IsSet() && o.StopPx != opts.StopPx.Value():
+	case opts.OrderQty.IsSet() && o.OrderQty != opts.OrderQty.Value():
+	case opts.LeavesQty.IsSet() && o.LeavesQty != opts.LeavesQty.Value():
+	case opts.PegOffsetValue.IsSet() && o.PegOffsetValue != opts.PegOffsetValue.Value():
+	default:
+		return true
+	}
+	return false
+}
+
+func ordersByClOrdID(orders []Order) map[string]Order {
+	m := make(map[string]Order, len(orders))
+	for _, o := range orders {
+		if o.ClOrdID != "" {
+			m[o.ClOrdID] = o
+		}
+	}
+	return m
+}
+
+// maxClOrdIDPrefix is the longest prefix newClOrdID takes, leaving 16 random
+// hex characters of the 36 BitMEX allows.
+const maxClOrdIDPrefix = 20
+
+// newClOrdID returns prefix followed by random hex, at most 36 characters long
+// as BitMEX requires.
+func newClOrdID(prefix string) (string, error) {
+	if len(prefix) > maxClOrdIDPrefix {
+		return "", reportError("clOrdID prefix %q longer than %d characters", prefix, maxClOrdIDPrefix)
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	id := prefix + hex.EncodeToString(b)
+	if len(id) > 36 {
+		id = id[:36]
+	}
+	return id, nil
+}
@@ -0,0 +1,196 @@
+package bitmex_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-numb/go-bitmex"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// submitServer answers order submissions with submit and order lookups with
+// the orders whose clOrdID it received, when found is set.
+type submitServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	clOrdIDs []string
+	lookups  int
+}
+
+func newSubmitServer(submit func(w http.ResponseWriter, r *http.Request, attempt int), found bool) *submitServer {
+	s := &submitServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// no Date header, which would move the clock of the client
+		w.Header()["Date"] = nil
+		w.Header().Set("Content-Type", "application/json")
+		s.mu.Lock()
+		if r.Method == http.MethodGet {
+			s.lookups++
+			ids := s.clOrdIDs
+			s.mu.Unlock()
+			if found && len(ids) > 0 && strings.Contains(r.URL.Query().Get("filter"), ids[0]) {
+				w.Write([]byte(`[{"orderID":"o1","clOrdID":"` + ids[0] + `","ordStatus":"New"}]`))
+				return
+			}
+			w.Write([]byte(`[]`))
+			return
+		}
+		r.ParseForm()
+		s.clOrdIDs = append(s.clOrdIDs, r.PostForm.Get("clOrdID"))
+		attempt := len(s.clOrdIDs)
+		s.mu.Unlock()
+		submit(w, r, attempt)
+	}))
+	return s
+}
+
+func (s *submitServer) client(configure func(*bitmex.Configuration)) *bitmex.APIClient {
+	cfg := bitmex.NewConfiguration()
+	cfg.BasePath = s.URL
+	if configure != nil {
+		configure(cfg)
+	}
+	return bitmex.NewAPIClient(cfg)
+}
+
+func hang(w http.ResponseWriter, r *http.Request, attempt int) {
+	select {
+	case <-r.Context().Done():
+	case <-time.After(5 * time.Second):
+	}
+}
+
+func TestSafeOrderNew(t *testing.T) {
+	// The submission times out, and the lookup made once ctx is done finds it.
+	srv := newSubmitServer(hang, true)
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(bitmex.NewAPIKeyContext("key", "secret"), 50*time.Millisecond)
+	defer cancel()
+	res, err := srv.client(nil).OrderApi.SafeOrderNew(ctx, "XBTUSD", nil, &bitmex.SafeSubmitOpts{ClOrdIDPrefix: "test-"})
+	assert.NoError(t, err)
+	assert.Equal(t, bitmex.SubmitAccepted, res.Status)
+	assert.Equal(t, "o1", res.Order.OrderID)
+	assert.True(t, strings.HasPrefix(res.ClOrdID, "test-"))
+	assert.Equal(t, 1, srv.lookups)
+}
+
+func TestSafeOrderNewCanceled(t *testing.T) {
+	// A gateway error leaves the outcome unknown; canceling ctx ends the lookups.
+	srv := newSubmitServer(func(w http.ResponseWriter, r *http.Request, attempt int) {
+		w.WriteHeader(http.StatusBadGateway)
+	}, false)
+	defer srv.Close()
+	ctx, cancel := context.WithCancel(bitmex.NewAPIKeyContext("key", "secret"))
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	res, err := srv.client(nil).OrderApi.SafeOrderNew(ctx, "XBTUSD", nil, &bitmex.SafeSubmitOpts{ProbeInterval: time.Minute})
+	assert.ErrorIs(t, err, bitmex.ErrUnknownOutcome)
+	assert.Equal(t, bitmex.SubmitUnknown, res.Status)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, 0, srv.lookups)
+}
+
+func TestSafeOrderNewRetried(t *testing.T) {
+	// The first attempt is shed, and the retry signed two seconds later times
+	// out: not finding the order once the api-expires of the first attempt has
+	// passed is not definitive, as the retry is still valid.
+	srv := newSubmitServer(func(w http.ResponseWriter, r *http.Request, attempt int) {
+		if attempt == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":{"message":"The system is currently overloaded. Please try again later.","name":"HTTPError"}}`))
+			return
+		}
+		hang(w, r, attempt)
+	}, false)
+	defer srv.Close()
+	client := srv.client(func(cfg *bitmex.Configuration) {
+		cfg.ExpiryWindow = 2 * time.Second
+		cfg.HTTPClient = &http.Client{Timeout: 200 * time.Millisecond}
+		cfg.Retry = &bitmex.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}
+	})
+
+	res, err := client.OrderApi.SafeOrderNew(bitmex.NewAPIKeyContext("key", "secret"), "XBTUSD", nil,
+		&bitmex.SafeSubmitOpts{Probes: 1, ProbeInterval: 10 * time.Millisecond})
+	assert.ErrorIs(t, err, bitmex.ErrUnknownOutcome)
+	assert.Equal(t, bitmex.SubmitUnknown, res.Status)
+	assert.Len(t, srv.clOrdIDs, 2)
+	assert.Equal(t, 1, srv.lookups)
+}
+
+func TestSafeSubmitClOrdIDPrefix(t *testing.T) {
+	srv := newSubmitServer(func(w http.ResponseWriter, r *http.Request, attempt int) {
+		w.Write([]byte(`{"orderID":"o1","ordStatus":"New"}`))
+	}, false)
+	defer srv.Close()
+	orders := srv.client(nil).OrderApi
+	ctx := bitmex.NewAPIKeyContext("key", "secret")
+
+	prefix := strings.Repeat("p", 20)
+	for i := 0; i < 2; i++ {
+		res, err := orders.SafeOrderNew(ctx, "XBTUSD", nil, &bitmex.SafeSubmitOpts{ClOrdIDPrefix: prefix})
+		assert.NoError(t, err)
+		assert.Len(t, res.ClOrdID, 36)
+		assert.True(t, strings.HasPrefix(res.ClOrdID, prefix))
+	}
+	assert.NotEqual(t, srv.clOrdIDs[0], srv.clOrdIDs[1])
+
+	_, err := orders.SafeOrderNew(ctx, "XBTUSD", nil, &bitmex.SafeSubmitOpts{ClOrdIDPrefix: prefix + "p"})
+	assert.Error(t, err)
+	assert.Len(t, srv.clOrdIDs, 2, "nothing sent with a prefix too long")
+}
+
+func TestSafeOrderNewDuplicate(t *testing.T) {
+	duplicate := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"message":"Duplicate clOrdID","name":"HTTPError"}}`))
+	}
+	for _, tt := range []struct {
+		name    string
+		clOrdID string // of the caller, generated when empty
+		retried bool   // the first attempt fails with a gateway error
+		status  bitmex.SubmitStatus
+		lookups int
+	}{
+		// A clOrdID of the caller already in use is refused, without a lookup.
+		{name: "clOrdID of the caller", clOrdID: "mine", status: bitmex.SubmitRejected},
+		// The retry is refused because the first attempt went through.
+		{name: "retried", clOrdID: "mine", retried: true, status: bitmex.SubmitAccepted, lookups: 1},
+		{name: "generated clOrdID", status: bitmex.SubmitAccepted, lookups: 1},
+	} {
+		srv := newSubmitServer(func(w http.ResponseWriter, r *http.Request, attempt int) {
+			if tt.retried && attempt == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			duplicate(w)
+		}, true)
+		client := srv.client(func(cfg *bitmex.Configuration) {
+			cfg.Retry = &bitmex.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}
+		})
+
+		var opts bitmex.OrderNewOpts
+		if tt.clOrdID != "" {
+			opts.ClOrdID.Set(tt.clOrdID)
+		}
+		res, err := client.OrderApi.SafeOrderNew(bitmex.NewAPIKeyContext("key", "secret"), "XBTUSD", &opts,
+			&bitmex.SafeSubmitOpts{ProbeInterval: time.Millisecond})
+		srv.Close()
+		assert.Equal(t, tt.status, res.Status, tt.name)
+		assert.Equal(t, tt.lookups, srv.lookups, tt.name)
+		if tt.status == bitmex.SubmitRejected {
+			assert.ErrorIs(t, err, bitmex.ErrDuplicateClOrdID, tt.name)
+		} else {
+			assert.NoError(t, err, tt.name)
+			assert.Equal(t, "o1", res.Order.OrderID, tt.name)
+		}
+	}
+}
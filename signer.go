@@ -0,0 +1,42 @@
+package bitmex
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Signer authenticates requests on behalf of an API key, so that the secret
+// need not be known to the process sending them.
+type Signer interface {
+	// KeyID returns the public part of the API key, sent in the api-key header.
+	KeyID() string
+	// Sign returns the hex encoded signature of a request. path includes the
+	// query string, expires is a unix time in seconds.
+	Sign(method, path string, expires int64, body []byte) (string, error)
+}
+
+// HMACSigner signs with an API secret held in memory.
+type HMACSigner struct {
+	key    string
+	secret []byte
+}
+
+// NewHMACSigner returns a Signer for the API key key and its secret.
+func NewHMACSigner(key, secret string) *HMACSigner {
+	return &HMACSigner{key: key, secret: []byte(secret)}
+}
+
+// KeyID implements Signer.
+func (s *HMACSigner) KeyID() string {
+	return s.key
+}
+
+// Sign implements Signer with HMAC-SHA256 over method + path + expires + body.
+func (s *HMACSigner) Sign(method, path string, expires int64, body []byte) (string, error) {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(method + path + strconv.FormatInt(expires, 10)))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
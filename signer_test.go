@@ -0,0 +1,34 @@
+package bitmex_test
+
+import (
+	"testing"
+
+	"github.com/go-numb/go-bitmex"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHMACSigner(t *testing.T) {
+	// The examples of the BitMEX API key documentation, signed as the client
+	// always has: method + path and query + expires + body.
+	signer := bitmex.NewHMACSigner("LAqUlngMIQkIUjXMUreyu3qn", "chNOOS4KvNXR_Xq4k4c9qsfoKWvnDecLATCRlcBwyKDYnWgO")
+	assert.Equal(t, "LAqUlngMIQkIUjXMUreyu3qn", signer.KeyID())
+	for _, tt := range []struct {
+		method  string
+		path    string
+		expires int64
+		body    string
+		want    string
+	}{
+		{"GET", "/api/v1/instrument", 1518064236, "",
+			"c7682d435d0cfe87c16098df34ef2eb5a549d4c5a3c2b1f0f77b8af73423bf00"},
+		{"GET", "/api/v1/instrument?filter=%7B%22symbol%22%3A+%22XBTM15%22%7D", 1518064237, "",
+			"e2f422547eecb5b3cb29ade2127e21b858b235b386bfa45e1c1756eb3383919f"},
+		{"POST", "/api/v1/order", 1518064238, `{"symbol":"XBTM15","price":219.0,"clOrdID":"mm_bitmex_1a/oemUeQ4CAJZgP3fjHsA","orderQty":98}`,
+			"1749cd2ccae4aa49048ae09f0b95110cee706e0944e6a14ad0b3a8cb45bd336b"},
+	} {
+		got, err := signer.Sign(tt.method, tt.path, tt.expires, []byte(tt.body))
+		assert.NoError(t, err, tt.path)
+		assert.Equal(t, tt.want, got, "%s %s", tt.method, tt.path)
+	}
+}